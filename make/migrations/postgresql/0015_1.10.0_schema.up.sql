@@ -0,0 +1,124 @@
+/* add quota history table */
+CREATE TABLE quota_history
+(
+  id            SERIAL PRIMARY KEY NOT NULL,
+  reference     VARCHAR(255)       NOT NULL,
+  reference_id  VARCHAR(255)       NOT NULL,
+  action        VARCHAR(32)        NOT NULL,
+  operator      VARCHAR(255)       NOT NULL,
+  old_value     JSONB              NOT NULL,
+  new_value     JSONB              NOT NULL,
+  creation_time timestamp default CURRENT_TIMESTAMP
+);
+
+CREATE INDEX quota_history_reference_idx ON quota_history (reference, reference_id);
+
+/* add the tables of the postgresql backend of job service */
+CREATE TABLE job_queue
+(
+  id          SERIAL PRIMARY KEY NOT NULL,
+  job_id      VARCHAR(64)        NOT NULL,
+  job_name    VARCHAR(255)       NOT NULL,
+  args        JSONB,
+  unique_key  VARCHAR(64),
+  run_at      BIGINT             NOT NULL,
+  enqueued_at BIGINT             NOT NULL,
+  fails       INT                NOT NULL DEFAULT 0,
+  last_err    TEXT,
+  failed_at   BIGINT             NOT NULL DEFAULT 0,
+  claimed_by  VARCHAR(64),
+  claimed_at  BIGINT             NOT NULL DEFAULT 0,
+  UNIQUE (job_id, run_at)
+);
+
+CREATE INDEX job_queue_run_at_idx ON job_queue (run_at);
+CREATE UNIQUE INDEX job_queue_unique_key_idx ON job_queue (unique_key) WHERE unique_key IS NOT NULL;
+
+CREATE TABLE job_periodic_policy
+(
+  id               SERIAL PRIMARY KEY NOT NULL,
+  policy_id        VARCHAR(64)        NOT NULL,
+  job_name         VARCHAR(255)       NOT NULL,
+  cron_spec        VARCHAR(255)       NOT NULL,
+  time_zone        VARCHAR(64)        NOT NULL DEFAULT '',
+  job_params       JSONB,
+  web_hook_url     TEXT,
+  paused           BOOLEAN            NOT NULL DEFAULT FALSE,
+  creation_time    timestamp default CURRENT_TIMESTAMP,
+  UNIQUE (policy_id)
+);
+
+/* add the table of the scanner registrations */
+CREATE TABLE scanner_registration
+(
+  id               SERIAL PRIMARY KEY NOT NULL,
+  uuid             VARCHAR(64)        NOT NULL,
+  name             VARCHAR(128)       NOT NULL,
+  description      VARCHAR(1024)      NULL,
+  url              VARCHAR(256)       NOT NULL,
+  auth             VARCHAR(32)        NOT NULL DEFAULT '',
+  access_cred      VARCHAR(512)       NULL,
+  skip_cert_verify BOOLEAN            NOT NULL DEFAULT FALSE,
+  disabled         BOOLEAN            NOT NULL DEFAULT FALSE,
+  is_default       BOOLEAN            NOT NULL DEFAULT FALSE,
+  creation_time    timestamp default CURRENT_TIMESTAMP,
+  update_time      timestamp default CURRENT_TIMESTAMP,
+  UNIQUE (uuid),
+  UNIQUE (name),
+  UNIQUE (url)
+);
+
+/* keep the scanner and the report of the scan overview */
+ALTER TABLE img_scan_overview ADD COLUMN scanner_uuid VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE img_scan_overview ADD COLUMN report TEXT;
+
+/* add the table of the components found in the scanned images, which make up the software bill of materials */
+CREATE TABLE img_component
+(
+  id            SERIAL PRIMARY KEY NOT NULL,
+  image_digest  VARCHAR(128)       NOT NULL,
+  name          VARCHAR(255)       NOT NULL,
+  version       VARCHAR(255)       NOT NULL DEFAULT '',
+  creation_time timestamp default CURRENT_TIMESTAMP,
+  UNIQUE (image_digest, name, version)
+);
+
+CREATE INDEX img_component_name_idx ON img_component (name, version);
+
+/* add the table of the vulnerabilities found in the scanned images, which indexes the images by CVE */
+CREATE TABLE img_vulnerability
+(
+  id            SERIAL PRIMARY KEY NOT NULL,
+  image_digest  VARCHAR(128)       NOT NULL,
+  cve_id        VARCHAR(128)       NOT NULL,
+  package       VARCHAR(255)       NOT NULL DEFAULT '',
+  version       VARCHAR(255)       NOT NULL DEFAULT '',
+  fix_version   VARCHAR(255)       NOT NULL DEFAULT '',
+  severity      INT                NOT NULL DEFAULT 0,
+  creation_time timestamp default CURRENT_TIMESTAMP,
+  UNIQUE (image_digest, cve_id, package, version)
+);
+
+CREATE INDEX img_vulnerability_cve_idx ON img_vulnerability (cve_id);
+/* the images scanned before are indexed by the core service once it's started */
+ALTER TABLE img_scan_overview ADD COLUMN vuln_indexed BOOLEAN NOT NULL DEFAULT FALSE;
+
+/* add the table of the quarantine states of the images pushed to the projects with quarantine on push enabled */
+CREATE TABLE img_quarantine
+(
+  id            SERIAL PRIMARY KEY NOT NULL,
+  repository    VARCHAR(256)       NOT NULL,
+  digest        VARCHAR(128)       NOT NULL,
+  tag           VARCHAR(128)       NOT NULL DEFAULT '',
+  status        VARCHAR(32)        NOT NULL,
+  reason        TEXT,
+  creation_time timestamp default CURRENT_TIMESTAMP,
+  update_time   timestamp default CURRENT_TIMESTAMP,
+  UNIQUE (repository, digest)
+);
+
+/* the notification policies with project ID 0 are the system level ones, which receive the events of the projects
+   matching their project pattern, so the one policy per project constraint excludes them */
+ALTER TABLE notification_policy ADD COLUMN project_pattern varchar(256);
+ALTER TABLE notification_policy DROP CONSTRAINT unique_project_id;
+CREATE UNIQUE INDEX unique_project_id ON notification_policy (project_id) WHERE project_id != 0;
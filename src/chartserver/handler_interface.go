@@ -85,6 +85,24 @@ type ServiceHandler interface {
 	// If succeed, a unsigned integer with nil error will be returned;
 	// otherwise, a non-nil error will be got.
 	GetCountOfCharts(namespaces []string) (uint64, error)
+
+	// GetChartVersionSize returns the size of the chart package of the specified chart version.
+	//
+	// namespace string: the chart namespace.
+	// chartName string: the name of the chart, e.g: "harbor"
+	// version string: the SemVer version of the chart, e.g: "0.2.0"
+	//
+	// If succeed, the size in bytes with nil error will be returned;
+	// otherwise, a non-nil error will be got.
+	GetChartVersionSize(namespace, chartName, version string) (int64, error)
+
+	// GetSizeOfCharts calculates and returns the total size of the chart packages under the specified namespace.
+	//
+	// namespace string: the chart namespace.
+	//
+	// If succeed, the total size in bytes with nil error will be returned;
+	// otherwise, a non-nil error will be got.
+	GetSizeOfCharts(namespace string) (int64, error)
 }
 
 // ProxyTrafficHandler defines the handler methods to handle the proxy traffic.
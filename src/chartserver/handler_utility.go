@@ -8,6 +8,7 @@ import (
 
 	"github.com/pkg/errors"
 	"k8s.io/helm/cmd/helm/search"
+	helm_repo "k8s.io/helm/pkg/repo"
 
 	hlog "github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/goharbor/harbor/src/core/config"
@@ -32,6 +33,44 @@ func (c *Controller) GetCountOfCharts(namespaces []string) (uint64, error) {
 	return (uint64)(len(indexFile.Entries)), nil
 }
 
+// GetChartVersionSize returns the size of the chart package of the specified chart version.
+// See @ServiceHandler.GetChartVersionSize
+func (c *Controller) GetChartVersionSize(namespace, chartName, version string) (int64, error) {
+	chartV, err := c.GetChartVersion(namespace, chartName, version)
+	if err != nil {
+		return 0, err
+	}
+
+	return c.getChartPackageSize(namespace, chartV)
+}
+
+// GetSizeOfCharts calculates and returns the total size of the chart packages under the specified namespace.
+// See @ServiceHandler.GetSizeOfCharts
+func (c *Controller) GetSizeOfCharts(namespace string) (int64, error) {
+	charts, err := c.ListCharts(namespace)
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+	for _, chart := range charts {
+		versions, err := c.GetChart(namespace, chart.Name)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, version := range versions {
+			s, err := c.getChartPackageSize(namespace, &version.ChartVersion)
+			if err != nil {
+				return 0, err
+			}
+			size += s
+		}
+	}
+
+	return size, nil
+}
+
 // DeleteChart deletes all the chart versions of the specified chart under the namespace.
 // See @ServiceHandler.DeleteChart
 func (c *Controller) DeleteChart(namespace, chartName string) error {
@@ -231,3 +270,17 @@ func (c *Controller) getChartVersionContent(namespace string, subPath string) ([
 	url = fmt.Sprintf("%s/%s", c.backendServerAddress.String(), url)
 	return c.apiClient.GetContent(url)
 }
+
+// Get the size of the chart package of the chart version
+func (c *Controller) getChartPackageSize(namespace string, chartV *helm_repo.ChartVersion) (int64, error) {
+	if len(chartV.URLs) == 0 {
+		return 0, errors.Errorf("no package found for chart %s:%s", chartV.Name, chartV.Version)
+	}
+
+	content, err := c.getChartVersionContent(namespace, chartV.URLs[0])
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(content)), nil
+}
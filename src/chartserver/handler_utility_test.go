@@ -2,6 +2,8 @@ package chartserver
 
 import (
 	"testing"
+
+	htesting "github.com/goharbor/harbor/src/testing"
 )
 
 // Test the function GetCountOfCharts
@@ -36,6 +38,28 @@ func TestGetCountOfCharts(t *testing.T) {
 	}
 }
 
+// Test the function GetChartVersionSize
+func TestGetChartVersionSize(t *testing.T) {
+	s, c, err := createMockObjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	size, err := c.GetChartVersionSize("repo1", "harbor", "0.2.0")
+	if err != nil {
+		t.Fatalf("expect nil error but got %s", err)
+	}
+
+	if size != int64(len(htesting.HelmChartContent)) {
+		t.Fatalf("expect %d but got %d", len(htesting.HelmChartContent), size)
+	}
+
+	if _, err := c.GetChartVersionSize("repo1", "harbor", "1.0.0"); err == nil {
+		t.Fatal("expect non-nil error but got nil one")
+	}
+}
+
 // Test the function DeleteChart
 func TestDeleteChart(t *testing.T) {
 	s, c, err := createMockObjects()
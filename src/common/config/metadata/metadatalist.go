@@ -154,6 +154,10 @@ var (
 		{Name: common.RobotTokenDuration, Scope: UserScope, Group: BasicGroup, EnvKey: "ROBOT_TOKEN_DURATION", DefaultValue: "43200", ItemType: &IntType{}, Editable: true},
 		{Name: common.NotificationEnable, Scope: UserScope, Group: BasicGroup, EnvKey: "NOTIFICATION_ENABLE", DefaultValue: "true", ItemType: &BoolType{}, Editable: true},
 
+		// rescan the images when the vulnerability database is updated, the images not pulled in the days are skipped, 0 means no limit
+		{Name: common.AutoRescanEnabled, Scope: UserScope, Group: BasicGroup, EnvKey: "AUTO_RESCAN_ENABLED", DefaultValue: "false", ItemType: &BoolType{}, Editable: true},
+		{Name: common.AutoRescanPulledWithinDays, Scope: UserScope, Group: BasicGroup, EnvKey: "AUTO_RESCAN_PULLED_WITHIN_DAYS", DefaultValue: "0", ItemType: &IntType{}, Editable: true},
+
 		{Name: common.QuotaPerProjectEnable, Scope: UserScope, Group: QuotaGroup, EnvKey: "QUOTA_PER_PROJECT_ENABLE", DefaultValue: "true", ItemType: &BoolType{}, Editable: true},
 		{Name: common.CountPerProject, Scope: UserScope, Group: QuotaGroup, EnvKey: "COUNT_PER_PROJECT", DefaultValue: "-1", ItemType: &QuotaType{}, Editable: true},
 		{Name: common.StoragePerProject, Scope: UserScope, Group: QuotaGroup, EnvKey: "STORAGE_PER_PROJECT", DefaultValue: "-1", ItemType: &QuotaType{}, Editable: true},
@@ -156,6 +156,10 @@ const (
 	CountPerProject       = "count_per_project"
 	StoragePerProject     = "storage_per_project"
 
+	// Settings of the automatic rescan when the vulnerability database of the scanner is updated
+	AutoRescanEnabled          = "auto_rescan_enabled"
+	AutoRescanPulledWithinDays = "auto_rescan_pulled_within_days"
+
 	// ForeignLayer
 	ForeignLayer = "application/vnd.docker.image.rootfs.foreign.diff.tar.gzip"
 )
@@ -22,6 +22,14 @@ import (
 	"github.com/goharbor/harbor/src/common/models"
 )
 
+// artifactOrderMap maps the sort field to the ordering columns of the artifacts,
+// the ID is the last one to keep the order stable when paging
+var artifactOrderMap = map[string][]string{
+	"repo":  {"Repo", "Tag", "ID"},
+	"+repo": {"Repo", "Tag", "ID"},
+	"-repo": {"-Repo", "-Tag", "-ID"},
+}
+
 // AddArtifact ...
 func AddArtifact(af *models.Artifact) (int64, error) {
 	now := time.Now()
@@ -86,6 +94,9 @@ func DeleteArtifactByTag(projectID int64, repo, tag string) error {
 // ListArtifacts list artifacts according to the query conditions
 func ListArtifacts(query *models.ArtifactQuery) ([]*models.Artifact, error) {
 	qs := getArtifactQuerySetter(query)
+	if order, ok := artifactOrderMap[query.Sort]; ok {
+		qs = qs.OrderBy(order...)
+	}
 	if query.Size > 0 {
 		qs = qs.Limit(query.Size)
 		if query.Page > 0 {
@@ -160,6 +160,28 @@ func TestListArtifacts(t *testing.T) {
 	})
 	require.Nil(t, err)
 	assert.Equal(t, 1, len(afs))
+
+	// sort by repository and tag
+	for _, tag := range []string{"v3.0", "v1.0"} {
+		_, err = AddArtifact(&models.Artifact{
+			PID:    1,
+			Repo:   "TestListArtifacts",
+			Tag:    tag,
+			Digest: "TestListArtifacts",
+			Kind:   "image",
+		})
+		require.Nil(t, err)
+	}
+
+	afs, err = ListArtifacts(&models.ArtifactQuery{
+		PID:     1,
+		Repo:    "TestListArtifacts",
+		Sorting: models.Sorting{Sort: "repo"},
+	})
+	require.Nil(t, err)
+	require.Equal(t, 2, len(afs))
+	assert.Equal(t, "v1.0", afs[0].Tag)
+	assert.Equal(t, "v3.0", afs[1].Tag)
 }
 
 func TestGetTotalOfArtifacts(t *testing.T) {
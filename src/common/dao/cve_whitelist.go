@@ -62,3 +62,21 @@ func GetCVEWhitelist(pid int64) (*models.CVEWhitelist, error) {
 	r[0].Items = items
 	return r[0], nil
 }
+
+// ListCVEWhitelists returns the CVE whitelists of the system and all the projects, ordered by project ID
+func ListCVEWhitelists() ([]*models.CVEWhitelist, error) {
+	r := []*models.CVEWhitelist{}
+	_, err := GetOrmer().QueryTable(&models.CVEWhitelist{}).OrderBy("ProjectID").All(&r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CVE whitelists, error: %v", err)
+	}
+	for _, l := range r {
+		items := []models.CVEWhitelistItem{}
+		if err := json.Unmarshal([]byte(l.ItemsText), &items); err != nil {
+			log.Errorf("Failed to decode item list, err: %v, text: %s", err, l.ItemsText)
+			return nil, err
+		}
+		l.Items = items
+	}
+	return r, nil
+}
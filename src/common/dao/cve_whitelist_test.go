@@ -51,5 +51,13 @@ func TestUpdateAndGetCVEWhitelist(t *testing.T) {
 	_, err = UpdateCVEWhitelist(in3)
 	require.Nil(t, err)
 
+	all, err := ListCVEWhitelists()
+	require.Nil(t, err)
+	if assert.Len(t, all, 2) {
+		assert.Equal(t, int64(0), all[0].ProjectID)
+		assert.Equal(t, sysCVEs, all[0].Items)
+		assert.Equal(t, int64(3), all[1].ProjectID)
+	}
+
 	require.Nil(t, ClearTable("cve_whitelist"))
 }
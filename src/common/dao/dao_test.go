@@ -874,6 +874,190 @@ func TestImgScanOverview(t *testing.T) {
 	assert.Equal(pk, res.DetailsKey)
 	assert.Equal(int(models.SevMedium), res.Sev)
 	assert.Equal(2, res.CompOverview.Summary[0].Count)
+	err = SetImgScanReport(digest, "scanner-uuid", `{"severity":"Medium"}`)
+	assert.Nil(err)
+	res, err = GetImgScanOverview(digest)
+	assert.Nil(err)
+	assert.Equal("scanner-uuid", res.ScannerUUID)
+	assert.Equal(`{"severity":"Medium"}`, res.Report)
+}
+
+func TestImgComponents(t *testing.T) {
+	assert := assert.New(t)
+	digest := "sha256:0204dc6e09fa57ab99ac40e415eb637d62c8b2571ecbbc9ca0eb5e2ad2b5c56f"
+	err := SetImgComponents(digest, []*models.ImgComponent{
+		{Name: "openssl", Version: "1.1.0l"},
+		{Name: "bash", Version: "4.4"},
+	})
+	assert.Nil(err)
+	err = SetImgComponents(digest, []*models.ImgComponent{
+		{Name: "openssl", Version: "1.1.1d"},
+		{Name: "bash", Version: "4.4"},
+	})
+	assert.Nil(err)
+	res, err := GetImgComponents(digest)
+	assert.Nil(err)
+	if assert.Len(res, 2) {
+		assert.Equal("bash", res[0].Name)
+		assert.Equal("1.1.1d", res[1].Version)
+	}
+
+	repo := "library/component-test"
+	id, err := AddArtifact(&models.Artifact{PID: 1, Repo: repo, Tag: "latest", Digest: digest, Kind: "Docker-Image"})
+	assert.Nil(err)
+	defer DeleteArtifact(id)
+
+	query := &models.ComponentQuery{Name: "openssl"}
+	total, err := GetTotalOfComponentImages(query)
+	assert.Nil(err)
+	assert.Equal(int64(1), total)
+	images, err := ListComponentImages(query)
+	assert.Nil(err)
+	if assert.Len(images, 1) {
+		assert.Equal(repo, images[0].Repository)
+		assert.Equal("latest", images[0].Tag)
+		assert.Equal(int64(1), images[0].ProjectID)
+	}
+
+	images, err = ListComponentImages(&models.ComponentQuery{Name: "openssl", Version: "1.1.0l"})
+	assert.Nil(err)
+	assert.Len(images, 0)
+	images, err = ListComponentImages(&models.ComponentQuery{Name: "openssl", ProjectIDs: []int64{}})
+	assert.Nil(err)
+	assert.Len(images, 0)
+	assert.Nil(ClearTable(models.ImgComponentTable))
+}
+
+func TestImgVulnerabilities(t *testing.T) {
+	assert := assert.New(t)
+	repo := "library/cve-impact-test"
+	digest1 := "sha256:1111dc6e09fa57ab99ac40e415eb637d62c8b2571ecbbc9ca0eb5e2ad2b5c56f"
+	digest2 := "sha256:2222dc6e09fa57ab99ac40e415eb637d62c8b2571ecbbc9ca0eb5e2ad2b5c56f"
+	digest3 := "sha256:3333dc6e09fa57ab99ac40e415eb637d62c8b2571ecbbc9ca0eb5e2ad2b5c56f"
+
+	// tag "v1" was scanned with digest1 and then re-pushed with digest2, digest3 is scanned but not indexed
+	for _, o := range []*models.ImgScanOverview{
+		{Digest: digest1, Status: models.JobFinished, Report: "{}"},
+		{Digest: digest3, Status: models.JobFinished, Report: "{}"},
+	} {
+		_, err := GetOrmer().Insert(o)
+		assert.Nil(err)
+	}
+	defer GetOrmer().Raw(`delete from img_scan_overview where image_digest in (?, ?)`, digest1, digest3).Exec()
+	for _, af := range []*models.Artifact{
+		{PID: 1, Repo: repo, Tag: "v1", Digest: digest2, Kind: "Docker-Image"},
+		{PID: 1, Repo: repo, Tag: "v2", Digest: digest1, Kind: "Docker-Image"},
+	} {
+		id, err := AddArtifact(af)
+		assert.Nil(err)
+		defer DeleteArtifact(id)
+	}
+
+	assert.Nil(SetImgVulnerabilities(digest1, []*models.ImgVulnerability{
+		{CVEID: "CVE-2019-0001", Package: "openssl", Version: "1.1.0l", FixVersion: "1.1.1d", Severity: int(models.SevHigh)},
+		{CVEID: "CVE-2019-0002", Package: "bash", Version: "4.4", Severity: int(models.SevLow)},
+	}))
+	assert.Nil(SetImgVulnerabilities(digest2, []*models.ImgVulnerability{
+		{CVEID: "CVE-2019-0002", Package: "bash", Version: "4.4", Severity: int(models.SevLow)},
+	}))
+	defer ClearTable(models.ImgVulnerabilityTable)
+
+	vulns, err := GetImgVulnerabilities(digest1)
+	assert.Nil(err)
+	assert.Len(vulns, 2)
+
+	digests, err := ListImgDigestsToIndex()
+	assert.Nil(err)
+	assert.Contains(digests, digest3)
+	assert.NotContains(digests, digest1)
+
+	query := &models.CVEQuery{CVEID: "CVE-2019-0001"}
+	total, err := GetTotalOfAffectedImages(query)
+	assert.Nil(err)
+	assert.Equal(int64(1), total)
+	images, err := ListAffectedImages(query)
+	assert.Nil(err)
+	if assert.Len(images, 1) {
+		assert.Equal("v2", images[0].Tag)
+		assert.Equal(int64(1), images[0].ProjectID)
+		assert.Equal("1.1.1d", images[0].FixVersion)
+	}
+
+	images, err = ListAffectedImages(&models.CVEQuery{CVEID: "CVE-2019-0001", ProjectIDs: []int64{}})
+	assert.Nil(err)
+	assert.Len(images, 0)
+
+	impacts, err := ListTopCVEs(&models.CVEQuery{})
+	assert.Nil(err)
+	if assert.Len(impacts, 2) {
+		assert.Equal("CVE-2019-0002", impacts[0].CVEID)
+		assert.Equal(int64(2), impacts[0].Images)
+		assert.Equal(int64(2), impacts[0].Tags)
+		assert.Equal(int64(1), impacts[0].Repositories)
+	}
+
+	impacts, err = ListTopCVEs(&models.CVEQuery{Severity: int(models.SevHigh)})
+	assert.Nil(err)
+	if assert.Len(impacts, 1) {
+		assert.Equal("CVE-2019-0001", impacts[0].CVEID)
+	}
+}
+
+func TestListImagesToRescan(t *testing.T) {
+	assert := assert.New(t)
+	repo := "library/rescan-test"
+	digest1 := "sha256:3333dc6e09fa57ab99ac40e415eb637d62c8b2571ecbbc9ca0eb5e2ad2b5c56f"
+	digest2 := "sha256:4444dc6e09fa57ab99ac40e415eb637d62c8b2571ecbbc9ca0eb5e2ad2b5c56f"
+	digest3 := "sha256:5555dc6e09fa57ab99ac40e415eb637d62c8b2571ecbbc9ca0eb5e2ad2b5c56f"
+	o := GetOrmer()
+	defer func() {
+		o.Raw(`delete from img_scan_job where repository = ?`, repo).Exec()
+		o.Raw(`delete from img_scan_overview where image_digest in (?, ?, ?)`, digest1, digest2, digest3).Exec()
+		o.Raw(`delete from access_log where repo_name = ?`, repo).Exec()
+	}()
+
+	for _, j := range []models.ScanJob{
+		{Repository: repo, Tag: "v1", Digest: digest1, Status: models.JobFinished},
+		{Repository: repo, Tag: "v2", Digest: digest2, Status: models.JobFinished},
+		{Repository: repo, Tag: "v3", Digest: digest3, Status: models.JobRunning},
+	} {
+		id, err := AddScanJob(j)
+		assert.Nil(err)
+		assert.Nil(SetScanJobForImg(j.Digest, id))
+	}
+	assert.Nil(SetImgScanReport(digest1, "clair", ""))
+	assert.Nil(SetImgScanReport(digest2, "trivy", "{}"))
+
+	query := &models.RescanQuery{
+		ScannerUUIDs:  []string{"clair", ""},
+		ScannedBefore: time.Now().Add(time.Minute),
+	}
+	jobs, err := ListImagesToRescan(query)
+	assert.Nil(err)
+	if assert.Len(jobs, 1) {
+		assert.Equal("v1", jobs[0].Tag)
+		assert.Equal(digest1, jobs[0].Digest)
+	}
+
+	jobs, err = ListImagesToRescan(&models.RescanQuery{ScannedBefore: time.Now().Add(-time.Hour)})
+	assert.Nil(err)
+	assert.Len(jobs, 0)
+
+	query.PulledAfter = time.Now().Add(-time.Hour)
+	jobs, err = ListImagesToRescan(query)
+	assert.Nil(err)
+	assert.Len(jobs, 0)
+	assert.Nil(AddAccessLog(models.AccessLog{
+		Username:  "admin",
+		ProjectID: 1,
+		RepoName:  repo,
+		RepoTag:   "v1",
+		Operation: "pull",
+		OpTime:    time.Now(),
+	}))
+	jobs, err = ListImagesToRescan(query)
+	assert.Nil(err)
+	assert.Len(jobs, 1)
 }
 
 func TestVulnTimestamp(t *testing.T) {
@@ -0,0 +1,73 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/goharbor/harbor/src/common/models"
+)
+
+// AddImgQuarantine quarantines the image if it has no quarantine record yet,
+// it returns whether the record is added.
+func AddImgQuarantine(q *models.ImgQuarantine) (bool, error) {
+	now := time.Now()
+	q.CreationTime = now
+	q.UpdateTime = now
+	created, _, err := GetOrmer().ReadOrCreate(q, "Repository", "Digest")
+	return created, err
+}
+
+// GetImgQuarantine returns the quarantine record of the image, nil is returned if the image has no record
+func GetImgQuarantine(repository, digest string) (*models.ImgQuarantine, error) {
+	q := []*models.ImgQuarantine{}
+	_, err := GetOrmer().QueryTable(models.ImgQuarantineTable).
+		Filter("repository", repository).
+		Filter("digest", digest).
+		All(&q)
+	if err != nil || len(q) == 0 {
+		return nil, err
+	}
+	return q[0], nil
+}
+
+// UpdateImgQuarantineStatus updates the status and the reason of the quarantine record
+func UpdateImgQuarantineStatus(id int64, status, reason string) error {
+	q := &models.ImgQuarantine{
+		ID:         id,
+		Status:     status,
+		Reason:     reason,
+		UpdateTime: time.Now(),
+	}
+	_, err := GetOrmer().Update(q, "Status", "Reason", "UpdateTime")
+	return err
+}
+
+// DeleteImgQuarantine deletes the quarantine record of the image
+func DeleteImgQuarantine(repository, digest string) error {
+	_, err := GetOrmer().QueryTable(models.ImgQuarantineTable).
+		Filter("repository", repository).
+		Filter("digest", digest).
+		Delete()
+	return err
+}
+
+// DeleteImgQuarantines deletes the quarantine records of the repository
+func DeleteImgQuarantines(repository string) error {
+	_, err := GetOrmer().QueryTable(models.ImgQuarantineTable).
+		Filter("repository", repository).
+		Delete()
+	return err
+}
@@ -0,0 +1,78 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImgQuarantineDaoSuite struct {
+	suite.Suite
+}
+
+func (suite *ImgQuarantineDaoSuite) TearDownTest() {
+	ClearTable(models.ImgQuarantineTable)
+}
+
+func (suite *ImgQuarantineDaoSuite) TestImgQuarantine() {
+	created, err := AddImgQuarantine(&models.ImgQuarantine{
+		Repository: "library/quarantine",
+		Digest:     "sha256:quarantine",
+		Tag:        "v1",
+		Status:     models.QuarantineStatusQuarantined,
+	})
+	suite.Require().Nil(err)
+	suite.True(created)
+
+	q, err := GetImgQuarantine("library/quarantine", "sha256:quarantine")
+	suite.Require().Nil(err)
+	suite.Require().NotNil(q)
+	suite.Equal("v1", q.Tag)
+	suite.True(q.Blocked())
+
+	suite.Require().Nil(UpdateImgQuarantineStatus(q.ID, models.QuarantineStatusPromoted, "passed"))
+
+	// the image tagged with another tag keeps its state
+	created, err = AddImgQuarantine(&models.ImgQuarantine{
+		Repository: "library/quarantine",
+		Digest:     "sha256:quarantine",
+		Tag:        "v2",
+		Status:     models.QuarantineStatusQuarantined,
+	})
+	suite.Require().Nil(err)
+	suite.False(created)
+	q, err = GetImgQuarantine("library/quarantine", "sha256:quarantine")
+	suite.Require().Nil(err)
+	suite.Equal(models.QuarantineStatusPromoted, q.Status)
+	suite.Equal("passed", q.Reason)
+	suite.False(q.Blocked())
+
+	suite.Require().Nil(DeleteImgQuarantine("library/quarantine", "sha256:quarantine"))
+	q, err = GetImgQuarantine("library/quarantine", "sha256:quarantine")
+	suite.Require().Nil(err)
+	suite.Nil(q)
+
+	suite.Require().Nil(DeleteImgQuarantines("library/quarantine"))
+	q, err = GetImgQuarantine("library/quarantine", "sha256:quarantine")
+	suite.Require().Nil(err)
+	suite.Nil(q)
+}
+
+func TestRunImgQuarantineDaoSuite(t *testing.T) {
+	suite.Run(t, new(ImgQuarantineDaoSuite))
+}
@@ -17,6 +17,14 @@ func CreateOrUpdateJobLog(log *models.JobLog) (int64, error) {
 	return count, nil
 }
 
+// AppendJobLog appends the content to the log of the job, the log is created if it doesn't exist
+func AppendJobLog(uuid string, content string) error {
+	sql := `insert into job_log (job_uuid, content) values (?, ?)
+		on conflict (job_uuid) do update set content = job_log.content || excluded.content`
+	_, err := GetOrmer().Raw(sql, uuid, content).Exec()
+	return err
+}
+
 // GetJobLog ...
 func GetJobLog(uuid string) (*models.JobLog, error) {
 	o := GetOrmer()
@@ -38,3 +46,12 @@ func DeleteJobLogsBefore(t time.Time) (int64, error) {
 	}
 	return res.RowsAffected()
 }
+
+// DeleteJobLogs deletes the logs of the specified jobs
+func DeleteJobLogs(uuids ...string) (int64, error) {
+	if len(uuids) == 0 {
+		return 0, nil
+	}
+
+	return GetOrmer().QueryTable(&models.JobLog{}).Filter("UUID__in", uuids).Delete()
+}
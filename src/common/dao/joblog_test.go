@@ -41,4 +41,27 @@ func TestMethodsOfJobLog(t *testing.T) {
 	count, err := DeleteJobLogsBefore(time.Now().Add(time.Duration(time.Minute)))
 	require.Nil(t, err)
 	assert.Equal(t, int64(1), count)
+
+	// delete by uuid
+	_, err = CreateOrUpdateJobLog(&models.JobLog{
+		UUID:         uuid,
+		CreationTime: now,
+		Content:      content,
+	})
+	require.Nil(t, err)
+	count, err = DeleteJobLogs(uuid)
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestAppendJobLog(t *testing.T) {
+	uuid := "uuid_for_appending_test"
+	defer DeleteJobLogs(uuid)
+
+	require.Nil(t, AppendJobLog(uuid, "line1\n"))
+	require.Nil(t, AppendJobLog(uuid, "line2\n"))
+
+	log, err := GetJobLog(uuid)
+	require.Nil(t, err)
+	assert.Equal(t, "line1\nline2\n", log.Content)
 }
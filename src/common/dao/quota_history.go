@@ -0,0 +1,78 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/astaxie/beego/orm"
+	"github.com/goharbor/harbor/src/common/models"
+)
+
+// AddQuotaHistory add quota history to the database.
+func AddQuotaHistory(history *models.QuotaHistory) (int64, error) {
+	history.CreationTime = time.Now()
+	return GetOrmer().Insert(history)
+}
+
+// ListQuotaHistories returns quota histories by query, the latest one comes first.
+func ListQuotaHistories(query ...*models.QuotaHistoryQuery) ([]*models.QuotaHistory, error) {
+	qs := quotaHistoryQueryConditions(query...).OrderBy("-creation_time", "-id")
+
+	if len(query) > 0 && query[0] != nil {
+		page, size := query[0].Page, query[0].Size
+		if size > 0 {
+			qs = qs.Limit(size)
+			if page > 0 {
+				qs = qs.Offset(size * (page - 1))
+			}
+		}
+	}
+
+	var histories []*models.QuotaHistory
+	if _, err := qs.All(&histories); err != nil {
+		return nil, err
+	}
+
+	return histories, nil
+}
+
+// GetTotalOfQuotaHistories returns total of quota histories
+func GetTotalOfQuotaHistories(query ...*models.QuotaHistoryQuery) (int64, error) {
+	return quotaHistoryQueryConditions(query...).Count()
+}
+
+func quotaHistoryQueryConditions(query ...*models.QuotaHistoryQuery) orm.QuerySeter {
+	qs := GetOrmer().QueryTable(&models.QuotaHistory{})
+	if len(query) == 0 || query[0] == nil {
+		return qs
+	}
+
+	q := query[0]
+	if q.Reference != "" {
+		qs = qs.Filter("reference", q.Reference)
+	}
+	if q.ReferenceID != "" {
+		qs = qs.Filter("reference_id", q.ReferenceID)
+	}
+	if q.Action != "" {
+		qs = qs.Filter("action", q.Action)
+	}
+	if q.Operator != "" {
+		qs = qs.Filter("operator", q.Operator)
+	}
+
+	return qs
+}
@@ -0,0 +1,63 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type QuotaHistoryDaoSuite struct {
+	suite.Suite
+}
+
+func (suite *QuotaHistoryDaoSuite) TearDownTest() {
+	ClearTable("quota_history")
+}
+
+func (suite *QuotaHistoryDaoSuite) TestListQuotaHistories() {
+	for i, operator := range []string{"admin", "admin", "user"} {
+		_, err := AddQuotaHistory(&models.QuotaHistory{
+			Reference:   quotaReference,
+			ReferenceID: "1",
+			Action:      models.QuotaHistoryActionUpdate,
+			Operator:    operator,
+			OldValue:    quotaHard.String(),
+			NewValue:    models.QuotaHard{"storage": int64(2048 * (i + 1))}.String(),
+		})
+		suite.Nil(err)
+	}
+
+	histories, err := ListQuotaHistories(&models.QuotaHistoryQuery{Reference: quotaReference, ReferenceID: "1"})
+	suite.Nil(err)
+	if suite.Len(histories, 3) {
+		// the latest one comes first
+		suite.Equal("user", histories[0].Operator)
+	}
+
+	total, err := GetTotalOfQuotaHistories(&models.QuotaHistoryQuery{Operator: "admin"})
+	suite.Nil(err)
+	suite.Equal(int64(2), total)
+
+	histories, err = ListQuotaHistories(&models.QuotaHistoryQuery{Pagination: models.Pagination{Page: 2, Size: 2}})
+	suite.Nil(err)
+	suite.Len(histories, 1)
+}
+
+func TestRunQuotaHistoryDaoSuite(t *testing.T) {
+	suite.Run(t, new(QuotaHistoryDaoSuite))
+}
@@ -181,6 +181,279 @@ func UpdateImgScanOverview(digest, detailsKey string, sev models.Severity, compO
 	return nil
 }
 
+// SetImgScanReport keeps the scanner and the vulnerability report of the image with the digest
+func SetImgScanReport(digest, scannerUUID, report string) error {
+	o := GetOrmer()
+	rec, err := GetImgScanOverview(digest)
+	if err != nil {
+		return fmt.Errorf("Failed to getting scan_overview record for update: %v", err)
+	}
+	if rec == nil {
+		return fmt.Errorf("No scan_overview record for digest: %s", digest)
+	}
+	rec.ScannerUUID = scannerUUID
+	rec.Report = report
+	rec.UpdateTime = time.Now()
+
+	_, err = o.Update(rec, "ScannerUUID", "Report", "UpdateTime")
+	if err != nil {
+		return fmt.Errorf("Failed to update scan report with digest: %s, error: %v", digest, err)
+	}
+	return nil
+}
+
+// SetImgComponents replaces the components found in the image with the digest
+func SetImgComponents(digest string, components []*models.ImgComponent) error {
+	return WithTransaction(func(o orm.Ormer) error {
+		if _, err := o.QueryTable(models.ImgComponentTable).Filter("Digest", digest).Delete(); err != nil {
+			return err
+		}
+		if len(components) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		for _, c := range components {
+			c.Digest = digest
+			c.CreationTime = now
+		}
+		_, err := o.InsertMulti(100, components)
+		return err
+	})
+}
+
+// GetImgComponents returns the components found in the image with the digest, ordered by name and version
+func GetImgComponents(digest string) ([]*models.ImgComponent, error) {
+	var res []*models.ImgComponent
+	_, err := GetOrmer().QueryTable(models.ImgComponentTable).Filter("Digest", digest).
+		OrderBy("name", "version").All(&res)
+	return res, err
+}
+
+// SetImgVulnerabilities replaces the vulnerabilities found in the image with the digest
+// and marks the scan overview of the image as indexed
+func SetImgVulnerabilities(digest string, vulnerabilities []*models.ImgVulnerability) error {
+	return WithTransaction(func(o orm.Ormer) error {
+		if _, err := o.QueryTable(models.ImgVulnerabilityTable).Filter("Digest", digest).Delete(); err != nil {
+			return err
+		}
+		if _, err := o.Raw(`update img_scan_overview set vuln_indexed = true where image_digest = ?`, digest).Exec(); err != nil {
+			return err
+		}
+		if len(vulnerabilities) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		for _, v := range vulnerabilities {
+			v.Digest = digest
+			v.CreationTime = now
+		}
+		_, err := o.InsertMulti(100, vulnerabilities)
+		return err
+	})
+}
+
+// GetImgVulnerabilities returns the vulnerabilities found in the image with the digest, ordered by CVE ID and package
+func GetImgVulnerabilities(digest string) ([]*models.ImgVulnerability, error) {
+	var res []*models.ImgVulnerability
+	_, err := GetOrmer().QueryTable(models.ImgVulnerabilityTable).Filter("Digest", digest).
+		OrderBy("cve_id", "package", "version").All(&res)
+	return res, err
+}
+
+// ListImgDigestsToIndex returns the digests of the scanned images whose vulnerabilities are not indexed,
+// which are scanned before the index is introduced
+func ListImgDigestsToIndex() ([]string, error) {
+	digests := []string{}
+	sql := `select image_digest from img_scan_overview
+	where vuln_indexed = false and (coalesce(report, '') != '' or coalesce(details_key, '') != '')`
+	if _, err := GetOrmer().Raw(sql).QueryRows(&digests); err != nil {
+		return nil, err
+	}
+	return digests, nil
+}
+
+// GetTotalOfAffectedImages returns the total count of the records returned by ListAffectedImages
+func GetTotalOfAffectedImages(query *models.CVEQuery) (int64, error) {
+	sql, params := cveQueryConditions(query)
+	sql = `select count(*) ` + sql
+	var total int64
+	if err := GetOrmer().Raw(sql, params).QueryRow(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// ListAffectedImages returns the tags whose last scanned images have the vulnerability with the CVE ID of the query,
+// there is a record for each vulnerable package of the image
+func ListAffectedImages(query *models.CVEQuery) ([]*models.AffectedImage, error) {
+	images := []*models.AffectedImage{}
+	condition, params := cveQueryConditions(query)
+	sql := `select a.project_id, a.repo as repository, a.tag, a.digest, v.package, v.version, v.fix_version, v.severity ` +
+		condition + `order by a.repo, a.tag, v.package, v.version `
+	sql, params = paginateCVEQuery(sql, params, query)
+
+	if _, err := GetOrmer().Raw(sql, params).QueryRows(&images); err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// ListTopCVEs returns the vulnerabilities affecting the most images, the CVE ID of the query is ignored
+func ListTopCVEs(query *models.CVEQuery) ([]*models.CVEImpact, error) {
+	impacts := []*models.CVEImpact{}
+	q := *query
+	q.CVEID = ""
+	condition, params := cveQueryConditions(&q)
+	sql := `select v.cve_id, max(v.severity) as severity, count(distinct a.digest) as images,
+	count(distinct a.id) as tags, count(distinct a.repo) as repositories ` + condition +
+		`group by v.cve_id order by images desc, severity desc, v.cve_id `
+	sql, params = paginateCVEQuery(sql, params, &q)
+
+	if _, err := GetOrmer().Raw(sql, params).QueryRows(&impacts); err != nil {
+		return nil, err
+	}
+	return impacts, nil
+}
+
+// ListImagesToRescan returns the tags whose last scan was done by the scanner before the time in the query,
+// the tags being scanned are skipped. The ID, repository, tag and digest of the last scan job of the tags are returned.
+func ListImagesToRescan(query *models.RescanQuery) ([]*models.ScanJob, error) {
+	jobs := []*models.ScanJob{}
+	sql := `select j.id, j.repository, j.tag, j.digest
+	from img_scan_job j
+	join img_scan_overview o on o.image_digest = j.digest
+	where j.id in (select max(id) from img_scan_job group by repository, tag)
+	and j.status not in (?, ?)
+	and o.update_time < ? `
+	params := []interface{}{models.JobPending, models.JobRunning, query.ScannedBefore}
+
+	if len(query.ScannerUUIDs) > 0 {
+		sql += fmt.Sprintf(`and o.scanner_uuid in ( %s ) `, ParamPlaceholderForIn(len(query.ScannerUUIDs)))
+		params = append(params, query.ScannerUUIDs)
+	}
+	if !query.PulledAfter.IsZero() {
+		sql += `and exists (select 1 from access_log a where a.repo_name = j.repository
+		and a.repo_tag = j.tag and a.operation = 'pull' and a.op_time >= ?) `
+		params = append(params, query.PulledAfter)
+	}
+	sql += `order by j.repository, j.tag`
+
+	if _, err := GetOrmer().Raw(sql, params).QueryRows(&jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// cveQueryConditions joins the vulnerabilities with the artifacts by digest,
+// so only the images the tags currently refer to are counted
+func cveQueryConditions(query *models.CVEQuery) (string, []interface{}) {
+	params := []interface{}{}
+	sql := `from img_vulnerability v
+	join artifact a on a.digest = v.image_digest
+	where 1=1 `
+	if query == nil {
+		return sql, params
+	}
+
+	if len(query.CVEID) > 0 {
+		sql += `and v.cve_id = ? `
+		params = append(params, query.CVEID)
+	}
+	if query.Severity > 0 {
+		sql += `and v.severity >= ? `
+		params = append(params, query.Severity)
+	}
+	if query.ProjectIDs != nil {
+		if len(query.ProjectIDs) == 0 {
+			sql += `and 1=0 `
+		} else {
+			sql += fmt.Sprintf(`and a.project_id in ( %s ) `, ParamPlaceholderForIn(len(query.ProjectIDs)))
+			params = append(params, query.ProjectIDs)
+		}
+	}
+	return sql, params
+}
+
+func paginateCVEQuery(sql string, params []interface{}, query *models.CVEQuery) (string, []interface{}) {
+	if query == nil {
+		return sql, params
+	}
+	return paginate(sql, params, &query.Pagination)
+}
+
+func paginate(sql string, params []interface{}, p *models.Pagination) (string, []interface{}) {
+	if p == nil || p.Size <= 0 {
+		return sql, params
+	}
+	sql += `limit ? `
+	params = append(params, p.Size)
+	if p.Page > 0 {
+		sql += `offset ? `
+		params = append(params, p.Size*(p.Page-1))
+	}
+	return sql, params
+}
+
+// GetTotalOfComponentImages returns the total count of the records returned by ListComponentImages
+func GetTotalOfComponentImages(query *models.ComponentQuery) (int64, error) {
+	sql, params := componentQueryConditions(query)
+	sql = `select count(*) ` + sql
+	var total int64
+	if err := GetOrmer().Raw(sql, params).QueryRow(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// ListComponentImages returns the tags of the images containing the component of the query,
+// there is a record for each version of the component found in the image
+func ListComponentImages(query *models.ComponentQuery) ([]*models.ComponentImage, error) {
+	images := []*models.ComponentImage{}
+	condition, params := componentQueryConditions(query)
+	sql := `select a.project_id, a.repo as repository, a.tag, a.digest, c.name, c.version ` +
+		condition + `order by a.repo, a.tag, c.version `
+	if query != nil {
+		sql, params = paginate(sql, params, &query.Pagination)
+	}
+
+	if _, err := GetOrmer().Raw(sql, params).QueryRows(&images); err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// componentQueryConditions joins the components with the artifacts by digest,
+// so only the images the tags currently refer to are returned
+func componentQueryConditions(query *models.ComponentQuery) (string, []interface{}) {
+	params := []interface{}{}
+	sql := `from img_component c
+	join artifact a on a.digest = c.image_digest
+	where 1=1 `
+	if query == nil {
+		return sql, params
+	}
+
+	if len(query.Name) > 0 {
+		sql += `and c.name = ? `
+		params = append(params, query.Name)
+	}
+	if len(query.Version) > 0 {
+		sql += `and c.version = ? `
+		params = append(params, query.Version)
+	}
+	if query.ProjectIDs != nil {
+		if len(query.ProjectIDs) == 0 {
+			sql += `and 1=0 `
+		} else {
+			sql += fmt.Sprintf(`and a.project_id in ( %s ) `, ParamPlaceholderForIn(len(query.ProjectIDs)))
+			params = append(params, query.ProjectIDs)
+		}
+	}
+	return sql, params
+}
+
 // ListImgScanOverviews list all records in table img_scan_overview, it is called in notification handler when it needs to refresh the severity of all images.
 func ListImgScanOverviews() ([]*models.ImgScanOverview, error) {
 	var res []*models.ImgScanOverview
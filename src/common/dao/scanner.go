@@ -0,0 +1,114 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/astaxie/beego/orm"
+	"github.com/goharbor/harbor/src/common/models"
+)
+
+// AddScannerRegistration adds a scanner registration to the database.
+func AddScannerRegistration(r *models.ScannerRegistration) (int64, error) {
+	now := time.Now()
+	r.CreationTime = now
+	r.UpdateTime = now
+	id, err := GetOrmer().Insert(r)
+	if err != nil && isDupRecErr(err) {
+		return 0, ErrDupRows
+	}
+	return id, err
+}
+
+// GetScannerRegistration returns the scanner registration with the UUID, nil is returned if it's not found.
+func GetScannerRegistration(uuid string) (*models.ScannerRegistration, error) {
+	r := &models.ScannerRegistration{
+		UUID: uuid,
+	}
+	if err := GetOrmer().Read(r, "UUID"); err != nil {
+		if err == orm.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// GetDefaultScannerRegistration returns the default scanner registration, nil is returned if there is no default one.
+func GetDefaultScannerRegistration() (*models.ScannerRegistration, error) {
+	var rs []*models.ScannerRegistration
+	if _, err := GetOrmer().QueryTable(&models.ScannerRegistration{}).Filter("IsDefault", true).All(&rs); err != nil {
+		return nil, err
+	}
+	if len(rs) == 0 {
+		return nil, nil
+	}
+
+	return rs[0], nil
+}
+
+// ListScannerRegistrations returns all the scanner registrations ordered by name.
+func ListScannerRegistrations() ([]*models.ScannerRegistration, error) {
+	var rs []*models.ScannerRegistration
+	if _, err := GetOrmer().QueryTable(&models.ScannerRegistration{}).OrderBy("name").All(&rs); err != nil {
+		return nil, err
+	}
+
+	return rs, nil
+}
+
+// UpdateScannerRegistration updates the scanner registration, only the properties specified are updated if any.
+func UpdateScannerRegistration(r *models.ScannerRegistration, props ...string) error {
+	r.UpdateTime = time.Now()
+	if len(props) > 0 {
+		props = append(props, "UpdateTime")
+	}
+	_, err := GetOrmer().Update(r, props...)
+	if err != nil && isDupRecErr(err) {
+		return ErrDupRows
+	}
+	return err
+}
+
+// DeleteScannerRegistration deletes the scanner registration with the UUID.
+func DeleteScannerRegistration(uuid string) error {
+	_, err := GetOrmer().QueryTable(&models.ScannerRegistration{}).Filter("UUID", uuid).Delete()
+	return err
+}
+
+// SetDefaultScannerRegistration sets the scanner registration with the UUID as the default one,
+// an empty UUID unsets the default scanner registration.
+func SetDefaultScannerRegistration(uuid string) error {
+	return WithTransaction(func(o orm.Ormer) error {
+		qs := o.QueryTable(&models.ScannerRegistration{})
+		if _, err := qs.Filter("IsDefault", true).Update(orm.Params{"is_default": false}); err != nil {
+			return err
+		}
+		if len(uuid) == 0 {
+			return nil
+		}
+
+		n, err := qs.Filter("UUID", uuid).Update(orm.Params{"is_default": true, "update_time": time.Now()})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return orm.ErrNoRows
+		}
+		return nil
+	})
+}
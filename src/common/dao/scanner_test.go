@@ -0,0 +1,83 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScannerRegistrationDaoSuite struct {
+	suite.Suite
+}
+
+func (suite *ScannerRegistrationDaoSuite) TearDownTest() {
+	ClearTable(models.ScannerRegistrationTable)
+}
+
+func (suite *ScannerRegistrationDaoSuite) TestScannerRegistration() {
+	for _, name := range []string{"trivy", "anchore"} {
+		_, err := AddScannerRegistration(&models.ScannerRegistration{
+			UUID: name + "-uuid",
+			Name: name,
+			URL:  "http://" + name + ":8080",
+		})
+		suite.Nil(err)
+	}
+
+	r, err := GetScannerRegistration("trivy-uuid")
+	suite.Nil(err)
+	suite.Require().NotNil(r)
+	suite.Equal("trivy", r.Name)
+
+	r, err = GetScannerRegistration("none")
+	suite.Nil(err)
+	suite.Nil(r)
+
+	rs, err := ListScannerRegistrations()
+	suite.Nil(err)
+	if suite.Len(rs, 2) {
+		suite.Equal("anchore", rs[0].Name)
+	}
+
+	r, err = GetDefaultScannerRegistration()
+	suite.Nil(err)
+	suite.Nil(r)
+
+	suite.Nil(SetDefaultScannerRegistration("trivy-uuid"))
+	suite.Nil(SetDefaultScannerRegistration("anchore-uuid"))
+	r, err = GetDefaultScannerRegistration()
+	suite.Nil(err)
+	suite.Require().NotNil(r)
+	suite.Equal("anchore-uuid", r.UUID)
+	suite.NotNil(SetDefaultScannerRegistration("none"))
+
+	r.Description = "updated"
+	suite.Nil(UpdateScannerRegistration(r, "Description"))
+	r, err = GetScannerRegistration("anchore-uuid")
+	suite.Nil(err)
+	suite.Equal("updated", r.Description)
+
+	suite.Nil(DeleteScannerRegistration("anchore-uuid"))
+	r, err = GetScannerRegistration("anchore-uuid")
+	suite.Nil(err)
+	suite.Nil(r)
+}
+
+func TestRunScannerRegistrationDaoSuite(t *testing.T) {
+	suite.Run(t, new(ScannerRegistrationDaoSuite))
+}
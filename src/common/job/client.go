@@ -2,8 +2,10 @@ package job
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"regexp"
@@ -28,8 +30,10 @@ var (
 type Client interface {
 	SubmitJob(*models.JobData) (string, error)
 	GetJobLog(uuid string) ([]byte, error)
+	FollowJobLog(ctx context.Context, uuid string, acceptEvents bool) (io.ReadCloser, error)
 	PostAction(uuid, action string) error
 	GetExecutions(uuid string) ([]job.Stats, error)
+	GetJob(uuid string) (*job.Stats, error)
 	// TODO Redirect joblog when we see there's memory issue.
 }
 
@@ -136,6 +140,37 @@ func (d *DefaultClient) GetJobLog(uuid string) ([]byte, error) {
 	return data, nil
 }
 
+// FollowJobLog call jobservice API to follow the log of a running job, the returned stream is ended when the job is done.
+// The log lines are streamed as server-sent events if acceptEvents is true.
+func (d *DefaultClient) FollowJobLog(ctx context.Context, uuid string, acceptEvents bool) (io.ReadCloser, error) {
+	url := d.endpoint + "/api/v1/jobs/" + uuid + "/log?follow=true"
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Stop following when the caller is gone
+	req = req.WithContext(ctx)
+	if acceptEvents {
+		req.Header.Set("Accept", "text/event-stream")
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &commonhttp.Error{
+			Code:    resp.StatusCode,
+			Message: string(data),
+		}
+	}
+	return resp.Body, nil
+}
+
 // GetExecutions ...
 func (d *DefaultClient) GetExecutions(periodicJobID string) ([]job.Stats, error) {
 	url := fmt.Sprintf("%s/api/v1/jobs/%s/executions?page_number=1&page_size=100", d.endpoint, periodicJobID)
@@ -166,6 +201,35 @@ func (d *DefaultClient) GetExecutions(periodicJobID string) ([]job.Stats, error)
 	return exes, nil
 }
 
+// GetJob call jobservice API to get the stats of a job, including the progress reported by the running job
+func (d *DefaultClient) GetJob(uuid string) (*job.Stats, error) {
+	url := d.endpoint + "/api/v1/jobs/" + uuid
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &commonhttp.Error{
+			Code:    resp.StatusCode,
+			Message: string(data),
+		}
+	}
+	stats := &job.Stats{}
+	if err := json.Unmarshal(data, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
 // PostAction call jobservice's API to operate action for job specified by uuid
 func (d *DefaultClient) PostAction(uuid, action string) error {
 	url := d.endpoint + "/api/v1/jobs/" + uuid
@@ -1,7 +1,9 @@
 package job
 
 import (
+	"context"
 	"errors"
+	"io/ioutil"
 	"os"
 	"testing"
 
@@ -49,6 +51,19 @@ func TestGetJobLog(t *testing.T) {
 	assert.Contains(text, "The content in this file is for mocking the get log api.")
 }
 
+func TestFollowJobLog(t *testing.T) {
+	assert := assert.New(t)
+	_, err1 := testClient.FollowJobLog(context.Background(), "non", false)
+	assert.NotNil(err1)
+
+	r, err2 := testClient.FollowJobLog(context.Background(), ID, false)
+	assert.Nil(err2)
+	defer r.Close()
+	b, err3 := ioutil.ReadAll(r)
+	assert.Nil(err3)
+	assert.Contains(string(b), "The content in this file is for mocking the get log api.")
+}
+
 func TestGetExecutions(t *testing.T) {
 	assert := assert.New(t)
 	exes, err := testClient.GetExecutions(ID)
@@ -57,6 +72,17 @@ func TestGetExecutions(t *testing.T) {
 	assert.Equal(ID+"@123123", stat.Info.JobID)
 }
 
+func TestGetJob(t *testing.T) {
+	assert := assert.New(t)
+	_, err := testClient.GetJob("non")
+	assert.NotNil(err)
+
+	stats, err := testClient.GetJob(ID)
+	assert.Nil(err)
+	assert.Equal(ID, stats.Info.JobID)
+	assert.Equal(int64(1), stats.Info.Progress.Current)
+}
+
 func TestPostAction(t *testing.T) {
 	assert := assert.New(t)
 	err := testClient.PostAction(ID, "fff")
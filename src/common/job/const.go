@@ -5,6 +5,8 @@ const (
 	ImageScanJob = "IMAGE_SCAN"
 	// ImageScanAllJob is the name of "scanall" job in job service
 	ImageScanAllJob = "IMAGE_SCAN_ALL"
+	// ImageRescanJob is the name of the job rescanning the images when the vulnerability database is updated
+	ImageRescanJob = "IMAGE_RESCAN"
 	// ImageGC the name of image garbage collection job in job service
 	ImageGC = "IMAGE_GC"
 
@@ -23,6 +23,8 @@ type JobMetadata struct {
 	JobKind       string `json:"kind"`
 	ScheduleDelay uint64 `json:"schedule_delay,omitempty"`
 	Cron          string `json:"cron_spec,omitempty"`
+	TimeZone      string `json:"time_zone,omitempty"`
+	CatchUp       string `json:"catch_up,omitempty"`
 	IsUnique      bool   `json:"unique"`
 }
 
@@ -6,4 +6,17 @@ type ScanJobParms struct {
 	Repository string `json:"repository"`
 	Tag        string `json:"tag"`
 	Digest     string `json:"digest"`
+	// The UUID of the scanner registration to scan the image, the built-in Clair is used if it's not set.
+	// The job loads the registration by the UUID, so the access credential isn't kept in the job parameters.
+	ScannerUUID string `json:"scanner_uuid,omitempty"`
+}
+
+// RescanJobParms holds the parameters of the job rescanning the images when the vulnerability database is updated
+type RescanJobParms struct {
+	// The UUIDs of the scanner whose vulnerability database is updated
+	ScannerUUIDs []string `json:"scanner_uuids"`
+	// The images scanned before the time are rescanned, it's the unix time of the update of the vulnerability database
+	ScannedBefore int64 `json:"scanned_before"`
+	// Only the images pulled after the time are rescanned if it's not zero
+	PulledAfter int64 `json:"pulled_after,omitempty"`
 }
@@ -72,6 +72,24 @@ func NewJobServiceServer() *httptest.Server {
 		})
 	mux.HandleFunc(fmt.Sprintf("%s/%s", jobsPrefix, jobUUID),
 		func(rw http.ResponseWriter, req *http.Request) {
+			if req.Method == http.MethodGet {
+				stat := job_models.Stats{
+					Info: &job_models.StatsInfo{
+						JobID:  jobUUID,
+						Status: "Running",
+						Progress: &job_models.Progress{
+							Phase:   "testing",
+							Current: 1,
+							Total:   2,
+						},
+					},
+				}
+				b, _ := json.Marshal(stat)
+				if _, err := rw.Write(b); err != nil {
+					panic(err)
+				}
+				return
+			}
 			if req.Method != http.MethodPost {
 				rw.WriteHeader(http.StatusMethodNotAllowed)
 				return
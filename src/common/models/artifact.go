@@ -29,4 +29,5 @@ type ArtifactQuery struct {
 	Tag    string
 	Digest string
 	Pagination
+	Sorting
 }
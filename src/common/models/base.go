@@ -46,5 +46,10 @@ func init() {
 		new(CVEWhitelist),
 		new(Quota),
 		new(QuotaUsage),
+		new(QuotaHistory),
+		new(ScannerRegistration),
+		new(ImgComponent),
+		new(ImgVulnerability),
+		new(ImgQuarantine),
 	)
 }
@@ -30,6 +30,20 @@ type CVEWhitelist struct {
 // CVEWhitelistItem defines one item in the CVE whitelist
 type CVEWhitelistItem struct {
 	CVEID string `json:"cve_id"`
+	// The item is ignored after the time, it never expires if it's not set
+	ExpiresAt *int64 `json:"expires_at,omitempty"`
+	// Why the vulnerability is acceptable, it's required for the new items
+	Justification string `json:"justification,omitempty"`
+	// Who approved the exception
+	Approver string `json:"approver,omitempty"`
+}
+
+// IsExpired returns whether the item is expired
+func (i *CVEWhitelistItem) IsExpired() bool {
+	if i.ExpiresAt == nil {
+		return false
+	}
+	return time.Now().Unix() >= *i.ExpiresAt
 }
 
 // TableName ...
@@ -37,15 +51,30 @@ func (c *CVEWhitelist) TableName() string {
 	return "cve_whitelist"
 }
 
-// CVESet returns the set of CVE id of the items in the whitelist to help filter the vulnerability list
+// CVESet returns the set of CVE id of the items in the whitelist to help filter the vulnerability list,
+// the expired items are excluded
 func (c *CVEWhitelist) CVESet() map[string]struct{} {
 	r := map[string]struct{}{}
 	for _, it := range c.Items {
+		if it.IsExpired() {
+			continue
+		}
 		r[it.CVEID] = struct{}{}
 	}
 	return r
 }
 
+// ExpiredItems returns the expired items in the whitelist
+func (c *CVEWhitelist) ExpiredItems() []CVEWhitelistItem {
+	r := []CVEWhitelistItem{}
+	for _, it := range c.Items {
+		if it.IsExpired() {
+			r = append(r, it)
+		}
+	}
+	return r
+}
+
 // IsExpired returns whether the whitelist is expired
 func (c *CVEWhitelist) IsExpired() bool {
 	if c.ExpiresAt == nil {
@@ -53,3 +82,12 @@ func (c *CVEWhitelist) IsExpired() bool {
 	}
 	return time.Now().Unix() >= *c.ExpiresAt
 }
+
+// CVEWhitelistAuditItem is an item in the CVE whitelist of the system or a project reported by the audit
+type CVEWhitelistAuditItem struct {
+	CVEWhitelistItem
+	// 0 means the system level whitelist
+	ProjectID   int64  `json:"project_id"`
+	ProjectName string `json:"project_name,omitempty"`
+	Expired     bool   `json:"expired"`
+}
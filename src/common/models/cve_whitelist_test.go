@@ -64,9 +64,40 @@ func TestCVEWhitelist_All(t *testing.T) {
 			},
 			expired: false,
 		},
+		{
+			input: CVEWhitelist{
+				ID:        3,
+				ProjectID: 3,
+				Items: []CVEWhitelistItem{
+					{CVEID: "CVE-1999-0067", ExpiresAt: &now},
+					{CVEID: "CVE-2016-7654321", ExpiresAt: &future},
+				},
+			},
+			cveset: map[string]struct{}{
+				"CVE-2016-7654321": {},
+			},
+			expired: false,
+		},
 	}
 	for _, c := range cases {
 		assert.Equal(t, c.expired, c.input.IsExpired())
 		assert.True(t, reflect.DeepEqual(c.cveset, c.input.CVESet()))
 	}
 }
+
+func TestCVEWhitelist_ExpiredItems(t *testing.T) {
+	future := int64(4411494000)
+	past := time.Now().Unix() - 1
+	wl := CVEWhitelist{
+		Items: []CVEWhitelistItem{
+			{CVEID: "CVE-1999-0067", ExpiresAt: &past, Justification: "not exploitable", Approver: "admin"},
+			{CVEID: "CVE-2016-7654321", ExpiresAt: &future},
+			{CVEID: "CVE-2019-0001"},
+		},
+	}
+	expired := wl.ExpiredItems()
+	if assert.Len(t, expired, 1) {
+		assert.Equal(t, "CVE-1999-0067", expired[0].CVEID)
+		assert.Equal(t, "admin", expired[0].Approver)
+	}
+}
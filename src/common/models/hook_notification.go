@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"path"
 	"time"
 )
 
@@ -10,6 +11,8 @@ const (
 	NotificationPolicyTable = "notification_policy"
 	// NotificationJobTable is table name for notification job
 	NotificationJobTable = "notification_job"
+	// SystemNotificationPolicyProjectID is the project ID of the system level notification policies
+	SystemNotificationPolicyProjectID = 0
 )
 
 // NotificationPolicy is the model for a notification policy.
@@ -26,6 +29,9 @@ type NotificationPolicy struct {
 	CreationTime time.Time     `orm:"column(creation_time);auto_now_add" json:"creation_time"`
 	UpdateTime   time.Time     `orm:"column(update_time);auto_now_add" json:"update_time"`
 	Enabled      bool          `orm:"column(enabled)" json:"enabled"`
+	// The glob pattern of the names of the projects whose events are sent by a system level policy,
+	// all the projects match if it's empty
+	ProjectPattern string `orm:"column(project_pattern)" json:"project_pattern,omitempty"`
 }
 
 // TableName set table name for ORM.
@@ -33,6 +39,23 @@ func (w *NotificationPolicy) TableName() string {
 	return NotificationPolicyTable
 }
 
+// SystemLevel returns whether the policy is a system level one, which spans all the projects
+func (w *NotificationPolicy) SystemLevel() bool {
+	return w.ProjectID == SystemNotificationPolicyProjectID
+}
+
+// MatchProject returns whether the events of the project are sent by the policy
+func (w *NotificationPolicy) MatchProject(name string) bool {
+	if !w.SystemLevel() {
+		return false
+	}
+	if len(w.ProjectPattern) == 0 {
+		return true
+	}
+	matched, err := path.Match(w.ProjectPattern, name)
+	return err == nil && matched
+}
+
 // ConvertToDBModel convert struct data in notification policy to DB model data
 func (w *NotificationPolicy) ConvertToDBModel() error {
 	if len(w.Targets) != 0 {
@@ -108,4 +131,10 @@ type EventTarget struct {
 	Address        string `json:"address"`
 	AuthHeader     string `json:"auth_header,omitempty"`
 	SkipCertVerify bool   `json:"skip_cert_verify"`
+	// The secret to sign the deliveries with HMAC-SHA256, the deliveries are not signed if it's empty
+	Secret string `json:"secret,omitempty"`
+	// The Go template rendering the payload, it's required by the target of type "template"
+	PayloadTemplate string `json:"payload_template,omitempty"`
+	// The content type of the payload rendered by the template, default is "application/json"
+	ContentType string `json:"content_type,omitempty"`
 }
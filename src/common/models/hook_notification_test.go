@@ -112,3 +112,25 @@ func TestNotificationPolicy_TableName(t *testing.T) {
 	assert.Equal(t, NotificationPolicyTable, got)
 
 }
+
+func TestNotificationPolicy_MatchProject(t *testing.T) {
+	// project level policy
+	policy := &NotificationPolicy{ProjectID: 1}
+	assert.False(t, policy.SystemLevel())
+	assert.False(t, policy.MatchProject("library"))
+
+	// system level policy without pattern
+	policy = &NotificationPolicy{}
+	assert.True(t, policy.SystemLevel())
+	assert.True(t, policy.MatchProject("library"))
+
+	policy.ProjectPattern = "team-*"
+	assert.True(t, policy.MatchProject("team-a"))
+	assert.False(t, policy.MatchProject("library"))
+
+	policy.ProjectPattern = "{team-a,team-b}"
+	assert.False(t, policy.MatchProject("team-a"))
+
+	policy.ProjectPattern = "team-["
+	assert.False(t, policy.MatchProject("team-a"))
+}
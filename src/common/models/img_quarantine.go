@@ -0,0 +1,52 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"time"
+)
+
+const (
+	// ImgQuarantineTable is the table name of the quarantine records of the images
+	ImgQuarantineTable = "img_quarantine"
+	// QuarantineStatusQuarantined the image is pushed and can't be pulled until its scan is finished
+	QuarantineStatusQuarantined = "quarantined"
+	// QuarantineStatusPromoted the image passed the vulnerability policy of the project
+	QuarantineStatusPromoted = "promoted"
+	// QuarantineStatusRejected the image failed the vulnerability policy of the project or its scan failed
+	QuarantineStatusRejected = "rejected"
+)
+
+// ImgQuarantine is the quarantine state of the image pushed to the project with quarantine on push enabled
+type ImgQuarantine struct {
+	ID           int64     `orm:"pk;auto;column(id)" json:"id"`
+	Repository   string    `orm:"column(repository)" json:"repository"`
+	Digest       string    `orm:"column(digest)" json:"digest"`
+	Tag          string    `orm:"column(tag)" json:"tag"`
+	Status       string    `orm:"column(status)" json:"status"`
+	Reason       string    `orm:"column(reason)" json:"reason,omitempty"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+	UpdateTime   time.Time `orm:"column(update_time);auto_now" json:"update_time"`
+}
+
+// TableName is required by by beego orm to map ImgQuarantine to table img_quarantine
+func (q *ImgQuarantine) TableName() string {
+	return ImgQuarantineTable
+}
+
+// Blocked returns whether the image can't be pulled
+func (q *ImgQuarantine) Blocked() bool {
+	return q.Status == QuarantineStatusQuarantined || q.Status == QuarantineStatusRejected
+}
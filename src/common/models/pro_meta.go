@@ -24,8 +24,12 @@ const (
 	ProMetaEnableContentTrust   = "enable_content_trust"
 	ProMetaPreventVul           = "prevent_vul" // prevent vulnerable images from being pulled
 	ProMetaSeverity             = "severity"
+	ProMetaPreventVulCVSS       = "prevent_vul_cvss"    // the minimum CVSS score of the vulnerabilities preventing the images from being pulled
+	ProMetaPreventVulFixable    = "prevent_vul_fixable" // only the vulnerabilities having fixed version prevent the images from being pulled
 	ProMetaAutoScan             = "auto_scan"
+	ProMetaQuarantineOnPush     = "quarantine_on_push" // quarantine the pushed images until they pass the vulnerable policy
 	ProMetaReuseSysCVEWhitelist = "reuse_sys_cve_whitelist"
+	ProMetaScanner              = "scanner" // the UUID of the scanner registration used by the project
 	SeverityNone                = "negligible"
 	SeverityLow                 = "low"
 	SeverityMedium              = "medium"
@@ -43,3 +47,16 @@ type ProjectMetadata struct {
 	UpdateTime   time.Time `orm:"column(update_time);auto_now" json:"update_time"`
 	Deleted      bool      `orm:"column(deleted)" json:"deleted"`
 }
+
+// VulnerablePolicy is the policy of a project to prevent the vulnerable images from being pulled
+type VulnerablePolicy struct {
+	Enabled bool
+	// The images having vulnerabilities whose severity is equal or higher than it are prevented
+	Severity Severity
+	// The images having vulnerabilities whose CVSS score is equal or higher than it are prevented,
+	// the severity is used for the vulnerabilities without CVSS score. 0 means the CVSS score isn't checked
+	CVSSThreshold float64
+	// Only the vulnerabilities having fixed version are checked if it's set
+	FixableOnly bool
+	Whitelist   CVEWhitelist
+}
@@ -15,6 +15,7 @@
 package models
 
 import (
+	"strconv"
 	"strings"
 	"time"
 
@@ -110,6 +111,29 @@ func (p *Project) Severity() string {
 	return severity
 }
 
+// CVSSThreshold returns the minimum CVSS score of the vulnerabilities preventing the images from being pulled,
+// 0 is returned if it's not set
+func (p *Project) CVSSThreshold() float64 {
+	value, exist := p.GetMetadata(ProMetaPreventVulCVSS)
+	if !exist {
+		return 0
+	}
+	score, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return score
+}
+
+// FixableVulPrevented returns whether only the vulnerabilities having fixed version prevent the images from being pulled
+func (p *Project) FixableVulPrevented() bool {
+	fixable, exist := p.GetMetadata(ProMetaPreventVulFixable)
+	if !exist {
+		return false
+	}
+	return isTrue(fixable)
+}
+
 // AutoScan ...
 func (p *Project) AutoScan() bool {
 	auto, exist := p.GetMetadata(ProMetaAutoScan)
@@ -119,6 +143,15 @@ func (p *Project) AutoScan() bool {
 	return isTrue(auto)
 }
 
+// QuarantineOnPush ...
+func (p *Project) QuarantineOnPush() bool {
+	quarantine, exist := p.GetMetadata(ProMetaQuarantineOnPush)
+	if !exist {
+		return false
+	}
+	return isTrue(quarantine)
+}
+
 func isTrue(value string) bool {
 	return strings.ToLower(value) == "true" ||
 		strings.ToLower(value) == "1"
@@ -0,0 +1,83 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/goharbor/harbor/src/pkg/types"
+)
+
+const (
+	// QuotaHistoryActionCreate the quota created with the hard limits
+	QuotaHistoryActionCreate = "create"
+	// QuotaHistoryActionUpdate the hard limits of the quota updated
+	QuotaHistoryActionUpdate = "update"
+	// QuotaHistoryActionEnsure the usage of the quota ensured
+	QuotaHistoryActionEnsure = "ensure"
+	// QuotaHistoryActionSync the usage of one resource of the quota synced
+	QuotaHistoryActionSync = "sync"
+)
+
+// QuotaHistory model for the change history of quota
+type QuotaHistory struct {
+	ID           int64     `orm:"pk;auto;column(id)" json:"id"`
+	Reference    string    `orm:"column(reference)" json:"reference"`
+	ReferenceID  string    `orm:"column(reference_id)" json:"reference_id"`
+	Action       string    `orm:"column(action)" json:"action"`
+	Operator     string    `orm:"column(operator)" json:"operator"`
+	OldValue     string    `orm:"column(old_value);type(jsonb)" json:"-"`
+	NewValue     string    `orm:"column(new_value);type(jsonb)" json:"-"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+}
+
+// TableName returns table name for orm
+func (h *QuotaHistory) TableName() string {
+	return "quota_history"
+}
+
+// MarshalJSON ...
+func (h *QuotaHistory) MarshalJSON() ([]byte, error) {
+	oldValue, err := types.NewResourceList(h.OldValue)
+	if err != nil {
+		return nil, err
+	}
+
+	newValue, err := types.NewResourceList(h.NewValue)
+	if err != nil {
+		return nil, err
+	}
+
+	type Alias QuotaHistory
+	return json.Marshal(&struct {
+		*Alias
+		OldValue types.ResourceList `json:"old_value"`
+		NewValue types.ResourceList `json:"new_value"`
+	}{
+		Alias:    (*Alias)(h),
+		OldValue: oldValue,
+		NewValue: newValue,
+	})
+}
+
+// QuotaHistoryQuery query parameters for quota history
+type QuotaHistoryQuery struct {
+	Reference   string
+	ReferenceID string
+	Action      string
+	Operator    string
+	Pagination
+}
@@ -22,6 +22,12 @@ const ScanJobTable = "img_scan_job"
 // ScanOverviewTable is the name of the table whose data is mapped by ImgScanOverview struct.
 const ScanOverviewTable = "img_scan_overview"
 
+// ImgComponentTable is the name of the table whose data is mapped by ImgComponent struct.
+const ImgComponentTable = "img_component"
+
+// ImgVulnerabilityTable is the name of the table whose data is mapped by ImgVulnerability struct.
+const ImgVulnerabilityTable = "img_vulnerability"
+
 // ScanJob is the model to represent a job for image scan in DB.
 type ScanJob struct {
 	ID           int64     `orm:"pk;auto;column(id)" json:"id"`
@@ -49,6 +55,8 @@ type ImgScanOverview struct {
 	CompOverviewStr string              `orm:"column(components_overview)" json:"-"`
 	CompOverview    *ComponentsOverview `orm:"-" json:"components,omitempty"`
 	DetailsKey      string              `orm:"column(details_key)" json:"details_key"`
+	ScannerUUID     string              `orm:"column(scanner_uuid)" json:"scanner_uuid,omitempty"`
+	Report          string              `orm:"column(report)" json:"-"`
 	CreationTime    time.Time           `orm:"column(creation_time);auto_now_add" json:"creation_time,omitempty"`
 	UpdateTime      time.Time           `orm:"column(update_time);auto_now" json:"update_time,omitempty"`
 }
@@ -58,6 +66,99 @@ func (iso *ImgScanOverview) TableName() string {
 	return ScanOverviewTable
 }
 
+// ImgComponent is a software component, e.g. a package, found in the image when scanning it.
+// The components of the image make up its software bill of materials.
+type ImgComponent struct {
+	ID           int64     `orm:"pk;auto;column(id)" json:"-"`
+	Digest       string    `orm:"column(image_digest)" json:"-"`
+	Name         string    `orm:"column(name)" json:"name"`
+	Version      string    `orm:"column(version)" json:"version"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"-"`
+}
+
+// TableName ...
+func (ic *ImgComponent) TableName() string {
+	return ImgComponentTable
+}
+
+// ImgVulnerability is a vulnerability found in the image by the scanner, it indexes the images by the CVE
+type ImgVulnerability struct {
+	ID           int64     `orm:"pk;auto;column(id)" json:"-"`
+	Digest       string    `orm:"column(image_digest)" json:"-"`
+	CVEID        string    `orm:"column(cve_id)" json:"cve_id"`
+	Package      string    `orm:"column(package)" json:"package"`
+	Version      string    `orm:"column(version)" json:"version"`
+	FixVersion   string    `orm:"column(fix_version)" json:"fix_version,omitempty"`
+	Severity     int       `orm:"column(severity)" json:"severity"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"-"`
+}
+
+// TableName ...
+func (iv *ImgVulnerability) TableName() string {
+	return ImgVulnerabilityTable
+}
+
+// CVEQuery holds the conditions to query the images affected by the vulnerabilities
+type CVEQuery struct {
+	CVEID string
+	// Only the images in the projects are returned if it's not nil
+	ProjectIDs []int64
+	// The minimum severity of the vulnerabilities
+	Severity int
+	Pagination
+}
+
+// AffectedImage is the tag of the image affected by a vulnerability according to its last scan
+type AffectedImage struct {
+	ProjectID  int64  `orm:"column(project_id)" json:"project_id"`
+	Repository string `orm:"column(repository)" json:"repository"`
+	Tag        string `orm:"column(tag)" json:"tag"`
+	Digest     string `orm:"column(digest)" json:"digest"`
+	Package    string `orm:"column(package)" json:"package"`
+	Version    string `orm:"column(version)" json:"version"`
+	FixVersion string `orm:"column(fix_version)" json:"fix_version,omitempty"`
+	Severity   int    `orm:"column(severity)" json:"severity"`
+}
+
+// CVEImpact summarizes the images affected by a vulnerability
+type CVEImpact struct {
+	CVEID        string `orm:"column(cve_id)" json:"cve_id"`
+	Severity     int    `orm:"column(severity)" json:"severity"`
+	Images       int64  `orm:"column(images)" json:"images"`
+	Tags         int64  `orm:"column(tags)" json:"tags"`
+	Repositories int64  `orm:"column(repositories)" json:"repositories"`
+}
+
+// ComponentQuery holds the conditions to query the images containing the component
+type ComponentQuery struct {
+	Name string
+	// Only the images containing the version of the component are returned if it's not empty
+	Version string
+	// Only the images in the projects are returned if it's not nil
+	ProjectIDs []int64
+	Pagination
+}
+
+// ComponentImage is the tag of the image containing a component according to its last scan
+type ComponentImage struct {
+	ProjectID  int64  `orm:"column(project_id)" json:"project_id"`
+	Repository string `orm:"column(repository)" json:"repository"`
+	Tag        string `orm:"column(tag)" json:"tag"`
+	Digest     string `orm:"column(digest)" json:"digest"`
+	Name       string `orm:"column(name)" json:"name"`
+	Version    string `orm:"column(version)" json:"version"`
+}
+
+// RescanQuery holds the conditions to query the images to be rescanned
+type RescanQuery struct {
+	// The UUIDs of the scanner which scanned the images
+	ScannerUUIDs []string
+	// Only the images scanned before the time are returned
+	ScannedBefore time.Time
+	// Only the images pulled after the time are returned if it's not zero
+	PulledAfter time.Time
+}
+
 // ComponentsOverview has the total number and a list of components number of different serverity level.
 type ComponentsOverview struct {
 	Total   int                        `json:"total"`
@@ -0,0 +1,41 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import "time"
+
+// ScannerRegistrationTable is the name of the table whose data is mapped by ScannerRegistration struct.
+const ScannerRegistrationTable = "scanner_registration"
+
+// ScannerRegistration is the registration of a scanner adapter
+type ScannerRegistration struct {
+	ID               int64     `orm:"pk;auto;column(id)" json:"-"`
+	UUID             string    `orm:"column(uuid)" json:"uuid"`
+	Name             string    `orm:"column(name)" json:"name"`
+	Description      string    `orm:"column(description)" json:"description"`
+	URL              string    `orm:"column(url)" json:"url"`
+	Auth             string    `orm:"column(auth)" json:"auth"`
+	AccessCredential string    `orm:"column(access_cred)" json:"access_credential,omitempty"`
+	SkipCertVerify   bool      `orm:"column(skip_cert_verify)" json:"skip_cert_verify"`
+	Disabled         bool      `orm:"column(disabled)" json:"disabled"`
+	IsDefault        bool      `orm:"column(is_default)" json:"is_default"`
+	CreationTime     time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+	UpdateTime       time.Time `orm:"column(update_time);auto_now" json:"update_time"`
+}
+
+// TableName is required by by beego orm to map ScannerRegistration to table scanner_registration
+func (s *ScannerRegistration) TableName() string {
+	return ScannerRegistrationTable
+}
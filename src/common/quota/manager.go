@@ -26,11 +26,44 @@ import (
 	"github.com/goharbor/harbor/src/pkg/types"
 )
 
+const (
+	// OperatorSystem the operator of the quota changes made by harbor itself
+	OperatorSystem = "system"
+	// OperatorGC the operator of the quota changes made by the garbage collection
+	OperatorGC = "gc"
+)
+
 // Manager manager for quota
 type Manager struct {
 	driver      driver.Driver
 	reference   string
 	referenceID string
+	operator    string
+}
+
+// WithOperator sets the operator recorded in the histories of the changes made by the manager
+func (m *Manager) WithOperator(operator string) *Manager {
+	m.operator = operator
+	return m
+}
+
+func (m *Manager) addHistory(o orm.Ormer, action string, oldValue, newValue types.ResourceList, now time.Time) error {
+	if oldValue == nil {
+		oldValue = types.ResourceList{}
+	}
+
+	history := &models.QuotaHistory{
+		Reference:    m.reference,
+		ReferenceID:  m.referenceID,
+		Action:       action,
+		Operator:     m.operator,
+		OldValue:     oldValue.String(),
+		NewValue:     newValue.String(),
+		CreationTime: now,
+	}
+
+	_, err := o.Insert(history)
+	return err
 }
 
 func (m *Manager) addQuota(o orm.Ormer, hardLimits types.ResourceList, now time.Time) (int64, error) {
@@ -69,6 +102,10 @@ func (m *Manager) newQuota(o orm.Ormer, hardLimits types.ResourceList, usages ..
 		return 0, err
 	}
 
+	if err := m.addHistory(o, models.QuotaHistoryActionCreate, nil, hardLimits, now); err != nil {
+		return 0, err
+	}
+
 	var used types.ResourceList
 	if len(usages) > 0 {
 		used = usages[0]
@@ -183,25 +220,59 @@ func (m *Manager) DeleteQuota() error {
 
 // UpdateQuota update the quota resource spec
 func (m *Manager) UpdateQuota(hardLimits types.ResourceList) error {
-	o := dao.GetOrmer()
 	if err := m.driver.Validate(hardLimits); err != nil {
 		return err
 	}
 
-	sql := `UPDATE quota SET hard = ? WHERE reference = ? AND reference_id = ?`
-	_, err := o.Raw(sql, hardLimits.String(), m.reference, m.referenceID).Exec()
+	return dao.WithTransaction(func(o orm.Ormer) error {
+		quota, err := m.getQuotaForUpdate(o)
+		if err != nil {
+			return err
+		}
+		oldHardLimits, err := types.NewResourceList(quota.Hard)
+		if err != nil {
+			return err
+		}
 
-	return err
+		sql := `UPDATE quota SET hard = ? WHERE reference = ? AND reference_id = ?`
+		if _, err := o.Raw(sql, hardLimits.String(), m.reference, m.referenceID).Exec(); err != nil {
+			return err
+		}
+
+		return m.addHistory(o, models.QuotaHistoryActionUpdate, oldHardLimits, hardLimits, time.Now())
+	})
 }
 
 // SetResourceUsage sets the usage per resource name
 func (m *Manager) SetResourceUsage(resource types.ResourceName, value int64) error {
-	o := dao.GetOrmer()
+	return dao.WithTransaction(func(o orm.Ormer) error {
+		usage, err := m.getUsageForUpdate(o)
+		if err != nil {
+			if err == orm.ErrNoRows {
+				// nothing to set when usage not exist
+				return nil
+			}
+			return err
+		}
+		used, err := types.NewResourceList(usage.Used)
+		if err != nil {
+			return err
+		}
+		if current, ok := used[resource]; ok && current == value {
+			// Unchanged, skip updating to avoid recording a history on every sync
+			return nil
+		}
 
-	sql := fmt.Sprintf("UPDATE quota_usage SET used = jsonb_set(used, '{%s}', to_jsonb(%d::bigint), true) WHERE reference = ? AND reference_id = ?", resource, value)
-	_, err := o.Raw(sql, m.reference, m.referenceID).Exec()
+		sql := fmt.Sprintf("UPDATE quota_usage SET used = jsonb_set(used, '{%s}', to_jsonb(%d::bigint), true) WHERE reference = ? AND reference_id = ?", resource, value)
+		if _, err := o.Raw(sql, m.reference, m.referenceID).Exec(); err != nil {
+			return err
+		}
 
-	return err
+		newUsed := types.Add(types.ResourceList{}, used)
+		newUsed[resource] = value
+
+		return m.addHistory(o, models.QuotaHistoryActionSync, used, newUsed, time.Now())
+	})
 }
 
 // EnsureQuota ensures the reference has quota and usage,
@@ -236,21 +307,23 @@ func (m *Manager) EnsureQuota(usages types.ResourceList) error {
 	if types.Equals(quotaUsed, used) {
 		return nil
 	}
-	dao.WithTransaction(func(o orm.Ormer) error {
+	return dao.WithTransaction(func(o orm.Ormer) error {
 		usage, err := m.getUsageForUpdate(o)
 		if err != nil {
 			return err
 		}
+		oldUsed, err := types.NewResourceList(usage.Used)
+		if err != nil {
+			return err
+		}
 		usage.Used = used.String()
 		usage.UpdateTime = time.Now()
 		_, err = o.Update(usage)
 		if err != nil {
 			return err
 		}
-		return nil
+		return m.addHistory(o, models.QuotaHistoryActionEnsure, oldUsed, used, usage.UpdateTime)
 	})
-
-	return nil
 }
 
 // AddResources add resources to usage
@@ -267,6 +340,32 @@ func (m *Manager) SubtractResources(resources types.ResourceList) error {
 	})
 }
 
+// CheckResources checks that the resources can be added to usage without exceeding the hard limits,
+// the usage itself is not changed
+func (m *Manager) CheckResources(resources types.ResourceList) error {
+	return dao.WithTransaction(func(o orm.Ormer) error {
+		quota, err := m.getQuotaForUpdate(o)
+		if err != nil {
+			return err
+		}
+		hardLimits, err := types.NewResourceList(quota.Hard)
+		if err != nil {
+			return err
+		}
+
+		usage, err := m.getUsageForUpdate(o)
+		if err != nil {
+			return err
+		}
+		used, err := types.NewResourceList(usage.Used)
+		if err != nil {
+			return err
+		}
+
+		return isSafe(hardLimits, used, types.Add(used, resources), false)
+	})
+}
+
 // NewManager returns quota manager
 func NewManager(reference string, referenceID string) (*Manager, error) {
 	d, ok := driver.Get(reference)
@@ -283,5 +382,6 @@ func NewManager(reference string, referenceID string) (*Manager, error) {
 		driver:      d,
 		reference:   reference,
 		referenceID: referenceID,
+		operator:    OperatorSystem,
 	}, nil
 }
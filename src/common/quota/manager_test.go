@@ -148,6 +148,14 @@ func (suite *ManagerSuite) TestSetResourceUsage() {
 		usage, _ := dao.GetQuotaUsage(id)
 		suite.Equal(types.ResourceList{types.ResourceCount: 999999999999999999, types.ResourceStorage: 234}, mustResourceList(usage.Used))
 	}
+
+	// no history is recorded when the usage is unchanged
+	query := &models.QuotaHistoryQuery{Reference: reference, ReferenceID: "1", Action: models.QuotaHistoryActionSync}
+	total, _ := dao.GetTotalOfQuotaHistories(query)
+	if err := mgr.SetResourceUsage(types.ResourceStorage, 234); suite.Nil(err) {
+		count, _ := dao.GetTotalOfQuotaHistories(query)
+		suite.Equal(total, count)
+	}
 }
 
 func (suite *ManagerSuite) TestEnsureQuota() {
@@ -226,6 +234,20 @@ func (suite *ManagerSuite) TestAddResources() {
 	}
 }
 
+func (suite *ManagerSuite) TestCheckResources() {
+	mgr := suite.quotaManager()
+	id, _ := mgr.NewQuota(hardLimits)
+
+	suite.Nil(mgr.CheckResources(types.ResourceList{types.ResourceStorage: 1000}))
+
+	if err := mgr.CheckResources(types.ResourceList{types.ResourceStorage: 1001}); suite.Error(err) {
+		suite.IsType(Errors{}, err)
+	}
+
+	usage, _ := dao.GetQuotaUsage(id)
+	suite.Equal(types.Zero(hardLimits), mustResourceList(usage.Used))
+}
+
 func (suite *ManagerSuite) TestSubtractResources() {
 	mgr := suite.quotaManager()
 	id, _ := mgr.NewQuota(hardLimits)
@@ -0,0 +1,66 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"encoding/json"
+)
+
+// JSONRecord is the layout of the log line formatted by JSONFormatter
+type JSONRecord struct {
+	Time   string            `json:"time"`
+	Level  string            `json:"level"`
+	Line   string            `json:"line,omitempty"`
+	Msg    string            `json:"msg"`
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// JSONFormatter represents a kind of formatter that formats the logs as JSON lines
+type JSONFormatter struct {
+	timeFormat string
+	fields     map[string]string
+}
+
+// NewJSONFormatter returns a JSONFormatter, the format of time is time.RFC3339.
+// The fields are attached to every log line.
+func NewJSONFormatter(fields map[string]string) *JSONFormatter {
+	return &JSONFormatter{
+		timeFormat: defaultTimeFormat,
+		fields:     fields,
+	}
+}
+
+// Format formats the log as one line JSON object
+func (j *JSONFormatter) Format(r *Record) ([]byte, error) {
+	b, err := json.Marshal(&JSONRecord{
+		Time:   r.Time.Format(j.timeFormat),
+		Level:  r.Lvl.string(),
+		Line:   r.Line,
+		Msg:    r.Msg,
+		Fields: j.fields,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, '\n'), nil
+}
+
+// SetTimeFormat sets time format of JSONFormatter if the parameter fmt is not null
+func (j *JSONFormatter) SetTimeFormat(fmt string) {
+	if len(fmt) != 0 {
+		j.timeFormat = fmt
+	}
+}
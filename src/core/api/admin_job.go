@@ -102,6 +102,8 @@ func (aj *AJAPI) get(id int64) {
 		return
 	}
 
+	fillProgress(&adminJobRep)
+
 	aj.Data["json"] = adminJobRep
 	aj.ServeJSON()
 }
@@ -121,6 +123,7 @@ func (aj *AJAPI) list(name string) {
 			aj.SendInternalServerError(fmt.Errorf("failed to convert admin job response: %v", err))
 			return
 		}
+		fillProgress(&AdminJobRep)
 		AdminJobReps = append(AdminJobReps, &AdminJobRep)
 	}
 
@@ -281,6 +284,7 @@ func convertToAdminJobRep(job *common_models.AdminJob) (models.AdminJobRep, erro
 		Name:         job.Name,
 		Kind:         job.Kind,
 		Status:       job.Status,
+		UUID:         job.UUID,
 		CreationTime: job.CreationTime,
 		UpdateTime:   job.UpdateTime,
 	}
@@ -294,3 +298,20 @@ func convertToAdminJobRep(job *common_models.AdminJob) (models.AdminJobRep, erro
 	}
 	return AdminJobRep, nil
 }
+
+// fillProgress fills the progress reported by the running job, the failure is only logged
+func fillProgress(rep *models.AdminJobRep) {
+	if rep.Kind == common_job.JobKindPeriodic || rep.Status != common_models.JobRunning || len(rep.UUID) == 0 {
+		return
+	}
+
+	stats, err := utils_core.GetJobServiceClient().GetJob(rep.UUID)
+	if err != nil {
+		log.Warningf("failed to get progress of admin job %d: %v", rep.ID, err)
+		return
+	}
+
+	if stats.Info != nil {
+		rep.Progress = stats.Info.Progress
+	}
+}
@@ -19,10 +19,13 @@ import (
 	"errors"
 	"fmt"
 	"github.com/goharbor/harbor/src/common/models"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	"github.com/goharbor/harbor/src/common/api"
+	commonhttp "github.com/goharbor/harbor/src/common/http"
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/common/security"
 	"github.com/goharbor/harbor/src/common/utils"
@@ -30,6 +33,7 @@ import (
 	"github.com/goharbor/harbor/src/core/config"
 	"github.com/goharbor/harbor/src/core/filter"
 	"github.com/goharbor/harbor/src/core/promgr"
+	utils_core "github.com/goharbor/harbor/src/core/utils"
 	"github.com/goharbor/harbor/src/pkg/project"
 	"github.com/goharbor/harbor/src/pkg/repository"
 	"github.com/goharbor/harbor/src/pkg/retention"
@@ -38,6 +42,7 @@ import (
 
 const (
 	yamlFileContentType = "application/x-yaml"
+	sseContentType      = "text/event-stream"
 	userSessionKey      = "user"
 )
 
@@ -154,6 +159,44 @@ func (b *BaseController) RequireProjectAccess(projectIDOrName interface{}, actio
 	return true
 }
 
+// ProjectIDsWithAccess returns the IDs of the public projects and the projects the user is a member of,
+// on which the request has action access to the subresource. Nil is returned for the system admin,
+// which means all the projects. The error response is sent and false returned if it fails.
+func (b *BaseController) ProjectIDsWithAccess(action rbac.Action, subresource rbac.Resource) ([]int64, bool) {
+	if b.SecurityCtx.IsSysAdmin() {
+		return nil, true
+	}
+
+	projects, err := b.ProjectMgr.GetPublic()
+	if err != nil {
+		b.ParseAndHandleError("failed to get public projects", err)
+		return nil, false
+	}
+	if b.SecurityCtx.IsAuthenticated() {
+		mys, err := b.SecurityCtx.GetMyProjects()
+		if err != nil {
+			b.SendInternalServerError(fmt.Errorf("failed to get projects which the user %s is a member of: %v",
+				b.SecurityCtx.GetUsername(), err))
+			return nil, false
+		}
+		projects = append(projects, mys...)
+	}
+
+	projectIDs := []int64{}
+	exist := map[int64]bool{}
+	for _, p := range projects {
+		if exist[p.ProjectID] {
+			continue
+		}
+		exist[p.ProjectID] = true
+		resource := rbac.NewProjectNamespace(p.ProjectID).Resource(subresource)
+		if b.SecurityCtx.Can(action, resource) {
+			projectIDs = append(projectIDs, p.ProjectID)
+		}
+	}
+	return projectIDs, true
+}
+
 // WriteJSONData writes the JSON data to the client.
 func (b *BaseController) WriteJSONData(object interface{}) {
 	b.Data["json"] = object
@@ -174,6 +217,51 @@ func (b *BaseController) WriteYamlData(object interface{}) {
 	_, _ = w.Write(yData)
 }
 
+// FollowJobLog streams the log of the running job from jobservice to the client until the job is done.
+// The log lines are streamed as server-sent events if the client accepts 'text/event-stream'.
+func (b *BaseController) FollowJobLog(jobID string) {
+	acceptEvents := strings.Contains(b.Ctx.Request.Header.Get("Accept"), sseContentType)
+	stream, err := utils_core.GetJobServiceClient().FollowJobLog(b.Ctx.Request.Context(), jobID, acceptEvents)
+	if err != nil {
+		if httpErr, ok := err.(*commonhttp.Error); ok && httpErr.Code == http.StatusNotFound {
+			b.SendNotFoundError(fmt.Errorf("the log of job %s not found", jobID))
+			return
+		}
+		b.SendInternalServerError(fmt.Errorf("failed to follow log of job %s: %v", jobID, err))
+		return
+	}
+	defer stream.Close()
+
+	w := b.Ctx.ResponseWriter
+	if acceptEvents {
+		w.Header().Set("Content-Type", sseContentType)
+	} else {
+		w.Header().Set("Content-Type", "text/plain")
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	w.Flush()
+
+	buf := make([]byte, 4096)
+	for {
+		n, err := stream.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				// The client is gone
+				log.Debugf("failed to write log of job %s: %v", jobID, werr)
+				return
+			}
+			w.Flush()
+		}
+		if err != nil {
+			if err != io.EOF {
+				log.Errorf("failed to read log of job %s: %v", jobID, err)
+			}
+			return
+		}
+	}
+}
+
 // PopulateUserSession generates a new session ID and fill the user model in parm to the session
 func (b *BaseController) PopulateUserSession(u models.User) {
 	b.SessionRegenerateID()
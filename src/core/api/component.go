@@ -0,0 +1,77 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/rbac"
+)
+
+// ComponentAPI handles the requests to search the components across the images,
+// only the images in the projects whose software bill of materials the user can read are returned
+type ComponentAPI struct {
+	BaseController
+	projectIDs []int64
+}
+
+// Prepare collects the projects the user can read the software bill of materials of
+func (c *ComponentAPI) Prepare() {
+	c.BaseController.Prepare()
+	// nil means all the projects
+	projectIDs, ok := c.ProjectIDsWithAccess(rbac.ActionList, rbac.ResourceRepositoryTagVulnerability)
+	if !ok {
+		return
+	}
+	c.projectIDs = projectIDs
+}
+
+// ListImages handles the GET request to list the tags of the images containing the component
+// with the name and the optional version, it's according to the last scan of the images
+func (c *ComponentAPI) ListImages() {
+	name := c.GetString("name")
+	if len(name) == 0 {
+		c.SendBadRequestError(errors.New("missing component name"))
+		return
+	}
+	query := &models.ComponentQuery{
+		Name:       name,
+		Version:    c.GetString("version"),
+		ProjectIDs: c.projectIDs,
+	}
+	var err error
+	query.Page, query.Size, err = c.GetPaginationParams()
+	if err != nil {
+		c.SendBadRequestError(err)
+		return
+	}
+
+	total, err := dao.GetTotalOfComponentImages(query)
+	if err != nil {
+		c.SendInternalServerError(fmt.Errorf("failed to get the total of images containing %s: %v", name, err))
+		return
+	}
+	images, err := dao.ListComponentImages(query)
+	if err != nil {
+		c.SendInternalServerError(fmt.Errorf("failed to list the images containing %s: %v", name, err))
+		return
+	}
+
+	c.SetPaginationHeader(total, query.Page, query.Size)
+	c.WriteJSONData(images)
+}
@@ -0,0 +1,52 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestListComponentImages(t *testing.T) {
+	cases := []*codeCheckingCase{
+		// 400, missing the component name
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/components/images",
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 200, anonymous user can read the public projects
+		{
+			request: &testingRequest{
+				method: http.MethodGet,
+				url:    "/api/components/images?name=openssl",
+			},
+			code: http.StatusOK,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/components/images?name=openssl&version=1.1.1d",
+				credential: nonSysAdmin,
+			},
+			code: http.StatusOK,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+}
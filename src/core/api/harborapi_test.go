@@ -109,6 +109,7 @@ func init() {
 	beego.Router("/api/projects/:id([0-9]+)/logs", &ProjectAPI{}, "get:Logs")
 	beego.Router("/api/projects/:id([0-9]+)/summary", &ProjectAPI{}, "get:Summary")
 	beego.Router("/api/projects/:id([0-9]+)/_deletable", &ProjectAPI{}, "get:Deletable")
+	beego.Router("/api/projects/:id([0-9]+)/vulnerability/export", &ProjectAPI{}, "get:ExportVulnerabilities")
 	beego.Router("/api/projects/:id([0-9]+)/metadatas/?:name", &MetadataAPI{}, "get:Get")
 	beego.Router("/api/projects/:id([0-9]+)/metadatas/", &MetadataAPI{}, "post:Post")
 	beego.Router("/api/projects/:id([0-9]+)/metadatas/:name", &MetadataAPI{}, "put:Put;delete:Delete")
@@ -126,6 +127,8 @@ func init() {
 	beego.Router("/api/repositories/*/tags/:tag", &RepositoryAPI{}, "delete:Delete;get:GetTag")
 	beego.Router("/api/repositories/*/tags", &RepositoryAPI{}, "get:GetTags;post:Retag")
 	beego.Router("/api/repositories/*/tags/:tag/manifest", &RepositoryAPI{}, "get:GetManifests")
+	beego.Router("/api/repositories/*/tags/:tag/sbom", &RepositoryAPI{}, "get:GetSBOM")
+	beego.Router("/api/repositories/*/tags/:tag/vulnerability/export", &RepositoryAPI{}, "get:ExportVulnerabilities")
 	beego.Router("/api/repositories/*/signatures", &RepositoryAPI{}, "get:GetSignatures")
 	beego.Router("/api/repositories/top", &RepositoryAPI{}, "get:GetTopRepos")
 	beego.Router("/api/registries", &RegistryAPI{}, "get:List;post:Post")
@@ -150,6 +153,20 @@ func init() {
 	beego.Router("/api/system/gc/schedule", &GCAPI{}, "get:Get;put:Put;post:Post")
 	beego.Router("/api/system/scanAll/schedule", &ScanAllAPI{}, "get:Get;put:Put;post:Post")
 	beego.Router("/api/system/CVEWhitelist", &SysCVEWhitelistAPI{}, "get:Get;put:Put")
+	beego.Router("/api/system/CVEWhitelist/audit", &SysCVEWhitelistAPI{}, "get:Audit")
+	beego.Router("/api/system/webhook/policies", &SysNotificationPolicyAPI{}, "get:List;post:Post")
+	beego.Router("/api/system/webhook/policies/:id([0-9]+)", &SysNotificationPolicyAPI{})
+	beego.Router("/api/system/webhook/policies/test", &SysNotificationPolicyAPI{}, "post:Test")
+	beego.Router("/api/system/webhook/jobs", &SysNotificationPolicyAPI{}, "get:ListJobs")
+	beego.Router("/api/vulnerabilities/top", &VulnerabilityAPI{}, "get:ListTopCVEs")
+	beego.Router("/api/vulnerabilities/:id/images", &VulnerabilityAPI{}, "get:ListAffectedImages")
+	beego.Router("/api/components/images", &ComponentAPI{}, "get:ListImages")
+	beego.Router("/api/scanners", &ScannerAPI{}, "get:List;post:Post")
+	beego.Router("/api/scanners/ping", &ScannerAPI{}, "post:Ping")
+	beego.Router("/api/scanners/:uuid", &ScannerAPI{}, "get:Get;put:Put;delete:Delete;patch:SetAsDefault")
+	beego.Router("/api/scanners/:uuid/metadata", &ScannerAPI{}, "get:Metadata")
+	beego.Router("/api/projects/:pid([0-9]+)/scanner", &ProjectScannerAPI{}, "get:Get;put:Put")
+	beego.Router("/api/projects/:pid([0-9]+)/scanner/candidates", &ProjectScannerAPI{}, "get:Candidates")
 	beego.Router("/api/system/oidc/ping", &OIDCAPI{}, "post:Ping")
 
 	beego.Router("/api/projects/:pid([0-9]+)/robots/", &RobotAPI{}, "post:Post;get:List")
@@ -204,6 +221,8 @@ func init() {
 	quotaAPIType := &QuotaAPI{}
 	beego.Router("/api/quotas", quotaAPIType, "get:List")
 	beego.Router("/api/quotas/:id([0-9]+)", quotaAPIType, "get:Get;put:Put")
+	beego.Router("/api/quotas/histories", quotaAPIType, "get:ListHistories")
+	beego.Router("/api/quotas/:id([0-9]+)/histories", quotaAPIType, "get:ListHistories")
 
 	beego.Router("/api/internal/switchquota", &InternalAPI{}, "put:SwitchQuota")
 	beego.Router("/api/internal/syncquota", &InternalAPI{}, "post:SyncQuota")
@@ -1322,6 +1341,19 @@ func (a testapi) QuotasGetByID(authInfo usrInfo, quotaID string) (int, apilib.Qu
 	return httpStatusCode, successPayload, err
 }
 
+// Return the change histories of the quota
+func (a testapi) QuotaHistoriesGet(authInfo usrInfo, quotaID string) (int, []*models.QuotaHistory, error) {
+	_sling := sling.New().Get(a.basePath).Path("api/quotas/" + quotaID + "/histories")
+
+	var successPayload []*models.QuotaHistory
+
+	httpStatusCode, body, err := request(_sling, jsonAcceptHeader, authInfo)
+	if err == nil && httpStatusCode == 200 {
+		err = json.Unmarshal(body, &successPayload)
+	}
+	return httpStatusCode, successPayload, err
+}
+
 // Update spec for the quota
 func (a testapi) QuotasPut(authInfo usrInfo, quotaID string, req models.QuotaUpdateRequest) (int, error) {
 	path := "/api/quotas/" + quotaID
@@ -129,7 +129,7 @@ func (ia *InternalAPI) ensureQuota() error {
 		}
 		pCount := int64(len(afs))
 
-		// it needs to append the chart count
+		// it needs to append the chart count and size
 		if config.WithChartMuseum() {
 			count, err := chartController.GetCountOfCharts([]string{project.Name})
 			if err != nil {
@@ -138,6 +138,14 @@ func (ia *InternalAPI) ensureQuota() error {
 				continue
 			}
 			pCount = pCount + int64(count)
+
+			size, err := chartController.GetSizeOfCharts(project.Name)
+			if err != nil {
+				err = errors.Wrap(err, fmt.Sprintf("get chart size of project %d failed", project.ProjectID))
+				logger.Error(err)
+				continue
+			}
+			pSize = pSize + size
 		}
 
 		quotaMgr, err := common_quota.NewManager("project", strconv.FormatInt(project.ProjectID, 10))
@@ -149,7 +157,7 @@ func (ia *InternalAPI) ensureQuota() error {
 			common_quota.ResourceStorage: pSize,
 			common_quota.ResourceCount:   pCount,
 		}
-		if err := quotaMgr.EnsureQuota(used); err != nil {
+		if err := quotaMgr.WithOperator(ia.SecurityCtx.GetUsername()).EnsureQuota(used); err != nil {
 			logger.Errorf("cannot ensure quota for the project: %d, err: %v, just skip it.", project.ProjectID, err)
 			continue
 		}
@@ -215,7 +215,9 @@ func validateProjectMetadata(metas map[string]string) (map[string]string, error)
 		models.ProMetaPublic,
 		models.ProMetaEnableContentTrust,
 		models.ProMetaPreventVul,
-		models.ProMetaAutoScan}
+		models.ProMetaPreventVulFixable,
+		models.ProMetaAutoScan,
+		models.ProMetaQuarantineOnPush}
 
 	for _, boolMeta := range boolMetas {
 		value, exist := metas[boolMeta]
@@ -238,5 +240,14 @@ func validateProjectMetadata(metas map[string]string) (map[string]string, error)
 		}
 	}
 
+	value, exist = metas[models.ProMetaPreventVulCVSS]
+	if exist {
+		score, err := strconv.ParseFloat(value, 64)
+		if err != nil || score < 0 || score > 10 {
+			return nil, fmt.Errorf("invalid CVSS score %s, it must be between 0 and 10", value)
+		}
+		metas[models.ProMetaPreventVulCVSS] = strconv.FormatFloat(score, 'f', -1, 64)
+	}
+
 	return metas, nil
 }
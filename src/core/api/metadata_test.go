@@ -60,6 +60,25 @@ func TestValidateProjectMetadata(t *testing.T) {
 	ms, err = validateProjectMetadata(metas)
 	require.Nil(t, err)
 	assert.Equal(t, "high", ms[models.ProMetaSeverity])
+
+	// invalid CVSS score
+	metas = map[string]string{
+		models.ProMetaPreventVulCVSS: "11",
+	}
+	ms, err = validateProjectMetadata(metas)
+	require.NotNil(t, err)
+
+	// valid CVSS score
+	metas = map[string]string{
+		models.ProMetaPreventVulCVSS:    "8.0",
+		models.ProMetaPreventVulFixable: "1",
+		models.ProMetaQuarantineOnPush:  "1",
+	}
+	ms, err = validateProjectMetadata(metas)
+	require.Nil(t, err)
+	assert.Equal(t, "8", ms[models.ProMetaPreventVulCVSS])
+	assert.Equal(t, "true", ms[models.ProMetaPreventVulFixable])
+	assert.Equal(t, "true", ms[models.ProMetaQuarantineOnPush])
 }
 
 func TestMetaAPI(t *testing.T) {
@@ -27,6 +27,7 @@ import (
 	common_utils "github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/goharbor/harbor/src/core/config"
+	jjob "github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/robfig/cron"
 )
 
@@ -78,6 +79,8 @@ type AdminJobRep struct {
 	Deleted      bool      `json:"deleted"`
 	CreationTime time.Time `json:"creation_time"`
 	UpdateTime   time.Time `json:"update_time"`
+	// Progress reported by the running job
+	Progress *jjob.Progress `json:"progress,omitempty"`
 }
 
 // Valid validates the schedule type of a admin job request.
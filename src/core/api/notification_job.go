@@ -67,6 +67,11 @@ func (w *NotificationJobAPI) List() {
 		return
 	}
 
+	if w.project.ProjectID != policy.ProjectID {
+		w.SendBadRequestError(fmt.Errorf("notification policy %d with projectID %d not belong to project %d in URL", policyID, policy.ProjectID, w.project.ProjectID))
+		return
+	}
+
 	query := &models.NotificationJobQuery{
 		PolicyID: policyID,
 	}
@@ -84,6 +84,15 @@ func TestNotificationJobAPI_List(t *testing.T) {
 			},
 			code: http.StatusBadRequest,
 		},
+		// 400 system level policy not belong to the project
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/projects/1/webhook/jobs?policy_id=4",
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
 		// 404 project not found
 		{
 			request: &testingRequest{
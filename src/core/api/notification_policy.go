@@ -13,6 +13,7 @@ import (
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notification/formatter"
 )
 
 // NotificationPolicyAPI ...
@@ -86,6 +87,7 @@ func (w *NotificationPolicyAPI) Get() {
 		return
 	}
 
+	hideTargetSecrets(policy)
 	w.WriteJSONData(policy)
 }
 
@@ -117,6 +119,8 @@ func (w *NotificationPolicyAPI) Post() {
 
 	policy.Creator = w.SecurityCtx.GetUsername()
 	policy.ProjectID = w.project.ProjectID
+	// the project pattern only applies to the system level policies
+	policy.ProjectPattern = ""
 
 	id, err := notification.PolicyMgr.Create(policy)
 	if err != nil {
@@ -170,6 +174,8 @@ func (w *NotificationPolicyAPI) Put() {
 
 	policy.ID = id
 	policy.ProjectID = w.project.ProjectID
+	policy.ProjectPattern = ""
+	keepTargetSecrets(policy, oriPolicy)
 
 	if err = notification.PolicyMgr.Update(policy); err != nil {
 		w.SendInternalServerError(fmt.Errorf("failed to update the notification policy: %v", err))
@@ -197,6 +203,7 @@ func (w *NotificationPolicyAPI) List() {
 		}
 	}
 
+	hideTargetSecrets(policies...)
 	w.WriteJSONData(policies)
 }
 
@@ -290,45 +297,83 @@ func (w *NotificationPolicyAPI) validateRBAC(action rbac.Action, projectID int64
 }
 
 func (w *NotificationPolicyAPI) validateTargets(policy *models.NotificationPolicy) bool {
-	if len(policy.Targets) == 0 {
-		w.SendBadRequestError(fmt.Errorf("empty notification target with policy %s", policy.Name))
+	if err := validateNotificationTargets(policy); err != nil {
+		w.SendBadRequestError(err)
 		return false
 	}
+	return true
+}
+
+func (w *NotificationPolicyAPI) validateEventTypes(policy *models.NotificationPolicy) bool {
+	if err := validateNotificationEventTypes(policy); err != nil {
+		w.SendBadRequestError(err)
+		return false
+	}
+	return true
+}
+
+func validateNotificationTargets(policy *models.NotificationPolicy) error {
+	if len(policy.Targets) == 0 {
+		return fmt.Errorf("empty notification target with policy %s", policy.Name)
+	}
 
 	for _, target := range policy.Targets {
 		url, err := utils.ParseEndpoint(target.Address)
 		if err != nil {
-			w.SendBadRequestError(err)
-			return false
+			return err
 		}
 		// Prevent SSRF security issue #3755
 		target.Address = url.Scheme + "://" + url.Host + url.Path
 
 		_, ok := notification.SupportedNotifyTypes[target.Type]
 		if !ok {
-			w.SendBadRequestError(fmt.Errorf("unsupport target type %s with policy %s", target.Type, policy.Name))
-			return false
+			return fmt.Errorf("unsupport target type %s with policy %s", target.Type, policy.Name)
+		}
+
+		if err := formatter.ValidateTarget(&target); err != nil {
+			return err
 		}
 	}
 
-	return true
+	return nil
 }
 
-func (w *NotificationPolicyAPI) validateEventTypes(policy *models.NotificationPolicy) bool {
+// hideTargetSecrets blanks the secrets of the targets of the policies, which are never returned to the client
+func hideTargetSecrets(policies ...*models.NotificationPolicy) {
+	for _, policy := range policies {
+		for i := range policy.Targets {
+			policy.Targets[i].Secret = ""
+		}
+	}
+}
+
+// keepTargetSecrets keeps the stored secrets of the targets whose secrets are omitted in the request,
+// the targets are matched by the address
+func keepTargetSecrets(policy, oriPolicy *models.NotificationPolicy) {
+	secrets := map[string]string{}
+	for _, target := range oriPolicy.Targets {
+		secrets[target.Address] = target.Secret
+	}
+	for i := range policy.Targets {
+		if len(policy.Targets[i].Secret) == 0 {
+			policy.Targets[i].Secret = secrets[policy.Targets[i].Address]
+		}
+	}
+}
+
+func validateNotificationEventTypes(policy *models.NotificationPolicy) error {
 	if len(policy.EventTypes) == 0 {
-		w.SendBadRequestError(errors.New("empty event type"))
-		return false
+		return errors.New("empty event type")
 	}
 
 	for _, eventType := range policy.EventTypes {
 		_, ok := notification.SupportedEventTypes[eventType]
 		if !ok {
-			w.SendBadRequestError(fmt.Errorf("unsupport event type %s", eventType))
-			return false
+			return fmt.Errorf("unsupport event type %s", eventType)
 		}
 	}
 
-	return true
+	return nil
 }
 
 func getLastTriggerTimeGroupByEventType(eventType string, policyID int64) (time.Time, error) {
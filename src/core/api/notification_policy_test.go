@@ -39,6 +39,8 @@ func (f *fakedNotificationPlyMgr) Get(id int64) (*models.NotificationPolicy, err
 		return &models.NotificationPolicy{ID: 2, ProjectID: 222}, nil
 	case 3:
 		return nil, errors.New("")
+	case 4:
+		return &models.NotificationPolicy{ID: 4, ProjectID: models.SystemNotificationPolicyProjectID}, nil
 	default:
 		return nil, nil
 	}
@@ -61,7 +63,7 @@ func (f *fakedNotificationPlyMgr) Test(*models.NotificationPolicy) error {
 	return nil
 }
 
-func (f *fakedNotificationPlyMgr) GetRelatedPolices(int64, string) ([]*models.NotificationPolicy, error) {
+func (f *fakedNotificationPlyMgr) GetRelatedPolices(*models.Project, string) ([]*models.NotificationPolicy, error) {
 	return nil, nil
 }
 
@@ -231,6 +233,25 @@ func TestNotificationPolicyAPI_Post(t *testing.T) {
 			},
 			code: http.StatusBadRequest,
 		},
+		// 400 invalid payload template
+		{
+			request: &testingRequest{
+				method:     http.MethodPost,
+				url:        "/api/projects/1/webhook/policies",
+				credential: sysAdmin,
+				bodyJSON: &models.NotificationPolicy{
+					EventTypes: []string{"pullImage", "pushImage", "deleteImage"},
+					Targets: []models.EventTarget{
+						{
+							Type:            "template",
+							Address:         "http://10.173.32.58:9009",
+							PayloadTemplate: `{"text": {{ json .Type }`,
+						},
+					},
+				},
+			},
+			code: http.StatusBadRequest,
+		},
 		// 201
 		{
 			request: &testingRequest{
@@ -32,6 +32,9 @@ import (
 	errutil "github.com/goharbor/harbor/src/common/utils/error"
 	"github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/pkg/scan"
+	"github.com/goharbor/harbor/src/pkg/scan/export"
+	"github.com/goharbor/harbor/src/pkg/scan/whitelist"
 	"github.com/goharbor/harbor/src/pkg/types"
 	"github.com/pkg/errors"
 )
@@ -209,7 +212,7 @@ func (p *ProjectAPI) Post() {
 			p.SendInternalServerError(fmt.Errorf("failed to get quota manager: %v", err))
 			return
 		}
-		if _, err := quotaMgr.NewQuota(hardLimits); err != nil {
+		if _, err := quotaMgr.WithOperator(p.SecurityCtx.GetUsername()).NewQuota(hardLimits); err != nil {
 			p.SendInternalServerError(fmt.Errorf("failed to create quota for project: %v", err))
 			return
 		}
@@ -505,11 +508,21 @@ func (p *ProjectAPI) Put() {
 		return
 	}
 
+	existing, err := whitelist.NewDefaultManager().Get(p.project.ProjectID)
+	if err != nil {
+		p.SendInternalServerError(fmt.Errorf("failed to get the CVE whitelist of project %d: %v", p.project.ProjectID, err))
+		return
+	}
+	whitelist.FillApprover(&req.CVEWhitelist, existing, p.SecurityCtx.GetUsername())
 	if err := p.ProjectMgr.Update(p.project.ProjectID,
 		&models.Project{
 			Metadata:     req.Metadata,
 			CVEWhitelist: req.CVEWhitelist,
 		}); err != nil {
+		if whitelist.IsInvalidErr(err) {
+			p.SendBadRequestError(err)
+			return
+		}
 		p.ParseAndHandleError(fmt.Sprintf("failed to update project %d",
 			p.project.ProjectID), err)
 		return
@@ -609,6 +622,59 @@ func (p *ProjectAPI) Summary() {
 	p.ServeJSON()
 }
 
+// ExportVulnerabilities exports the vulnerabilities of the scanned images of the project in SARIF or CSV format,
+// which is specified by the query parameter "format". The images not scanned are skipped.
+func (p *ProjectAPI) ExportVulnerabilities() {
+	if !p.requireAccess(rbac.ActionList, rbac.ResourceRepositoryTagVulnerability) {
+		return
+	}
+
+	images := []*export.Image{}
+	vls := map[string]scan.VulnerabilityList{}
+	query := &models.ArtifactQuery{
+		PID:        p.project.ProjectID,
+		Pagination: models.Pagination{Page: 1, Size: 1000},
+		// Keep the order stable across the pages
+		Sorting: models.Sorting{Sort: "repo"},
+	}
+	for {
+		artifacts, err := dao.ListArtifacts(query)
+		if err != nil {
+			p.SendInternalServerError(fmt.Errorf("failed to list the artifacts of project %d: %v", p.project.ProjectID, err))
+			return
+		}
+		for _, af := range artifacts {
+			vl, ok := vls[af.Digest]
+			if !ok {
+				var scanned bool
+				vl, scanned, err = scannedVulnList(af.Digest)
+				if err != nil {
+					p.SendInternalServerError(fmt.Errorf("failed to get the vulnerabilities of %s:%s: %v", af.Repo, af.Tag, err))
+					return
+				}
+				if !scanned {
+					vl = nil
+				}
+				vls[af.Digest] = vl
+			}
+			if vl == nil {
+				continue
+			}
+			images = append(images, &export.Image{
+				Repository:      af.Repo,
+				Tag:             af.Tag,
+				Digest:          af.Digest,
+				Vulnerabilities: vl,
+			})
+		}
+		if int64(len(artifacts)) < query.Size {
+			break
+		}
+		query.Page++
+	}
+	writeVulnerabilityExport(&p.BaseController, images)
+}
+
 // TODO move this to pa ckage models
 func validateProjectReq(req *models.ProjectRequest) error {
 	pn := req.Name
@@ -111,7 +111,7 @@ func (qa *QuotaAPI) Put() {
 		return
 	}
 
-	if err := mgr.UpdateQuota(req.Hard); err != nil {
+	if err := mgr.WithOperator(qa.SecurityCtx.GetUsername()).UpdateQuota(req.Hard); err != nil {
 		qa.SendInternalServerError(fmt.Errorf("failed to update hard limits of the quota, error: %v", err))
 		return
 	}
@@ -153,3 +153,45 @@ func (qa *QuotaAPI) List() {
 	qa.Data["json"] = quotas
 	qa.ServeJSON()
 }
+
+// ListHistories returns the change histories of quotas by query,
+// only the histories of the quota returned when the quota ID specified in the path
+func (qa *QuotaAPI) ListHistories() {
+	page, size, err := qa.GetPaginationParams()
+	if err != nil {
+		qa.SendBadRequestError(err)
+		return
+	}
+
+	query := &models.QuotaHistoryQuery{
+		Reference:   qa.GetString("reference"),
+		ReferenceID: qa.GetString("reference_id"),
+		Action:      qa.GetString("action"),
+		Operator:    qa.GetString("operator"),
+		Pagination: models.Pagination{
+			Page: page,
+			Size: size,
+		},
+	}
+
+	if qa.quota != nil {
+		query.Reference = qa.quota.Reference
+		query.ReferenceID = qa.quota.ReferenceID
+	}
+
+	total, err := dao.GetTotalOfQuotaHistories(query)
+	if err != nil {
+		qa.SendInternalServerError(fmt.Errorf("failed to query database for total of quota histories, error: %v", err))
+		return
+	}
+
+	histories, err := dao.ListQuotaHistories(query)
+	if err != nil {
+		qa.SendInternalServerError(fmt.Errorf("failed to query database for quota histories, error: %v", err))
+		return
+	}
+
+	qa.SetPaginationHeader(total, page, size)
+	qa.Data["json"] = histories
+	qa.ServeJSON()
+}
@@ -119,7 +119,10 @@ func (rm *Migrator) Dump() ([]quota.ProjectInfo, error) {
 
 			// repo
 			for _, chart := range chartInfo {
-				var afs []*models.Artifact
+				var (
+					afs   []*models.Artifact
+					blobs []*models.Blob
+				)
 				chartVersions, err := ctr.GetChart(project.Name, chart.Name)
 				if err != nil {
 					errChan <- err
@@ -134,10 +137,23 @@ func (rm *Migrator) Dump() ([]quota.ProjectInfo, error) {
 						Kind:   "Chart",
 					}
 					afs = append(afs, af)
+
+					// the chart package is treated as blob to compute the storage usage
+					size, err := ctr.GetChartVersionSize(project.Name, chart.Name, chart.Version)
+					if err != nil {
+						errChan <- err
+						continue
+					}
+					blob := &models.Blob{
+						Digest: chart.Digest,
+						Size:   size,
+					}
+					blobs = append(blobs, blob)
 				}
 				repoData := quota.RepoData{
-					Name: project.Name,
-					Afs:  afs,
+					Name:  project.Name,
+					Afs:   afs,
+					Blobs: blobs,
 				}
 				repos = append(repos, repoData)
 			}
@@ -164,20 +180,23 @@ func (rm *Migrator) Dump() ([]quota.ProjectInfo, error) {
 }
 
 // Usage ...
-// Chart will not cover size.
+// The size of chart is the sum of the chart packages.
 func (rm *Migrator) Usage(projects []quota.ProjectInfo) ([]quota.ProjectUsage, error) {
 	var pros []quota.ProjectUsage
 	for _, project := range projects {
-		var count int64
-		// usage count
+		var count, size int64
+		// usage count and size
 		for _, repo := range project.Repos {
 			count = count + int64(len(repo.Afs))
+			for _, blob := range repo.Blobs {
+				size = size + blob.Size
+			}
 		}
 		proUsage := quota.ProjectUsage{
 			Project: project.Name,
 			Used: common_quota.ResourceList{
 				common_quota.ResourceCount:   count,
-				common_quota.ResourceStorage: 0,
+				common_quota.ResourceStorage: size,
 			},
 		}
 		pros = append(pros, proUsage)
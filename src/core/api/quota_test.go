@@ -131,3 +131,32 @@ func TestQuotaPut(t *testing.T) {
 	assert.Equal(int(200), code)
 	assert.Equal(map[string]int64{"count": 100, "storage": 100}, quota.Hard)
 }
+
+func TestQuotaHistories(t *testing.T) {
+	assert := assert.New(t)
+	apiTest := newHarborAPI()
+
+	mgr, err := quota.NewManager(reference, "quota-histories")
+	assert.Nil(err)
+
+	quotaID, err := mgr.NewQuota(hardLimits)
+	assert.Nil(err)
+
+	code, err := apiTest.QuotasPut(*admin, fmt.Sprintf("%d", quotaID), models.QuotaUpdateRequest{Hard: types.ResourceList{types.ResourceCount: 100, types.ResourceStorage: 100}})
+	assert.Nil(err)
+	assert.Equal(int(200), code)
+
+	code, histories, err := apiTest.QuotaHistoriesGet(*admin, fmt.Sprintf("%d", quotaID))
+	assert.Nil(err)
+	assert.Equal(int(200), code)
+	if assert.Len(histories, 2) {
+		assert.Equal(models.QuotaHistoryActionUpdate, histories[0].Action)
+		assert.Equal(admin.Name, histories[0].Operator)
+		assert.Equal(models.QuotaHistoryActionCreate, histories[1].Action)
+		assert.Equal(quota.OperatorSystem, histories[1].Operator)
+	}
+
+	code, _, err = apiTest.QuotaHistoriesGet(*testUser, fmt.Sprintf("%d", quotaID))
+	assert.Nil(err)
+	assert.Equal(int(403), code)
+}
@@ -227,6 +227,11 @@ func (r *ReplicationOperationAPI) ListTasks() {
 
 // GetTaskLog ...
 func (r *ReplicationOperationAPI) GetTaskLog() {
+	if follow, _ := r.GetBool("follow"); follow {
+		r.FollowJobLog(r.task.JobID)
+		return
+	}
+
 	logBytes, err := replication.OperationCtl.GetTaskLog(r.task.ID)
 	if err != nil {
 		if httpErr, ok := err.(*common_http.Error); ok {
@@ -41,6 +41,9 @@ import (
 	notifierEvt "github.com/goharbor/harbor/src/core/notifier/event"
 	coreutils "github.com/goharbor/harbor/src/core/utils"
 	"github.com/goharbor/harbor/src/pkg/scan"
+	"github.com/goharbor/harbor/src/pkg/scan/export"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom"
+	"github.com/goharbor/harbor/src/pkg/scan/scanner"
 	"github.com/goharbor/harbor/src/replication"
 	"github.com/goharbor/harbor/src/replication/event"
 	"github.com/goharbor/harbor/src/replication/model"
@@ -375,6 +378,9 @@ func (ra *RepositoryAPI) Delete() {
 			ra.SendInternalServerError(fmt.Errorf("failed to delete repository %s: %v", repoName, err))
 			return
 		}
+		if err = dao.DeleteImgQuarantines(repoName); err != nil {
+			log.Errorf("failed to delete the quarantine records of repository %s: %v", repoName, err)
+		}
 	}
 }
 
@@ -619,9 +625,10 @@ func assembleTagsInParallel(client *registry.Repository, repository string,
 		}
 	}
 
+	scanEnabled := scanner.Enabled()
 	c := make(chan *models.TagResp)
 	for _, tag := range tags {
-		go assembleTag(c, client, repository, tag, config.WithClair(),
+		go assembleTag(c, client, repository, tag, scanEnabled,
 			config.WithNotary(), signatures)
 	}
 	result := []*models.TagResp{}
@@ -970,9 +977,9 @@ func (ra *RepositoryAPI) GetSignatures() {
 
 // ScanImage handles request POST /api/repository/$repository/tags/$tag/scan to trigger image scan manually.
 func (ra *RepositoryAPI) ScanImage() {
-	if !config.WithClair() {
-		log.Warningf("Harbor is not deployed with Clair, scan is disabled.")
-		ra.SendInternalServerError(errors.New("harbor is not deployed with Clair, scan is disabled"))
+	if !scanner.Enabled() {
+		log.Warningf("No scanner is available, scan is disabled.")
+		ra.SendInternalServerError(errors.New("no scanner is available, scan is disabled"))
 		return
 	}
 	repoName := ra.GetString(":splat")
@@ -1004,11 +1011,11 @@ func (ra *RepositoryAPI) ScanImage() {
 	}
 }
 
-// VulnerabilityDetails fetch vulnerability info from clair, transform to Harbor's format and return to client.
+// VulnerabilityDetails fetch vulnerability info from the scanner, transform to Harbor's format and return to client.
 func (ra *RepositoryAPI) VulnerabilityDetails() {
-	if !config.WithClair() {
-		log.Warningf("Harbor is not deployed with Clair, it's not impossible to get vulnerability details.")
-		ra.SendInternalServerError(errors.New("harbor is not deployed with Clair, it's not impossible to get vulnerability details"))
+	if !scanner.Enabled() {
+		log.Warningf("No scanner is available, it's not impossible to get vulnerability details.")
+		ra.SendInternalServerError(errors.New("no scanner is available, it's not impossible to get vulnerability details"))
 		return
 	}
 	repository := ra.GetString(":splat")
@@ -1035,6 +1042,103 @@ func (ra *RepositoryAPI) VulnerabilityDetails() {
 	ra.ServeJSON()
 }
 
+// GetSBOM handles request GET /api/repositories/$repository/tags/$tag/sbom to retrieve the software bill of materials
+// of the scanned image in SPDX or CycloneDX format, which is specified by the query parameter "format"
+func (ra *RepositoryAPI) GetSBOM() {
+	repository := ra.GetString(":splat")
+	tag := ra.GetString(":tag")
+	exist, digest, err := ra.checkExistence(repository, tag)
+	if err != nil {
+		ra.SendInternalServerError(fmt.Errorf("failed to check the existence of resource, error: %v", err))
+		return
+	}
+	if !exist {
+		ra.SendNotFoundError(fmt.Errorf("resource: %s:%s not found", repository, tag))
+		return
+	}
+
+	projectName, _ := utils.ParseRepository(repository)
+	if !ra.RequireProjectAccess(projectName, rbac.ActionList, rbac.ResourceRepositoryTagVulnerability) {
+		return
+	}
+
+	components, err := sbom.ComponentsByDigest(digest)
+	if err != nil {
+		log.Errorf("Failed to get the components of image %s:%s: %v", repository, tag, err)
+		ra.SendNotFoundError(fmt.Errorf("no software bill of materials of %s:%s, please scan it first", repository, tag))
+		return
+	}
+	created := time.Now()
+	if len(components) > 0 {
+		created = components[0].CreationTime
+	}
+	baseURL, err := config.ExtEndpoint()
+	if err != nil {
+		ra.SendInternalServerError(err)
+		return
+	}
+
+	mimeType, doc, err := sbom.Generate(ra.GetString("format"), &sbom.Artifact{
+		Repository: repository,
+		Tag:        tag,
+		Digest:     digest,
+		BaseURL:    baseURL,
+	}, components, created)
+	if err != nil {
+		ra.SendBadRequestError(err)
+		return
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		ra.SendInternalServerError(err)
+		return
+	}
+
+	w := ra.Ctx.ResponseWriter
+	w.Header().Set("Content-Type", mimeType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// ExportVulnerabilities handles request GET /api/repositories/$repository/tags/$tag/vulnerability/export to export
+// the vulnerabilities of the scanned image in SARIF or CSV format, which is specified by the query parameter "format"
+func (ra *RepositoryAPI) ExportVulnerabilities() {
+	repository := ra.GetString(":splat")
+	tag := ra.GetString(":tag")
+	exist, digest, err := ra.checkExistence(repository, tag)
+	if err != nil {
+		ra.SendInternalServerError(fmt.Errorf("failed to check the existence of resource, error: %v", err))
+		return
+	}
+	if !exist {
+		ra.SendNotFoundError(fmt.Errorf("resource: %s:%s not found", repository, tag))
+		return
+	}
+
+	projectName, _ := utils.ParseRepository(repository)
+	if !ra.RequireProjectAccess(projectName, rbac.ActionList, rbac.ResourceRepositoryTagVulnerability) {
+		return
+	}
+
+	vl, scanned, err := scannedVulnList(digest)
+	if err != nil {
+		ra.SendInternalServerError(fmt.Errorf("failed to get the vulnerabilities of %s:%s: %v", repository, tag, err))
+		return
+	}
+	if !scanned {
+		ra.SendNotFoundError(fmt.Errorf("no scan report of %s:%s, please scan it first", repository, tag))
+		return
+	}
+	writeVulnerabilityExport(&ra.BaseController, []*export.Image{
+		{
+			Repository:      repository,
+			Tag:             tag,
+			Digest:          digest,
+			Vulnerabilities: vl,
+		},
+	})
+}
+
 func getSignatures(username, repository string) (map[string][]notarymodel.Target, error) {
 	targets, err := notary.GetInternalTargets(config.InternalNotaryEndpoint(),
 		username, repository)
@@ -414,6 +414,19 @@ func (r *RetentionAPI) GetRetentionExecTaskLog() {
 	if !r.requireAccess(p, rbac.ActionRead) {
 		return
 	}
+	if follow, _ := r.GetBool("follow"); follow {
+		task, err := retentionMgr.GetTask(tid)
+		if err != nil {
+			r.SendInternalServerError(err)
+			return
+		}
+		if task == nil {
+			r.SendNotFoundError(fmt.Errorf("task %d not found", tid))
+			return
+		}
+		r.FollowJobLog(task.JobID)
+		return
+	}
 	log, err := retentionController.GetRetentionExecTaskLog(tid)
 	if err != nil {
 		r.SendInternalServerError(err)
@@ -8,7 +8,7 @@ import (
 	common_job "github.com/goharbor/harbor/src/common/job"
 	"github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/goharbor/harbor/src/core/api/models"
-	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/pkg/scan/scanner"
 )
 
 // ScanAllAPI handles request of scan all images...
@@ -19,8 +19,8 @@ type ScanAllAPI struct {
 // Prepare validates the URL and parms, it needs the system admin permission.
 func (sc *ScanAllAPI) Prepare() {
 	sc.BaseController.Prepare()
-	if !config.WithClair() {
-		log.Warningf("Harbor is not deployed with Clair, it's not possible to scan images.")
+	if !scanner.Enabled() {
+		log.Warningf("No scanner is available, it's not possible to scan images.")
 		sc.SendStatusServiceUnavailableError(errors.New(""))
 		return
 	}
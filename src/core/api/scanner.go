@@ -0,0 +1,313 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/pkg/scan/adapter"
+	"github.com/goharbor/harbor/src/pkg/scan/scanner"
+)
+
+// ScannerAPI handles the requests to manage the scanner registrations, it needs the system admin permission
+type ScannerAPI struct {
+	BaseController
+	manager      scanner.Manager
+	registration *models.ScannerRegistration
+}
+
+// Prepare validates the request initially
+func (s *ScannerAPI) Prepare() {
+	s.BaseController.Prepare()
+	if !s.SecurityCtx.IsAuthenticated() {
+		s.SendUnAuthorizedError(errors.New("Unauthorized"))
+		return
+	}
+	if !s.SecurityCtx.IsSysAdmin() {
+		s.SendForbiddenError(errors.New(s.SecurityCtx.GetUsername()))
+		return
+	}
+	s.manager = scanner.NewDefaultManager()
+
+	if s.ParamExistsInPath(":uuid") {
+		uuid := s.GetStringFromPath(":uuid")
+		r, err := s.manager.Get(uuid)
+		if err != nil {
+			s.SendInternalServerError(fmt.Errorf("failed to get scanner registration %s: %v", uuid, err))
+			return
+		}
+		if r == nil {
+			s.SendNotFoundError(fmt.Errorf("scanner registration %s not found", uuid))
+			return
+		}
+		s.registration = r
+	}
+}
+
+// List handles the GET request to list all the scanner registrations
+func (s *ScannerAPI) List() {
+	rs, err := s.manager.List()
+	if err != nil {
+		s.SendInternalServerError(fmt.Errorf("failed to list scanner registrations: %v", err))
+		return
+	}
+	for _, r := range rs {
+		r.AccessCredential = ""
+	}
+	s.WriteJSONData(rs)
+}
+
+// Get handles the GET request to retrieve the scanner registration
+func (s *ScannerAPI) Get() {
+	s.registration.AccessCredential = ""
+	s.WriteJSONData(s.registration)
+}
+
+// Post handles the POST request to create a scanner registration
+func (s *ScannerAPI) Post() {
+	r := &models.ScannerRegistration{}
+	if err := s.DecodeJSONReq(r); err != nil {
+		s.SendBadRequestError(err)
+		return
+	}
+
+	uuid, err := s.manager.Create(r)
+	if err != nil {
+		s.handleError(err)
+		return
+	}
+	s.Redirect(http.StatusCreated, uuid)
+}
+
+// Put handles the PUT request to update the scanner registration,
+// the access credential is kept if it's not provided
+func (s *ScannerAPI) Put() {
+	r := &models.ScannerRegistration{}
+	if err := s.DecodeJSONReq(r); err != nil {
+		s.SendBadRequestError(err)
+		return
+	}
+	r.UUID = s.registration.UUID
+	if len(r.AccessCredential) == 0 && r.Auth == s.registration.Auth {
+		r.AccessCredential = s.registration.AccessCredential
+	}
+
+	if err := s.manager.Update(r); err != nil {
+		s.handleError(err)
+		return
+	}
+}
+
+// Delete handles the DELETE request to delete the scanner registration
+func (s *ScannerAPI) Delete() {
+	if err := s.manager.Delete(s.registration.UUID); err != nil {
+		s.handleError(err)
+		return
+	}
+}
+
+// SetAsDefault handles the PATCH request to set the scanner registration as the default one
+//
+//	{
+//		"is_default": true
+//	}
+func (s *ScannerAPI) SetAsDefault() {
+	req := &struct {
+		IsDefault bool `json:"is_default"`
+	}{}
+	if err := s.DecodeJSONReq(req); err != nil {
+		s.SendBadRequestError(err)
+		return
+	}
+	if !req.IsDefault {
+		s.SendBadRequestError(errors.New("only setting the scanner registration as the default one is supported"))
+		return
+	}
+
+	if err := s.manager.SetAsDefault(s.registration.UUID); err != nil {
+		s.handleError(err)
+		return
+	}
+}
+
+// Metadata handles the GET request to retrieve the metadata of the scanner adapter
+func (s *ScannerAPI) Metadata() {
+	meta, err := getScannerMetadata(s.registration)
+	if err != nil {
+		s.SendStatusServiceUnavailableError(err)
+		return
+	}
+	s.WriteJSONData(meta)
+}
+
+// Ping handles the POST request to test the connection to the scanner adapter of the registration in the request body
+func (s *ScannerAPI) Ping() {
+	r := &models.ScannerRegistration{}
+	if err := s.DecodeJSONReq(r); err != nil {
+		s.SendBadRequestError(err)
+		return
+	}
+	if _, err := adapter.NewClient(r.URL, r.Auth, r.AccessCredential, r.SkipCertVerify); err != nil {
+		s.SendBadRequestError(err)
+		return
+	}
+
+	meta, err := getScannerMetadata(r)
+	if err != nil {
+		s.SendBadRequestError(err)
+		return
+	}
+	s.WriteJSONData(meta)
+}
+
+func (s *ScannerAPI) handleError(err error) {
+	if scanner.IsInvalidErr(err) {
+		s.SendBadRequestError(err)
+		return
+	}
+	s.SendInternalServerError(err)
+}
+
+// getScannerMetadata gets the metadata of the scanner adapter and checks if it's able to scan the images
+func getScannerMetadata(r *models.ScannerRegistration) (*adapter.ScannerAdapterMetadata, error) {
+	a, err := scanner.NewAdapter(r)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := a.GetMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the metadata of scanner %s: %v", r.Name, err)
+	}
+	if err := meta.Validate(); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// ProjectScannerAPI handles the requests to get and set the scanner used by the project
+type ProjectScannerAPI struct {
+	BaseController
+	manager scanner.Manager
+	project *models.Project
+}
+
+// Prepare validates the request initially
+func (p *ProjectScannerAPI) Prepare() {
+	p.BaseController.Prepare()
+	if !p.SecurityCtx.IsAuthenticated() {
+		p.SendUnAuthorizedError(errors.New("Unauthorized"))
+		return
+	}
+
+	pid, err := p.GetInt64FromPath(":pid")
+	if err != nil || pid <= 0 {
+		p.SendBadRequestError(fmt.Errorf("invalid project ID %s", p.GetStringFromPath(":pid")))
+		return
+	}
+	project, err := p.ProjectMgr.Get(pid)
+	if err != nil {
+		p.ParseAndHandleError(fmt.Sprintf("failed to get project %d", pid), err)
+		return
+	}
+	if project == nil {
+		p.SendNotFoundError(fmt.Errorf("project %d not found", pid))
+		return
+	}
+	p.project = project
+	p.manager = scanner.NewDefaultManager()
+}
+
+// Get handles the GET request to retrieve the scanner used by the project
+func (p *ProjectScannerAPI) Get() {
+	if !p.RequireProjectAccess(p.project.ProjectID, rbac.ActionRead, rbac.ResourceConfiguration) {
+		return
+	}
+
+	r, err := p.manager.GetProjectScanner(p.project.ProjectID)
+	if err != nil {
+		p.SendInternalServerError(fmt.Errorf("failed to get the scanner of project %d: %v", p.project.ProjectID, err))
+		return
+	}
+	if r == nil {
+		p.SendNotFoundError(fmt.Errorf("no scanner is available for project %d", p.project.ProjectID))
+		return
+	}
+	p.WriteJSONData(projectScannerView(r))
+}
+
+// Put handles the PUT request to set the scanner used by the project
+//
+//	{
+//		"uuid": "<the UUID of the scanner registration>"
+//	}
+func (p *ProjectScannerAPI) Put() {
+	if !p.RequireProjectAccess(p.project.ProjectID, rbac.ActionUpdate, rbac.ResourceConfiguration) {
+		return
+	}
+
+	req := &struct {
+		UUID string `json:"uuid"`
+	}{}
+	if err := p.DecodeJSONReq(req); err != nil {
+		p.SendBadRequestError(err)
+		return
+	}
+	if len(req.UUID) == 0 {
+		p.SendBadRequestError(errors.New("missing UUID of the scanner registration"))
+		return
+	}
+
+	if err := p.manager.SetProjectScanner(p.project.ProjectID, req.UUID); err != nil {
+		if scanner.IsInvalidErr(err) {
+			p.SendBadRequestError(err)
+			return
+		}
+		p.SendInternalServerError(err)
+	}
+}
+
+// Candidates handles the GET request to list the scanners which can be used by the project
+func (p *ProjectScannerAPI) Candidates() {
+	if !p.RequireProjectAccess(p.project.ProjectID, rbac.ActionUpdate, rbac.ResourceConfiguration) {
+		return
+	}
+
+	rs, err := p.manager.List()
+	if err != nil {
+		p.SendInternalServerError(fmt.Errorf("failed to list scanner registrations: %v", err))
+		return
+	}
+	candidates := []*models.ScannerRegistration{}
+	for _, r := range rs {
+		if !r.Disabled {
+			candidates = append(candidates, projectScannerView(r))
+		}
+	}
+	p.WriteJSONData(candidates)
+}
+
+// projectScannerView hides the connection details of the scanner registration from the project members
+func projectScannerView(r *models.ScannerRegistration) *models.ScannerRegistration {
+	return &models.ScannerRegistration{
+		UUID:        r.UUID,
+		Name:        r.Name,
+		Description: r.Description,
+		IsDefault:   r.IsDefault,
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/models"
+)
+
+func TestScannerAPI(t *testing.T) {
+	url := "/api/scanners"
+	cases := []*codeCheckingCase{
+		// 401
+		{
+			request: &testingRequest{
+				method: http.MethodGet,
+				url:    url,
+			},
+			code: http.StatusUnauthorized,
+		},
+		// 403
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        url,
+				credential: nonSysAdmin,
+			},
+			code: http.StatusForbidden,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        url,
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+		// 400
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    url,
+				bodyJSON: &models.ScannerRegistration{
+					Name: "trivy",
+					URL:  "not-a-url",
+				},
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 404
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        url + "/not-exist",
+				credential: sysAdmin,
+			},
+			code: http.StatusNotFound,
+		},
+		// 400, ping an unreachable scanner adapter
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    url + "/ping",
+				bodyJSON: &models.ScannerRegistration{
+					Name: "trivy",
+					URL:  "http://127.0.0.1:1",
+				},
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+}
+
+func TestProjectScannerAPI(t *testing.T) {
+	cases := []*codeCheckingCase{
+		// 401
+		{
+			request: &testingRequest{
+				method: http.MethodGet,
+				url:    "/api/projects/1/scanner",
+			},
+			code: http.StatusUnauthorized,
+		},
+		// 404
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/projects/1000000/scanner",
+				credential: sysAdmin,
+			},
+			code: http.StatusNotFound,
+		},
+		// 400
+		{
+			request: &testingRequest{
+				method:     http.MethodPut,
+				url:        "/api/projects/1/scanner",
+				bodyJSON:   map[string]string{"uuid": "not-exist"},
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/projects/1/scanner/candidates",
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+}
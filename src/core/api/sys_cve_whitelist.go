@@ -21,6 +21,7 @@ import (
 	"github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/goharbor/harbor/src/pkg/scan/whitelist"
 	"net/http"
+	"time"
 )
 
 // SysCVEWhitelistAPI Handles the requests to manage system level CVE whitelist
@@ -69,6 +70,12 @@ func (sca *SysCVEWhitelistAPI) Put() {
 		sca.SendBadRequestError(errors.New(msg))
 		return
 	}
+	existing, err := sca.manager.GetSys()
+	if err != nil {
+		sca.SendInternalServerError(fmt.Errorf("failed to get the system CVE whitelist: %v", err))
+		return
+	}
+	whitelist.FillApprover(&l, existing, sca.SecurityCtx.GetUsername())
 	if err := sca.manager.SetSys(l); err != nil {
 		if whitelist.IsInvalidErr(err) {
 			log.Errorf("Invalid CVE whitelist: %v", err)
@@ -79,3 +86,43 @@ func (sca *SysCVEWhitelistAPI) Put() {
 		return
 	}
 }
+
+// Audit handles the GET request to report the expired items of the CVE whitelists of the system and all the projects,
+// the items expiring in the days specified by the query parameter "expiring_in_days" are reported as well
+func (sca *SysCVEWhitelistAPI) Audit() {
+	if !sca.SecurityCtx.IsSysAdmin() {
+		sca.SendForbiddenError(errors.New(sca.SecurityCtx.GetUsername()))
+		return
+	}
+	days, err := sca.GetInt("expiring_in_days", 0)
+	if err != nil || days < 0 {
+		sca.SendBadRequestError(fmt.Errorf("invalid expiring_in_days: %s", sca.GetString("expiring_in_days")))
+		return
+	}
+
+	items, err := sca.manager.Audit(time.Duration(days) * 24 * time.Hour)
+	if err != nil {
+		sca.SendInternalServerError(fmt.Errorf("failed to audit the CVE whitelists: %v", err))
+		return
+	}
+	names := map[int64]string{}
+	for _, it := range items {
+		if it.ProjectID == 0 {
+			continue
+		}
+		name, ok := names[it.ProjectID]
+		if !ok {
+			project, err := sca.ProjectMgr.Get(it.ProjectID)
+			if err != nil {
+				sca.ParseAndHandleError(fmt.Sprintf("failed to get project %d", it.ProjectID), err)
+				return
+			}
+			if project != nil {
+				name = project.Name
+			}
+			names[it.ProjectID] = name
+		}
+		it.ProjectName = name
+	}
+	sca.WriteJSONData(items)
+}
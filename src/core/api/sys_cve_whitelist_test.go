@@ -106,7 +106,7 @@ func TestSysCVEWhitelistAPIPut(t *testing.T) {
 			},
 			code: http.StatusBadRequest,
 		},
-		// 200
+		// 400, missing justification
 		{
 			request: &testingRequest{
 				method: http.MethodPut,
@@ -115,11 +115,68 @@ func TestSysCVEWhitelistAPIPut(t *testing.T) {
 					ExpiresAt: &s,
 					Items: []models.CVEWhitelistItem{
 						{CVEID: "CVE-2019-12310"},
-						{CVEID: "RHSA-2019:2237"},
 					},
 				},
 				credential: sysAdmin,
 			},
+			code: http.StatusBadRequest,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method: http.MethodPut,
+				url:    url,
+				bodyJSON: models.CVEWhitelist{
+					ExpiresAt: &s,
+					Items: []models.CVEWhitelistItem{
+						{CVEID: "CVE-2019-12310", Justification: "not exploitable"},
+						{CVEID: "RHSA-2019:2237", Justification: "fixed in the base image", ExpiresAt: &s},
+					},
+				},
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+}
+
+func TestSysCVEWhitelistAPIAudit(t *testing.T) {
+	url := "/api/system/CVEWhitelist/audit"
+	cases := []*codeCheckingCase{
+		// 401
+		{
+			request: &testingRequest{
+				method: http.MethodGet,
+				url:    url,
+			},
+			code: http.StatusUnauthorized,
+		},
+		// 403
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        url,
+				credential: nonSysAdmin,
+			},
+			code: http.StatusForbidden,
+		},
+		// 400
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        url + "?expiring_in_days=-1",
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        url + "?expiring_in_days=7",
+				credential: sysAdmin,
+			},
 			code: http.StatusOK,
 		},
 	}
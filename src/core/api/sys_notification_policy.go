@@ -0,0 +1,224 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/pkg/notification"
+)
+
+// SysNotificationPolicyAPI handles the requests to manage the system level notification policies,
+// which send the events of all the projects matching their project pattern
+type SysNotificationPolicyAPI struct {
+	BaseController
+}
+
+// Prepare validates the request initially, only the system admin can manage the system level policies
+func (s *SysNotificationPolicyAPI) Prepare() {
+	s.BaseController.Prepare()
+	if !s.SecurityCtx.IsAuthenticated() {
+		s.SendUnAuthorizedError(errors.New("UnAuthorized"))
+		return
+	}
+	if !s.SecurityCtx.IsSysAdmin() {
+		s.SendForbiddenError(errors.New(s.SecurityCtx.GetUsername()))
+		return
+	}
+}
+
+// Get the system level notification policy specified by ID
+func (s *SysNotificationPolicyAPI) Get() {
+	policy, ok := s.getPolicy()
+	if !ok {
+		return
+	}
+	hideTargetSecrets(policy)
+	s.WriteJSONData(policy)
+}
+
+// List the system level notification policies
+func (s *SysNotificationPolicyAPI) List() {
+	policies, err := notification.PolicyMgr.List(models.SystemNotificationPolicyProjectID)
+	if err != nil {
+		s.SendInternalServerError(fmt.Errorf("failed to list system level notification policies: %v", err))
+		return
+	}
+	hideTargetSecrets(policies...)
+	s.WriteJSONData(policies)
+}
+
+// Post creates a system level notification policy
+func (s *SysNotificationPolicyAPI) Post() {
+	policy, ok := s.decodePolicy()
+	if !ok {
+		return
+	}
+	if policy.ID != 0 {
+		s.SendBadRequestError(fmt.Errorf("cannot accept policy creating request with ID: %d", policy.ID))
+		return
+	}
+
+	policy.Creator = s.SecurityCtx.GetUsername()
+	policy.ProjectID = models.SystemNotificationPolicyProjectID
+
+	id, err := notification.PolicyMgr.Create(policy)
+	if err != nil {
+		s.SendInternalServerError(fmt.Errorf("failed to create the system level notification policy: %v", err))
+		return
+	}
+	s.Redirect(http.StatusCreated, strconv.FormatInt(id, 10))
+}
+
+// Put updates the system level notification policy specified by ID
+func (s *SysNotificationPolicyAPI) Put() {
+	oriPolicy, ok := s.getPolicy()
+	if !ok {
+		return
+	}
+	policy, ok := s.decodePolicy()
+	if !ok {
+		return
+	}
+
+	policy.ID = oriPolicy.ID
+	policy.ProjectID = models.SystemNotificationPolicyProjectID
+	policy.Creator = oriPolicy.Creator
+	policy.CreationTime = oriPolicy.CreationTime
+	keepTargetSecrets(policy, oriPolicy)
+
+	if err := notification.PolicyMgr.Update(policy); err != nil {
+		s.SendInternalServerError(fmt.Errorf("failed to update the system level notification policy: %v", err))
+		return
+	}
+}
+
+// Delete the system level notification policy specified by ID
+func (s *SysNotificationPolicyAPI) Delete() {
+	policy, ok := s.getPolicy()
+	if !ok {
+		return
+	}
+	if err := notification.PolicyMgr.Delete(policy.ID); err != nil {
+		s.SendInternalServerError(fmt.Errorf("failed to delete notification policy %d: %v", policy.ID, err))
+		return
+	}
+}
+
+// Test the targets of the system level notification policy in the request body
+func (s *SysNotificationPolicyAPI) Test() {
+	policy := &models.NotificationPolicy{}
+	isValid, err := s.DecodeJSONReqAndValidate(policy)
+	if !isValid {
+		s.SendBadRequestError(err)
+		return
+	}
+	if err := validateNotificationTargets(policy); err != nil {
+		s.SendBadRequestError(err)
+		return
+	}
+
+	if err := notification.PolicyMgr.Test(policy); err != nil {
+		log.Errorf("notification policy %s test failed: %v", policy.Name, err)
+		s.SendBadRequestError(fmt.Errorf("notification policy %s test failed", policy.Name))
+		return
+	}
+}
+
+// ListJobs lists the notification jobs of the system level notification policy specified by the "policy_id" query
+func (s *SysNotificationPolicyAPI) ListJobs() {
+	policyID, err := s.GetInt64("policy_id")
+	if err != nil || policyID <= 0 {
+		s.SendBadRequestError(fmt.Errorf("invalid policy_id: %s", s.GetString("policy_id")))
+		return
+	}
+	policy, err := notification.PolicyMgr.Get(policyID)
+	if err != nil {
+		s.SendInternalServerError(fmt.Errorf("failed to get policy %d: %v", policyID, err))
+		return
+	}
+	if policy == nil || !policy.SystemLevel() {
+		s.SendBadRequestError(fmt.Errorf("system level policy %d not found", policyID))
+		return
+	}
+
+	query := &models.NotificationJobQuery{
+		PolicyID: policyID,
+		Statuses: s.GetStrings("status"),
+	}
+	query.Page, query.Size, err = s.GetPaginationParams()
+	if err != nil {
+		s.SendBadRequestError(err)
+		return
+	}
+
+	total, jobs, err := notification.JobMgr.List(query)
+	if err != nil {
+		s.SendInternalServerError(fmt.Errorf("failed to list notification jobs: %v", err))
+		return
+	}
+	s.SetPaginationHeader(total, query.Page, query.Size)
+	s.WriteJSONData(jobs)
+}
+
+// getPolicy gets the system level policy specified by the ID in URL, the error is sent if it fails
+func (s *SysNotificationPolicyAPI) getPolicy() (*models.NotificationPolicy, bool) {
+	id, err := s.GetIDFromURL()
+	if err != nil {
+		s.SendBadRequestError(err)
+		return nil, false
+	}
+
+	policy, err := notification.PolicyMgr.Get(id)
+	if err != nil {
+		s.SendInternalServerError(fmt.Errorf("failed to get the notification policy %d: %v", id, err))
+		return nil, false
+	}
+	// the project level policies can't be managed by this API
+	if policy == nil || !policy.SystemLevel() {
+		s.SendNotFoundError(fmt.Errorf("system level notification policy %d not found", id))
+		return nil, false
+	}
+	return policy, true
+}
+
+// decodePolicy decodes and validates the policy in the request body, the error is sent if it fails
+func (s *SysNotificationPolicyAPI) decodePolicy() (*models.NotificationPolicy, bool) {
+	policy := &models.NotificationPolicy{}
+	isValid, err := s.DecodeJSONReqAndValidate(policy)
+	if !isValid {
+		s.SendBadRequestError(err)
+		return nil, false
+	}
+	if err := validateNotificationTargets(policy); err != nil {
+		s.SendBadRequestError(err)
+		return nil, false
+	}
+	if err := validateNotificationEventTypes(policy); err != nil {
+		s.SendBadRequestError(err)
+		return nil, false
+	}
+	if _, err := path.Match(policy.ProjectPattern, ""); err != nil {
+		s.SendBadRequestError(fmt.Errorf("invalid project pattern %s: %v", policy.ProjectPattern, err))
+		return nil, false
+	}
+	return policy, true
+}
@@ -0,0 +1,205 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/pkg/notification"
+)
+
+func TestSysNotificationPolicyAPI(t *testing.T) {
+	policyCtl := notification.PolicyMgr
+	jobMgr := notification.JobMgr
+	defer func() {
+		notification.PolicyMgr = policyCtl
+		notification.JobMgr = jobMgr
+	}()
+
+	notification.PolicyMgr = &fakedNotificationPlyMgr{}
+	notification.JobMgr = &fakedNotificationJobMgr{}
+
+	policy := &models.NotificationPolicy{
+		EventTypes:     []string{"pushImage", "scanningCompleted"},
+		ProjectPattern: "team-*",
+		Targets: []models.EventTarget{
+			{
+				Type:    "http",
+				Address: "http://10.173.32.58:9009",
+			},
+		},
+	}
+
+	cases := []*codeCheckingCase{
+		// 401
+		{
+			request: &testingRequest{
+				method: http.MethodGet,
+				url:    "/api/system/webhook/policies",
+			},
+			code: http.StatusUnauthorized,
+		},
+		// 403
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/system/webhook/policies",
+				credential: projAdmin,
+			},
+			code: http.StatusForbidden,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/system/webhook/policies",
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/system/webhook/policies/4",
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+		// 404 project level policy
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/system/webhook/policies/1",
+				credential: sysAdmin,
+			},
+			code: http.StatusNotFound,
+		},
+		// 500
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/system/webhook/policies/3",
+				credential: sysAdmin,
+			},
+			code: http.StatusInternalServerError,
+		},
+		// 400 invalid project pattern
+		{
+			request: &testingRequest{
+				method:     http.MethodPost,
+				url:        "/api/system/webhook/policies",
+				credential: sysAdmin,
+				bodyJSON: &models.NotificationPolicy{
+					EventTypes:     policy.EventTypes,
+					ProjectPattern: "team-[",
+					Targets:        policy.Targets,
+				},
+			},
+			code: http.StatusBadRequest,
+		},
+		// 400 empty event types
+		{
+			request: &testingRequest{
+				method:     http.MethodPost,
+				url:        "/api/system/webhook/policies",
+				credential: sysAdmin,
+				bodyJSON: &models.NotificationPolicy{
+					Targets: policy.Targets,
+				},
+			},
+			code: http.StatusBadRequest,
+		},
+		// 201
+		{
+			request: &testingRequest{
+				method:     http.MethodPost,
+				url:        "/api/system/webhook/policies",
+				credential: sysAdmin,
+				bodyJSON:   policy,
+			},
+			code: http.StatusCreated,
+		},
+		// 404 project level policy
+		{
+			request: &testingRequest{
+				method:     http.MethodPut,
+				url:        "/api/system/webhook/policies/1",
+				credential: sysAdmin,
+				bodyJSON:   policy,
+			},
+			code: http.StatusNotFound,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodPut,
+				url:        "/api/system/webhook/policies/4",
+				credential: sysAdmin,
+				bodyJSON:   policy,
+			},
+			code: http.StatusOK,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodPost,
+				url:        "/api/system/webhook/policies/test",
+				credential: sysAdmin,
+				bodyJSON:   policy,
+			},
+			code: http.StatusOK,
+		},
+		// 400 invalid policy ID
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/system/webhook/jobs?policy_id=1",
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/system/webhook/jobs?policy_id=4",
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+		// 404 project level policy
+		{
+			request: &testingRequest{
+				method:     http.MethodDelete,
+				url:        "/api/system/webhook/policies/2",
+				credential: sysAdmin,
+			},
+			code: http.StatusNotFound,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodDelete,
+				url:        "/api/system/webhook/policies/4",
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+}
@@ -0,0 +1,137 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/pkg/scan"
+	"github.com/goharbor/harbor/src/pkg/scan/export"
+)
+
+// VulnerabilityAPI handles the requests to search the vulnerabilities across the images,
+// only the images in the projects whose vulnerabilities the user can read are counted
+type VulnerabilityAPI struct {
+	BaseController
+	projectIDs []int64
+}
+
+// Prepare collects the projects the user can read the vulnerabilities of
+func (v *VulnerabilityAPI) Prepare() {
+	v.BaseController.Prepare()
+	// nil means all the projects
+	projectIDs, ok := v.ProjectIDsWithAccess(rbac.ActionList, rbac.ResourceRepositoryTagVulnerability)
+	if !ok {
+		return
+	}
+	v.projectIDs = projectIDs
+}
+
+// ListAffectedImages handles the GET request to list the tags affected by the vulnerability with the CVE ID,
+// it's according to the last scan of the tags
+func (v *VulnerabilityAPI) ListAffectedImages() {
+	cveID := v.GetStringFromPath(":id")
+	if len(cveID) == 0 {
+		v.SendBadRequestError(errors.New("missing CVE ID"))
+		return
+	}
+	query := &models.CVEQuery{
+		CVEID:      cveID,
+		ProjectIDs: v.projectIDs,
+	}
+	var err error
+	query.Page, query.Size, err = v.GetPaginationParams()
+	if err != nil {
+		v.SendBadRequestError(err)
+		return
+	}
+
+	total, err := dao.GetTotalOfAffectedImages(query)
+	if err != nil {
+		v.SendInternalServerError(fmt.Errorf("failed to get the total of images affected by %s: %v", cveID, err))
+		return
+	}
+	images, err := dao.ListAffectedImages(query)
+	if err != nil {
+		v.SendInternalServerError(fmt.Errorf("failed to list the images affected by %s: %v", cveID, err))
+		return
+	}
+
+	v.SetPaginationHeader(total, query.Page, query.Size)
+	v.WriteJSONData(images)
+}
+
+// ListTopCVEs handles the GET request to list the vulnerabilities affecting the most images
+func (v *VulnerabilityAPI) ListTopCVEs() {
+	count, err := v.GetInt64("count", 10)
+	if err != nil || count <= 0 {
+		v.SendBadRequestError(fmt.Errorf("invalid count: %s", v.GetString("count")))
+		return
+	}
+	severity, err := v.GetInt("severity", 0)
+	if err != nil || severity < 0 {
+		v.SendBadRequestError(fmt.Errorf("invalid severity: %s", v.GetString("severity")))
+		return
+	}
+
+	impacts, err := dao.ListTopCVEs(&models.CVEQuery{
+		ProjectIDs: v.projectIDs,
+		Severity:   severity,
+		Pagination: models.Pagination{
+			Page: 1,
+			Size: count,
+		},
+	})
+	if err != nil {
+		v.SendInternalServerError(fmt.Errorf("failed to list the top vulnerabilities: %v", err))
+		return
+	}
+	v.WriteJSONData(impacts)
+}
+
+// scannedVulnList returns the vulnerabilities of the image with the digest,
+// the returned bool is false if the image isn't scanned
+func scannedVulnList(digest string) (scan.VulnerabilityList, bool, error) {
+	overview, err := dao.GetImgScanOverview(digest)
+	if err != nil {
+		return nil, false, err
+	}
+	if overview == nil || (len(overview.Report) == 0 && len(overview.DetailsKey) == 0) {
+		return nil, false, nil
+	}
+	vl, err := scan.VulnListByDigest(digest)
+	if err != nil {
+		return nil, false, err
+	}
+	return vl, true, nil
+}
+
+// writeVulnerabilityExport writes the vulnerabilities of the images in the format specified by the query parameter "format"
+func writeVulnerabilityExport(c *BaseController, images []*export.Image) {
+	mimeType, data, err := export.Export(c.GetString("format"), images)
+	if err != nil {
+		c.SendBadRequestError(err)
+		return
+	}
+	w := c.Ctx.ResponseWriter
+	w.Header().Set("Content-Type", mimeType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
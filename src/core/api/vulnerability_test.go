@@ -0,0 +1,118 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTopCVEs(t *testing.T) {
+	cases := []*codeCheckingCase{
+		// 400
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/vulnerabilities/top?count=-1",
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 200, anonymous user can read the public projects
+		{
+			request: &testingRequest{
+				method: http.MethodGet,
+				url:    "/api/vulnerabilities/top",
+			},
+			code: http.StatusOK,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/vulnerabilities/top?count=5&severity=5",
+				credential: nonSysAdmin,
+			},
+			code: http.StatusOK,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+}
+
+func TestListAffectedImages(t *testing.T) {
+	impacts := []*models.AffectedImage{}
+	err := handleAndParse(&testingRequest{
+		method:     http.MethodGet,
+		url:        "/api/vulnerabilities/CVE-2019-0001/images",
+		credential: sysAdmin,
+	}, &impacts)
+	require.Nil(t, err)
+	assert.Len(t, impacts, 0)
+}
+
+func TestExportVulnerabilities(t *testing.T) {
+	cases := []*codeCheckingCase{
+		// 404, the project doesn't exist
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/projects/1000/vulnerability/export",
+				credential: sysAdmin,
+			},
+			code: http.StatusNotFound,
+		},
+		// 400, unsupported format
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/projects/1/vulnerability/export?format=html",
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/projects/1/vulnerability/export?format=csv",
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/projects/1/vulnerability/export?format=sarif",
+				credential: nonSysAdmin,
+			},
+			code: http.StatusOK,
+		},
+		// 404, the tag doesn't exist
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/repositories/library/non-exist/tags/latest/vulnerability/export",
+				credential: sysAdmin,
+			},
+			code: http.StatusNotFound,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+}
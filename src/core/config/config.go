@@ -530,6 +530,17 @@ func QuotaPerProjectEnable() bool {
 	return cfgMgr.Get(common.QuotaPerProjectEnable).GetBool()
 }
 
+// AutoRescanEnabled returns a bool to indicates if the images are rescanned when the vulnerability database is updated
+func AutoRescanEnabled() bool {
+	return cfgMgr.Get(common.AutoRescanEnabled).GetBool()
+}
+
+// AutoRescanPulledWithinDays returns the days within which the images must be pulled to be rescanned automatically,
+// 0 means all the images are rescanned
+func AutoRescanPulledWithinDays() int {
+	return cfgMgr.Get(common.AutoRescanPulledWithinDays).GetInt()
+}
+
 // QuotaSetting returns the setting of quota.
 func QuotaSetting() (*models.QuotaSetting, error) {
 	if err := cfgMgr.Load(); err != nil {
@@ -212,6 +212,8 @@ func TestConfig(t *testing.T) {
 	assert.Equal("http://127.0.0.1:8080", localCoreURL)
 
 	assert.True(NotificationEnable())
+	assert.False(AutoRescanEnabled())
+	assert.Equal(0, AutoRescanPulledWithinDays())
 }
 
 func currPath() string {
@@ -47,6 +47,8 @@ import (
 	_ "github.com/goharbor/harbor/src/core/notifier/topic"
 	"github.com/goharbor/harbor/src/core/service/token"
 	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/scan"
+	"github.com/goharbor/harbor/src/pkg/scan/rescan"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 	"github.com/goharbor/harbor/src/pkg/types"
 	"github.com/goharbor/harbor/src/replication"
@@ -226,6 +228,16 @@ func main() {
 	log.Info("initializing notification...")
 	notification.Init()
 
+	// rescan the images when the vulnerability databases of the scanners are updated
+	rescan.Start(closing)
+
+	// index the images scanned before the vulnerability index is introduced
+	go func() {
+		if err := scan.IndexScannedImages(); err != nil {
+			log.Errorf("Failed to index the vulnerabilities of the scanned images: %v", err)
+		}
+	}()
+
 	filter.Init()
 	beego.InsertFilter("/api/*", beego.BeforeStatic, filter.SessionCheck)
 	beego.InsertFilter("/*", beego.BeforeRouter, filter.SecurityFilter)
@@ -42,7 +42,7 @@ func deleteChartVersion(projectName, chartName, version string) {
 	h.ServeHTTP(util.NewCustomResponseWriter(rr), req)
 }
 
-func uploadChartVersion(projectID int64, projectName, chartName, version string) {
+func uploadChartVersion(projectID int64, projectName, chartName, version string, size ...int64) {
 	url := fmt.Sprintf("/api/chartrepo/%s/charts/", projectName)
 	req, _ := http.NewRequest(http.MethodPost, url, nil)
 
@@ -52,6 +52,9 @@ func uploadChartVersion(projectID int64, projectName, chartName, version string)
 		ChartName: chartName,
 		Version:   version,
 	}
+	if len(size) > 0 {
+		info.Size = size[0]
+	}
 	*req = *req.WithContext(util.NewChartVersionInfoContext(req.Context(), info))
 
 	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -171,6 +174,25 @@ func (suite *HandlerSuite) TestDelete() {
 	}, "123456")
 }
 
+func (suite *HandlerSuite) TestStorage() {
+	suite.WithProject(func(projectID int64, projectName string) {
+		size := int64(len(htesting.HelmChartContent))
+
+		uploadChartVersion(projectID, projectName, "harbor", "0.2.1", size)
+		suite.AssertResourceUsage(1, types.ResourceCount, projectID)
+		suite.AssertResourceUsage(size, types.ResourceStorage, projectID)
+
+		// harbor:0.2.0 exists in repo1, upload it again
+		uploadChartVersion(projectID, projectName, "harbor", "0.2.0", size)
+		suite.AssertResourceUsage(size, types.ResourceStorage, projectID)
+
+		// the size of harbor:0.2.0 in repo1 is the size of HelmChartContent
+		deleteChartVersion(projectName, "harbor", "0.2.0")
+		suite.AssertResourceUsage(0, types.ResourceCount, projectID)
+		suite.AssertResourceUsage(0, types.ResourceStorage, projectID)
+	}, "repo1")
+}
+
 func TestRunHandlerSuite(t *testing.T) {
 	suite.Run(t, new(HandlerSuite))
 }
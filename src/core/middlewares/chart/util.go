@@ -85,8 +85,8 @@ func chartVersionExists(namespace, chartName, version string) bool {
 	return !chartVersion.Removed
 }
 
-// computeResourcesForChartVersionCreation returns count resource required for the chart package
-// no count required if the chart package of version exists in project
+// computeResourcesForChartVersionCreation returns count and storage resources required for the chart package
+// no resources required if the chart package of version exists in project
 func computeResourcesForChartVersionCreation(req *http.Request) (types.ResourceList, error) {
 	info, ok := util.ChartVersionInfoFromContext(req.Context())
 	if !ok {
@@ -98,19 +98,48 @@ func computeResourcesForChartVersionCreation(req *http.Request) (types.ResourceL
 		return nil, nil
 	}
 
-	return types.ResourceList{types.ResourceCount: 1}, nil
+	return types.ResourceList{types.ResourceCount: 1, types.ResourceStorage: info.Size}, nil
 }
 
-func parseChart(req *http.Request) (*chart.Chart, error) {
-	chartFile, _, err := req.FormFile(formFieldNameForChart)
+// computeResourcesForChartVersionDeletion returns count and storage resources released by the chart package
+func computeResourcesForChartVersionDeletion(req *http.Request) (types.ResourceList, error) {
+	info, ok := util.ChartVersionInfoFromContext(req.Context())
+	if !ok {
+		return nil, errors.New("chart version info missing")
+	}
+
+	return types.ResourceList{types.ResourceCount: 1, types.ResourceStorage: chartVersionSize(info.Namespace, info.ChartName, info.Version)}, nil
+}
+
+// chartVersionSize returns the size of the chart package, 0 returned when failed to get it,
+// the storage usage will be corrected by the next quota sync in this case
+func chartVersionSize(namespace, chartName, version string) int64 {
+	ctr, err := chartController()
+	if err != nil {
+		log.Warningf("Get size of chart %s of version %s in namespace %s failed, error: %v", chartName, version, namespace, err)
+		return 0
+	}
+
+	size, err := ctr.GetChartVersionSize(namespace, chartName, version)
+	if err != nil {
+		log.Warningf("Get size of chart %s of version %s in namespace %s failed, error: %v", chartName, version, namespace, err)
+		return 0
+	}
+
+	return size
+}
+
+// parseChart returns the chart and the size of the chart package from the upload request
+func parseChart(req *http.Request) (*chart.Chart, int64, error) {
+	chartFile, header, err := req.FormFile(formFieldNameForChart)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	chart, err := chartutil.LoadArchive(chartFile)
 	if err != nil {
-		return nil, fmt.Errorf("load chart from archive failed: %s", err.Error())
+		return nil, 0, fmt.Errorf("load chart from archive failed: %s", err.Error())
 	}
 
-	return chart, nil
+	return chart, header.Size, nil
 }
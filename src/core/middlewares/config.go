@@ -23,6 +23,7 @@ const (
 	LISTREPO         = "listrepo"
 	CONTENTTRUST     = "contenttrust"
 	VULNERABLE       = "vulnerable"
+	QUARANTINE       = "quarantine"
 	SIZEQUOTA        = "sizequota"
 	COUNTQUOTA       = "countquota"
 )
@@ -31,7 +32,7 @@ const (
 var ChartMiddlewares = []string{CHART}
 
 // Middlewares with sequential organization
-var Middlewares = []string{READONLY, URL, MUITIPLEMANIFEST, LISTREPO, CONTENTTRUST, VULNERABLE, SIZEQUOTA, COUNTQUOTA}
+var Middlewares = []string{READONLY, URL, MUITIPLEMANIFEST, LISTREPO, CONTENTTRUST, QUARANTINE, VULNERABLE, SIZEQUOTA, COUNTQUOTA}
 
 // MiddlewaresLocal ...
 var MiddlewaresLocal = []string{SIZEQUOTA, COUNTQUOTA}
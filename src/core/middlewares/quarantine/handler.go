@@ -0,0 +1,116 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quarantine
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/core/middlewares/util"
+	"github.com/goharbor/harbor/src/pkg/scan/quarantine"
+	"github.com/goharbor/harbor/src/pkg/scan/scanner"
+)
+
+type quarantineHandler struct {
+	next http.Handler
+}
+
+// New ...
+func New(next http.Handler) http.Handler {
+	return &quarantineHandler{
+		next: next,
+	}
+}
+
+// ServeHTTP quarantines the images pushed to the projects with quarantine on push enabled,
+// and prevents the quarantined or rejected images from being pulled
+func (qh quarantineHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if match, repository, _ := util.MatchPushManifest(req); match {
+		qh.handlePush(rw, req, repository)
+		return
+	}
+
+	imgRaw := req.Context().Value(util.ImageInfoCtxKey)
+	if imgRaw == nil {
+		qh.next.ServeHTTP(rw, req)
+		return
+	}
+	img, _ := imgRaw.(util.ImageInfo)
+	if img.Digest == "" || !util.GetPolicyChecker().QuarantineOnPush(img.ProjectName) {
+		qh.next.ServeHTTP(rw, req)
+		return
+	}
+	q, err := quarantine.Blocked(img.Repository, img.Digest)
+	if err != nil {
+		log.Errorf("Failed to get the quarantine state of image %s(%s), error: %v", img.Repository, img.Digest, err)
+		http.Error(rw, util.MarshalError("PROJECT_POLICY_VIOLATION", "Failed to get the quarantine state of the image."), http.StatusPreconditionFailed)
+		return
+	}
+	if q != nil {
+		http.Error(rw, util.MarshalError("PROJECT_POLICY_VIOLATION", blockedMsg(q)), http.StatusPreconditionFailed)
+		return
+	}
+	qh.next.ServeHTTP(rw, req)
+}
+
+// handlePush quarantines the image before the manifest is pushed, so that it can't be pulled
+// until the scan triggered by the push is finished. The quarantine record added by the push is
+// removed if the registry doesn't accept the manifest, as no scan will be triggered then.
+func (qh quarantineHandler) handlePush(rw http.ResponseWriter, req *http.Request, repository string) {
+	projectName, _ := utils.ParseRepository(repository)
+	if !scanner.Enabled() || !util.GetPolicyChecker().QuarantineOnPush(projectName) {
+		qh.next.ServeHTTP(rw, req)
+		return
+	}
+	info, ok := util.ManifestInfoFromContext(req.Context())
+	if !ok {
+		var err error
+		info, err = util.ParseManifestInfoFromReq(req)
+		if err != nil {
+			log.Errorf("Failed to parse the manifest of repository %s, error: %v", repository, err)
+			http.Error(rw, util.MarshalError("MANIFEST_INVALID", fmt.Sprintf("Failed to parse the manifest: %v", err)), http.StatusBadRequest)
+			return
+		}
+		req = req.WithContext(util.NewManifestInfoContext(req.Context(), info))
+	}
+	created, err := quarantine.Quarantine(repository, info.Tag, info.Digest)
+	if err != nil {
+		log.Error(err)
+		http.Error(rw, util.MarshalError("PROJECT_POLICY_VIOLATION", "Failed to quarantine the image."), http.StatusInternalServerError)
+		return
+	}
+	if !created {
+		qh.next.ServeHTTP(rw, req)
+		return
+	}
+
+	crw := util.NewCustomResponseWriter(rw)
+	qh.next.ServeHTTP(crw, req)
+	if crw.Status() != http.StatusCreated {
+		if err := quarantine.Release(repository, info.Digest); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+func blockedMsg(q *models.ImgQuarantine) string {
+	if q.Status == models.QuarantineStatusRejected {
+		return fmt.Sprintf("The image is rejected by the project policy: %s", q.Reason)
+	}
+	return "The image is quarantined until its scan is finished."
+}
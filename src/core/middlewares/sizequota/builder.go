@@ -30,6 +30,7 @@ import (
 var (
 	defaultBuilders = []interceptor.Builder{
 		&blobStreamUploadBuilder{},
+		&blobUploadCancellationBuilder{},
 		&blobStorageQuotaBuilder{},
 		&manifestCreationBuilder{},
 		&manifestDeletionBuilder{},
@@ -44,29 +45,81 @@ func (*blobStreamUploadBuilder) Build(req *http.Request) (interceptor.Intercepto
 		return nil, nil
 	}
 
+	if !config.QuotaPerProjectEnable() {
+		return nil, nil
+	}
+
 	s := blobUploadURLRe.FindStringSubmatch(req.URL.Path)
-	uuid := s[2]
+	repository, uuid := s[1][:len(s[1])-1], s[2]
 
-	onResponse := func(w http.ResponseWriter, req *http.Request) {
-		if !config.QuotaPerProjectEnable() {
-			return
-		}
+	reservation, err := newUploadReservation(repository, uuid)
+	if err != nil {
+		return nil, err
+	}
 
-		size, err := parseUploadedBlobSize(w)
-		if err != nil {
-			log.Errorf("failed to parse uploaded blob size for upload %s, error: %v", uuid, err)
-			return
-		}
+	if reservation == nil {
+		// pass through, the upload to the unknown project is rejected by the registry
+		return nil, nil
+	}
+
+	return &blobStreamUploadInterceptor{reservation: reservation}, nil
+}
+
+// blobStreamUploadInterceptor reserves the storage for the chunk before it's uploaded,
+// and corrects the reservation by the progress of the upload after the chunk uploaded
+type blobStreamUploadInterceptor struct {
+	reservation *uploadReservation
+}
+
+func (i *blobStreamUploadInterceptor) HandleRequest(req *http.Request) error {
+	var size int64
+	if req.ContentLength > 0 {
+		size = req.ContentLength
+	}
+
+	return i.reservation.Reserve(size)
+}
 
+func (i *blobStreamUploadInterceptor) HandleResponse(w http.ResponseWriter, req *http.Request) {
+	uuid := i.reservation.UUID
+
+	size, err := parseUploadedBlobSize(w)
+	if err != nil {
+		log.Errorf("failed to parse uploaded blob size for upload %s, error: %v", uuid, err)
+
+		// chunk not accepted, fallback the reservation to the last progress of the upload
+		size, _ = getUploadedBlobSize(uuid)
+	} else {
 		ok, err := setUploadedBlobSize(uuid, size)
 		if err != nil {
 			log.Errorf("failed to update blob update size for upload %s, error: %v", uuid, err)
+		} else if !ok {
+			// ToDo discuss what to do here.
+			log.Errorf("fail to set bunk: %s size: %d in redis, it causes unable to set correct quota for the artifact", uuid, size)
+		}
+	}
+
+	if err := i.reservation.Update(size); err != nil {
+		log.Errorf("failed to update the reservation for upload %s, error: %v", uuid, err)
+	}
+}
+
+// blobUploadCancellationBuilder interceptor builder for DELETE /v2/<name>/blobs/uploads/<uuid>
+type blobUploadCancellationBuilder struct{}
+
+func (*blobUploadCancellationBuilder) Build(req *http.Request) (interceptor.Interceptor, error) {
+	if !match(req, http.MethodDelete, blobUploadURLRe) {
+		return nil, nil
+	}
+
+	onResponse := func(w http.ResponseWriter, req *http.Request) {
+		sr, ok := w.(interface{ Status() int })
+		if !ok || sr.Status() != http.StatusNoContent {
 			return
 		}
 
-		if !ok {
-			// ToDo discuss what to do here.
-			log.Errorf("fail to set bunk: %s size: %d in redis, it causes unable to set correct quota for the artifact", uuid, size)
+		if err := releaseUploadReservation(req); err != nil {
+			log.Errorf("failed to release the reservation of the canceled upload, error: %v", err)
 		}
 	}
 
@@ -99,8 +152,13 @@ func (*blobStorageQuotaBuilder) Build(req *http.Request) (interceptor.Intercepto
 		quota.StatusCode(http.StatusCreated), // NOTICE: mount blob and blob upload complete both return 201 when success
 		quota.OnResources(computeResourcesForBlob),
 		quota.MutexKeys(info.MutexKey()),
-		quota.OnFulfilled(func(http.ResponseWriter, *http.Request) error {
-			return syncBlobInfoToProject(info)
+		quota.OnFulfilled(func(w http.ResponseWriter, req *http.Request) error {
+			if err := syncBlobInfoToProject(info); err != nil {
+				return err
+			}
+
+			// the blob is charged to the project now, release the storage reserved by its upload session
+			return releaseUploadReservation(req)
 		}),
 	}
 
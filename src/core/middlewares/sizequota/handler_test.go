@@ -33,6 +33,7 @@ import (
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/dao"
 	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/quota"
 	"github.com/goharbor/harbor/src/core/config"
 	"github.com/goharbor/harbor/src/core/middlewares/countquota"
 	"github.com/goharbor/harbor/src/core/middlewares/util"
@@ -339,6 +340,54 @@ func (suite *HandlerSuite) TestPatchBlobUpload() {
 	})
 }
 
+func (suite *HandlerSuite) TestPatchBlobUploadToUnknownProject() {
+	url := fmt.Sprintf("/v2/%s/photon/blobs/uploads/%s", "unknown_project", genUUID())
+	req, _ := http.NewRequest(http.MethodPatch, url, bytes.NewReader(make([]byte, 1024)))
+
+	code := doHandle(req, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func (suite *HandlerSuite) TestPatchBlobUploadExceedQuota() {
+	withProject(func(projectID int64, projectName string) {
+		mgr, err := quota.NewManager("project", strconv.FormatInt(projectID, 10))
+		suite.Nil(err)
+		suite.Nil(mgr.EnsureQuota(types.ResourceList{types.ResourceCount: 0, types.ResourceStorage: 0}))
+		suite.Nil(mgr.UpdateQuota(types.ResourceList{types.ResourceCount: -1, types.ResourceStorage: 1500}))
+
+		patch := func(uuid string, chunkSize int64) int {
+			url := fmt.Sprintf("/v2/%s/photon/blobs/uploads/%s", projectName, uuid)
+			req, _ := http.NewRequest(http.MethodPatch, url, bytes.NewReader(make([]byte, chunkSize)))
+
+			return doHandle(req, func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Add("Range", fmt.Sprintf("0-%d", chunkSize-1))
+				w.WriteHeader(http.StatusAccepted)
+			})
+		}
+
+		uuid1, uuid2 := genUUID(), genUUID()
+		suite.Equal(http.StatusAccepted, patch(uuid1, 1024))
+		suite.Equal(http.StatusForbidden, patch(uuid2, 1024))
+
+		// storage reserved by the first upload released after canceled
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/v2/%s/photon/blobs/uploads/%s", projectName, uuid1), nil)
+		suite.Equal(http.StatusNoContent, doHandle(req, func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		suite.Equal(http.StatusAccepted, patch(uuid2, 1024))
+
+		// storage reserved by the second upload released after completed
+		putBlobUpload(projectName, "photon", uuid2, digest.FromString(randomString(15)).String())
+		suite.checkStorageUsage(1024, projectID)
+
+		suite.Equal(http.StatusForbidden, patch(genUUID(), 1024))
+		suite.Equal(http.StatusAccepted, patch(genUUID(), 400))
+	})
+}
+
 func (suite *HandlerSuite) TestPutBlobUpload() {
 	withProject(func(projectID int64, projectName string) {
 		uuid := genUUID()
@@ -0,0 +1,221 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sizequota
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/quota"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	redislock "github.com/goharbor/harbor/src/common/utils/redis"
+	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/core/middlewares/util"
+	"github.com/goharbor/harbor/src/pkg/types"
+)
+
+var (
+	// defaultReservationExpire the seconds the reservation of an upload session lives without any progress
+	defaultReservationExpire = int64(24 * time.Hour / time.Second)
+)
+
+// reservationExpire returns the expiration of the reservation, it can be overwritten by QUOTA_RESERVATION_EXPIRE
+func reservationExpire() int64 {
+	expire, err := strconv.ParseInt(os.Getenv("QUOTA_RESERVATION_EXPIRE"), 10, 64)
+	if err != nil || expire <= 0 {
+		expire = defaultReservationExpire
+	}
+
+	return expire
+}
+
+func reservationSessionKey(uuid string) string {
+	return fmt.Sprintf("upload:%s:reservation", uuid)
+}
+
+// uploadReservation the storage reserved in the project for an in-flight blob upload session
+type uploadReservation struct {
+	ProjectID   int64
+	ProjectName string
+	UUID        string
+}
+
+// MutexKey returns mutex key of the reservations of the project
+func (r *uploadReservation) MutexKey(suffix ...string) string {
+	a := []string{"quota", r.ProjectName, "reservations"}
+
+	return strings.Join(append(a, suffix...), ":")
+}
+
+// key returns the key of the hash which keeps the reserved size of all upload sessions of the project
+func (r *uploadReservation) key() string {
+	return fmt.Sprintf("quota:%s:reservations", r.ProjectName)
+}
+
+// sessionKey returns the key of the upload session, the reservation expired when this key expired
+func (r *uploadReservation) sessionKey() string {
+	return reservationSessionKey(r.UUID)
+}
+
+// reserved returns the size reserved by this upload session and the total size reserved by all upload sessions of the project,
+// reservations of the expired upload sessions will be cleaned
+func (r *uploadReservation) reserved(conn redis.Conn) (int64, int64, error) {
+	values, err := redis.Int64Map(conn.Do("HGETALL", r.key()))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var current, total int64
+	for uuid, size := range values {
+		exist, err := redis.Bool(conn.Do("EXISTS", reservationSessionKey(uuid)))
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if !exist {
+			log.Debugf("reservation of upload %s in project %s expired, release it", uuid, r.ProjectName)
+			if _, err := conn.Do("HDEL", r.key(), uuid); err != nil {
+				return 0, 0, err
+			}
+			continue
+		}
+
+		if uuid == r.UUID {
+			current = size
+		}
+		total += size
+	}
+
+	return current, total, nil
+}
+
+// set updates the size reserved by this upload session and refreshes its expiration
+func (r *uploadReservation) set(conn redis.Conn, size int64) error {
+	if _, err := conn.Do("SET", r.sessionKey(), r.ProjectID, "EX", reservationExpire()); err != nil {
+		return err
+	}
+
+	_, err := conn.Do("HSET", r.key(), r.UUID, size)
+	return err
+}
+
+// Reserve adds size to the reservation of this upload session,
+// quota.Errors returned when the usage of the project together with all the reservations exceeds the hard limits
+func (r *uploadReservation) Reserve(size int64) error {
+	return r.withLock(func(conn redis.Conn) error {
+		current, total, err := r.reserved(conn)
+		if err != nil {
+			return err
+		}
+
+		if size > 0 {
+			mgr, err := quota.NewManager("project", strconv.FormatInt(r.ProjectID, 10))
+			if err != nil {
+				return err
+			}
+
+			if err := mgr.CheckResources(types.ResourceList{types.ResourceStorage: total + size}); err != nil {
+				return err
+			}
+		}
+
+		return r.set(conn, current+size)
+	})
+}
+
+// Update sets the reservation of this upload session to size, no hard limits checked,
+// it's used to correct the reservation by the real progress of the upload
+func (r *uploadReservation) Update(size int64) error {
+	return r.withLock(func(conn redis.Conn) error {
+		return r.set(conn, size)
+	})
+}
+
+// Release releases the reservation of this upload session
+func (r *uploadReservation) Release() error {
+	return r.withLock(func(conn redis.Conn) error {
+		if _, err := conn.Do("DEL", r.sessionKey()); err != nil {
+			return err
+		}
+
+		_, err := conn.Do("HDEL", r.key(), r.UUID)
+		return err
+	})
+}
+
+func (r *uploadReservation) withLock(f func(redis.Conn) error) error {
+	m, err := redislock.RequireLock(r.MutexKey())
+	if err != nil {
+		return err
+	}
+	defer redislock.FreeLock(m)
+
+	conn, err := util.GetRegRedisCon()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return f(conn)
+}
+
+// newUploadReservation returns the reservation of the upload session in the repository,
+// nil returned if the project of the repository doesn't exist
+func newUploadReservation(repository, uuid string) (*uploadReservation, error) {
+	projectName, _ := utils.ParseRepository(repository)
+	project, err := dao.GetProjectByName(projectName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project %s, error: %v", projectName, err)
+	}
+	if project == nil {
+		return nil, nil
+	}
+
+	return &uploadReservation{
+		ProjectID:   project.ProjectID,
+		ProjectName: projectName,
+		UUID:        uuid,
+	}, nil
+}
+
+// releaseUploadReservation releases the reservation of the upload session for these requests
+// PUT    /v2/<name>/blobs/uploads/<uuid>?digest=<digest>
+// DELETE /v2/<name>/blobs/uploads/<uuid>
+func releaseUploadReservation(req *http.Request) error {
+	if !config.QuotaPerProjectEnable() {
+		return nil
+	}
+
+	if !match(req, http.MethodPut, blobUploadURLRe) && !match(req, http.MethodDelete, blobUploadURLRe) {
+		return nil
+	}
+
+	s := blobUploadURLRe.FindStringSubmatch(req.URL.Path)
+	repository, uuid := s[1][:len(s[1])-1], s[2]
+
+	reservation, err := newUploadReservation(repository, uuid)
+	if err != nil || reservation == nil {
+		return err
+	}
+
+	return reservation.Release()
+}
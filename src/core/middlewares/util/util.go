@@ -77,6 +77,7 @@ type ChartVersionInfo struct {
 	Namespace string
 	ChartName string
 	Version   string
+	Size      int64
 }
 
 // MutexKey returns mutex key of the chart version
@@ -330,8 +331,10 @@ func CopyResp(rec *httptest.ResponseRecorder, rw http.ResponseWriter) {
 type PolicyChecker interface {
 	// contentTrustEnabled returns whether a project has enabled content trust.
 	ContentTrustEnabled(name string) bool
-	// vulnerablePolicy  returns whether a project has enabled vulnerable, and the project's severity.
-	VulnerablePolicy(name string) (bool, models.Severity, models.CVEWhitelist)
+	// vulnerablePolicy returns the policy of a project to prevent the vulnerable images from being pulled.
+	VulnerablePolicy(name string) *models.VulnerablePolicy
+	// quarantineOnPush returns whether a project quarantines the pushed images until they pass the vulnerable policy.
+	QuarantineOnPush(name string) bool
 }
 
 // PmsPolicyChecker ...
@@ -350,29 +353,45 @@ func (pc PmsPolicyChecker) ContentTrustEnabled(name string) bool {
 }
 
 // VulnerablePolicy ...
-func (pc PmsPolicyChecker) VulnerablePolicy(name string) (bool, models.Severity, models.CVEWhitelist) {
+func (pc PmsPolicyChecker) VulnerablePolicy(name string) *models.VulnerablePolicy {
 	project, err := pc.pm.Get(name)
-	wl := models.CVEWhitelist{}
 	if err != nil {
 		log.Errorf("Unexpected error when getting the project, error: %v", err)
-		return true, models.SevUnknown, wl
+		return &models.VulnerablePolicy{
+			Enabled:  true,
+			Severity: models.SevUnknown,
+		}
+	}
+	policy := &models.VulnerablePolicy{
+		Enabled:       project.VulPrevented(),
+		Severity:      clair.ParseClairSev(project.Severity()),
+		CVSSThreshold: project.CVSSThreshold(),
+		FixableOnly:   project.FixableVulPrevented(),
 	}
 	mgr := whitelist.NewDefaultManager()
+	var wl *models.CVEWhitelist
 	if project.ReuseSysCVEWhitelist() {
-		w, err := mgr.GetSys()
-		if err != nil {
-			return project.VulPrevented(), clair.ParseClairSev(project.Severity()), wl
-		}
-		wl = *w
+		wl, err = mgr.GetSys()
 	} else {
-		w, err := mgr.Get(project.ProjectID)
-		if err != nil {
-			return project.VulPrevented(), clair.ParseClairSev(project.Severity()), wl
-		}
-		wl = *w
+		wl, err = mgr.Get(project.ProjectID)
 	}
-	return project.VulPrevented(), clair.ParseClairSev(project.Severity()), wl
+	if err == nil && wl != nil {
+		policy.Whitelist = *wl
+	}
+	return policy
+}
 
+// QuarantineOnPush ...
+func (pc PmsPolicyChecker) QuarantineOnPush(name string) bool {
+	project, err := pc.pm.Get(name)
+	if err != nil {
+		log.Errorf("Unexpected error when getting the project, error: %v", err)
+		return true
+	}
+	if project == nil {
+		return false
+	}
+	return project.QuarantineOnPush()
 }
 
 // NewPMSPolicyChecker returns an instance of an pmsPolicyChecker
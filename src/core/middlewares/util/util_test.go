@@ -171,6 +171,9 @@ func TestPMSPolicyChecker(t *testing.T) {
 			models.ProMetaEnableContentTrust:   "true",
 			models.ProMetaPreventVul:           "true",
 			models.ProMetaSeverity:             "low",
+			models.ProMetaPreventVulCVSS:       "8.0",
+			models.ProMetaPreventVulFixable:    "true",
+			models.ProMetaQuarantineOnPush:     "true",
 			models.ProMetaReuseSysCVEWhitelist: "false",
 		},
 	})
@@ -183,10 +186,14 @@ func TestPMSPolicyChecker(t *testing.T) {
 
 	contentTrustFlag := GetPolicyChecker().ContentTrustEnabled("project_for_test_get_sev_low")
 	assert.True(t, contentTrustFlag)
-	projectVulnerableEnabled, projectVulnerableSeverity, wl := GetPolicyChecker().VulnerablePolicy("project_for_test_get_sev_low")
-	assert.True(t, projectVulnerableEnabled)
-	assert.Equal(t, projectVulnerableSeverity, models.SevLow)
-	assert.Empty(t, wl.Items)
+	policy := GetPolicyChecker().VulnerablePolicy("project_for_test_get_sev_low")
+	assert.True(t, policy.Enabled)
+	assert.Equal(t, policy.Severity, models.SevLow)
+	assert.Equal(t, 8.0, policy.CVSSThreshold)
+	assert.True(t, policy.FixableOnly)
+	assert.Empty(t, policy.Whitelist.Items)
+	assert.True(t, GetPolicyChecker().QuarantineOnPush("project_for_test_get_sev_low"))
+	assert.False(t, GetPolicyChecker().QuarantineOnPush("project_not_exist"))
 }
 
 func TestCopyResp(t *testing.T) {
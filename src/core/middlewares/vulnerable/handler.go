@@ -17,9 +17,9 @@ package vulnerable
 import (
 	"fmt"
 	"github.com/goharbor/harbor/src/common/utils/log"
-	"github.com/goharbor/harbor/src/core/config"
 	"github.com/goharbor/harbor/src/core/middlewares/util"
 	"github.com/goharbor/harbor/src/pkg/scan"
+	"github.com/goharbor/harbor/src/pkg/scan/scanner"
 	"net/http"
 )
 
@@ -37,7 +37,7 @@ func New(next http.Handler) http.Handler {
 // ServeHTTP ...
 func (vh vulnerableHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	imgRaw := req.Context().Value(util.ImageInfoCtxKey)
-	if imgRaw == nil || !config.WithClair() {
+	if imgRaw == nil || !scanner.Enabled() {
 		vh.next.ServeHTTP(rw, req)
 		return
 	}
@@ -46,8 +46,8 @@ func (vh vulnerableHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request)
 		vh.next.ServeHTTP(rw, req)
 		return
 	}
-	projectVulnerableEnabled, projectVulnerableSeverity, wl := util.GetPolicyChecker().VulnerablePolicy(img.ProjectName)
-	if !projectVulnerableEnabled {
+	policy := util.GetPolicyChecker().VulnerablePolicy(img.ProjectName)
+	if !policy.Enabled {
 		vh.next.ServeHTTP(rw, req)
 		return
 	}
@@ -57,12 +57,12 @@ func (vh vulnerableHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request)
 		http.Error(rw, util.MarshalError("PROJECT_POLICY_VIOLATION", "Failed to get vulnerabilities."), http.StatusPreconditionFailed)
 		return
 	}
-	filtered := vl.ApplyWhitelist(wl)
+	filtered := vl.ApplyWhitelist(policy.Whitelist)
 	msg := vh.filterMsg(img, filtered)
 	log.Info(msg)
-	if int(vl.Severity()) >= int(projectVulnerableSeverity) {
-		log.Debugf("the image severity: %q is higher then project setting: %q, failing the response.", vl.Severity(), projectVulnerableSeverity)
-		http.Error(rw, util.MarshalError("PROJECT_POLICY_VIOLATION", fmt.Sprintf("The severity of vulnerability of the image: %q is equal or higher than the threshold in project setting: %q.", vl.Severity(), projectVulnerableSeverity)), http.StatusPreconditionFailed)
+	if violation := vl.PolicyViolation(policy); len(violation) > 0 {
+		log.Debugf("the image violates the vulnerable policy of the project, failing the response: %s", violation)
+		http.Error(rw, util.MarshalError("PROJECT_POLICY_VIOLATION", violation), http.StatusPreconditionFailed)
 		return
 	}
 	vh.next.ServeHTTP(rw, req)
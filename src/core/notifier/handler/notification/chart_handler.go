@@ -39,7 +39,7 @@ func (cph *ChartPreprocessHandler) Handle(value interface{}) error {
 	if project == nil {
 		return fmt.Errorf("project not found for chart event: %s", chartEvent.ProjectName)
 	}
-	policies, err := notification.PolicyMgr.GetRelatedPolices(project.ProjectID, chartEvent.EventType)
+	policies, err := notification.PolicyMgr.GetRelatedPolices(project, chartEvent.EventType)
 	if err != nil {
 		log.Errorf("failed to find policy for %s event: %v", chartEvent.EventType, err)
 		return err
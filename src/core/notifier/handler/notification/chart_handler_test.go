@@ -42,7 +42,7 @@ func (f *fakedPolicyMgr) Test(*models.NotificationPolicy) error {
 	return nil
 }
 
-func (f *fakedPolicyMgr) GetRelatedPolices(id int64, eventType string) ([]*models.NotificationPolicy, error) {
+func (f *fakedPolicyMgr) GetRelatedPolices(project *models.Project, eventType string) ([]*models.NotificationPolicy, error) {
 	return []*models.NotificationPolicy{
 		{
 			ID: 1,
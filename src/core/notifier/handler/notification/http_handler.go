@@ -1,7 +1,6 @@
 package notification
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -9,9 +8,11 @@ import (
 	"github.com/goharbor/harbor/src/core/notifier/model"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notification/formatter"
 )
 
-// HTTPHandler preprocess http event data and start the hook processing
+// HTTPHandler preprocess http event data and start the hook processing,
+// it handles the events of all the targets sending the payload by HTTP, e.g. http, slack and template
 type HTTPHandler struct {
 }
 
@@ -42,18 +43,22 @@ func (h *HTTPHandler) process(event *model.HookEvent) error {
 	}
 	j.Name = job.WebhookJob
 
-	payload, err := json.Marshal(event.Payload)
+	contentType, payload, err := formatter.Format(event.Target, event.Payload)
 	if err != nil {
-		return fmt.Errorf("marshal from payload %v failed: %v", event.Payload, err)
+		return fmt.Errorf("format payload %v for target type %s failed: %v", event.Payload, event.Target.Type, err)
 	}
 
 	j.Parameters = map[string]interface{}{
-		"payload": string(payload),
-		"address": event.Target.Address,
+		"payload":      string(payload),
+		"content_type": contentType,
+		"address":      event.Target.Address,
 		// Users can define a auth header in http statement in notification(webhook) policy.
 		// So it will be sent in header in http request.
 		"auth_header":      event.Target.AuthHeader,
 		"skip_cert_verify": event.Target.SkipCertVerify,
+		// The secret isn't passed as the parameters are kept in the job stats,
+		// the job loads it from the policy by the ID and the address of the target
+		"policy_id": event.PolicyID,
 	}
 	return notification.HookManager.StartHook(event, j)
 }
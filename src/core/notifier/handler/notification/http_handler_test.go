@@ -78,6 +78,47 @@ func TestHTTPHandler_Handle(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "HTTPHandler_Handle Slack",
+			args: args{
+				event: &event.Event{
+					Topic: "slack",
+					Data: &model.HookEvent{
+						PolicyID:  1,
+						EventType: "pushImage",
+						Target: &cModels.EventTarget{
+							Type:    "slack",
+							Address: "http://127.0.0.1:8080",
+						},
+						Payload: &model.Payload{
+							OccurAt: time.Now().Unix(),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "HTTPHandler_Handle Template Want Error",
+			args: args{
+				event: &event.Event{
+					Topic: "template",
+					Data: &model.HookEvent{
+						PolicyID:  1,
+						EventType: "pushImage",
+						Target: &cModels.EventTarget{
+							Type:            "template",
+							Address:         "http://127.0.0.1:8080",
+							PayloadTemplate: "{{ .NotExist }}",
+						},
+						Payload: &model.Payload{
+							OccurAt: time.Now().Unix(),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -47,8 +47,8 @@ func (f *fakedNotificationPlyMgr) Test(*models.NotificationPolicy) error {
 	return nil
 }
 
-func (f *fakedNotificationPlyMgr) GetRelatedPolices(id int64, eventType string) ([]*models.NotificationPolicy, error) {
-	if id == 1 {
+func (f *fakedNotificationPlyMgr) GetRelatedPolices(project *models.Project, eventType string) ([]*models.NotificationPolicy, error) {
+	if project.ProjectID == 1 {
 		return []*models.NotificationPolicy{
 			{
 				ID: 1,
@@ -65,7 +65,7 @@ func (f *fakedNotificationPlyMgr) GetRelatedPolices(id int64, eventType string)
 			},
 		}, nil
 	}
-	if id == 2 {
+	if project.ProjectID == 2 {
 		return nil, nil
 	}
 	return nil, errors.New("")
@@ -154,7 +154,7 @@ func preprocessAndSendImageHook(value interface{}) error {
 		return err
 	}
 
-	policies, err := notification.PolicyMgr.GetRelatedPolices(imgEvent.Project.ProjectID, imgEvent.EventType)
+	policies, err := notification.PolicyMgr.GetRelatedPolices(imgEvent.Project, imgEvent.EventType)
 	if err != nil {
 		log.Errorf("failed to find policy for %s event: %v", imgEvent.EventType, err)
 		return err
@@ -55,7 +55,7 @@ func (si *ScanImagePreprocessHandler) Handle(value interface{}) error {
 	if project == nil {
 		return fmt.Errorf("project[%s] not found", projectName)
 	}
-	policies, err := notification.PolicyMgr.GetRelatedPolices(project.ProjectID, e.EventType)
+	policies, err := notification.PolicyMgr.GetRelatedPolices(project, e.EventType)
 	if err != nil {
 		log.Errorf("failed to find policy for %s event: %v", e.EventType, err)
 		return err
@@ -21,6 +21,10 @@ const (
 
 	// WebhookTopic is topic for sending webhook payload
 	WebhookTopic = "http"
+	// SlackTopic is topic for sending webhook payload as Slack message
+	SlackTopic = "slack"
+	// TemplateTopic is topic for sending webhook payload rendered by the template of the target
+	TemplateTopic = "template"
 	// EmailTopic is topic for sending email payload
 	EmailTopic = "email"
 )
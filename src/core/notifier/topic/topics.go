@@ -14,6 +14,8 @@ func init() {
 		model.PullImageTopic:         {&notification.ImagePreprocessHandler{}},
 		model.DeleteImageTopic:       {&notification.ImagePreprocessHandler{}},
 		model.WebhookTopic:           {&notification.HTTPHandler{}},
+		model.SlackTopic:             {&notification.HTTPHandler{}},
+		model.TemplateTopic:          {&notification.HTTPHandler{}},
 		model.UploadChartTopic:       {&notification.ChartPreprocessHandler{}},
 		model.DownloadChartTopic:     {&notification.ChartPreprocessHandler{}},
 		model.DeleteChartTopic:       {&notification.ChartPreprocessHandler{}},
@@ -69,6 +69,7 @@ func initRouters() {
 	beego.Router("/api/projects/:id([0-9]+)/summary", &api.ProjectAPI{}, "get:Summary")
 	beego.Router("/api/projects/:id([0-9]+)/logs", &api.ProjectAPI{}, "get:Logs")
 	beego.Router("/api/projects/:id([0-9]+)/_deletable", &api.ProjectAPI{}, "get:Deletable")
+	beego.Router("/api/projects/:id([0-9]+)/vulnerability/export", &api.ProjectAPI{}, "get:ExportVulnerabilities")
 	beego.Router("/api/projects/:id([0-9]+)/metadatas/?:name", &api.MetadataAPI{}, "get:Get")
 	beego.Router("/api/projects/:id([0-9]+)/metadatas/", &api.MetadataAPI{}, "post:Post")
 	beego.Router("/api/projects/:id([0-9]+)/metadatas/:name", &api.MetadataAPI{}, "put:Put;delete:Delete")
@@ -78,6 +79,8 @@ func initRouters() {
 
 	beego.Router("/api/quotas", &api.QuotaAPI{}, "get:List")
 	beego.Router("/api/quotas/:id([0-9]+)", &api.QuotaAPI{}, "get:Get;put:Put")
+	beego.Router("/api/quotas/histories", &api.QuotaAPI{}, "get:ListHistories")
+	beego.Router("/api/quotas/:id([0-9]+)/histories", &api.QuotaAPI{}, "get:ListHistories")
 
 	beego.Router("/api/repositories", &api.RepositoryAPI{}, "get:Get")
 	beego.Router("/api/repositories/*", &api.RepositoryAPI{}, "delete:Delete;put:Put")
@@ -89,6 +92,8 @@ func initRouters() {
 	beego.Router("/api/repositories/*/tags", &api.RepositoryAPI{}, "get:GetTags;post:Retag")
 	beego.Router("/api/repositories/*/tags/:tag/scan", &api.RepositoryAPI{}, "post:ScanImage")
 	beego.Router("/api/repositories/*/tags/:tag/vulnerability/details", &api.RepositoryAPI{}, "Get:VulnerabilityDetails")
+	beego.Router("/api/repositories/*/tags/:tag/vulnerability/export", &api.RepositoryAPI{}, "get:ExportVulnerabilities")
+	beego.Router("/api/repositories/*/tags/:tag/sbom", &api.RepositoryAPI{}, "get:GetSBOM")
 	beego.Router("/api/repositories/*/tags/:tag/manifest", &api.RepositoryAPI{}, "get:GetManifests")
 	beego.Router("/api/repositories/*/signatures", &api.RepositoryAPI{}, "get:GetSignatures")
 	beego.Router("/api/repositories/top", &api.RepositoryAPI{}, "get:GetTopRepos")
@@ -100,6 +105,20 @@ func initRouters() {
 	beego.Router("/api/system/gc/schedule", &api.GCAPI{}, "get:Get;put:Put;post:Post")
 	beego.Router("/api/system/scanAll/schedule", &api.ScanAllAPI{}, "get:Get;put:Put;post:Post")
 	beego.Router("/api/system/CVEWhitelist", &api.SysCVEWhitelistAPI{}, "get:Get;put:Put")
+	beego.Router("/api/system/CVEWhitelist/audit", &api.SysCVEWhitelistAPI{}, "get:Audit")
+	beego.Router("/api/system/webhook/policies", &api.SysNotificationPolicyAPI{}, "get:List;post:Post")
+	beego.Router("/api/system/webhook/policies/:id([0-9]+)", &api.SysNotificationPolicyAPI{})
+	beego.Router("/api/system/webhook/policies/test", &api.SysNotificationPolicyAPI{}, "post:Test")
+	beego.Router("/api/system/webhook/jobs", &api.SysNotificationPolicyAPI{}, "get:ListJobs")
+	beego.Router("/api/vulnerabilities/top", &api.VulnerabilityAPI{}, "get:ListTopCVEs")
+	beego.Router("/api/vulnerabilities/:id/images", &api.VulnerabilityAPI{}, "get:ListAffectedImages")
+	beego.Router("/api/components/images", &api.ComponentAPI{}, "get:ListImages")
+	beego.Router("/api/scanners", &api.ScannerAPI{}, "get:List;post:Post")
+	beego.Router("/api/scanners/ping", &api.ScannerAPI{}, "post:Ping")
+	beego.Router("/api/scanners/:uuid", &api.ScannerAPI{}, "get:Get;put:Put;delete:Delete;patch:SetAsDefault")
+	beego.Router("/api/scanners/:uuid/metadata", &api.ScannerAPI{}, "get:Metadata")
+	beego.Router("/api/projects/:pid([0-9]+)/scanner", &api.ProjectScannerAPI{}, "get:Get;put:Put")
+	beego.Router("/api/projects/:pid([0-9]+)/scanner/candidates", &api.ProjectScannerAPI{}, "get:Candidates")
 	beego.Router("/api/system/oidc/ping", &api.OIDCAPI{}, "post:Ping")
 
 	beego.Router("/api/logs", &api.LogAPI{})
@@ -23,10 +23,12 @@ import (
 	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/goharbor/harbor/src/core/api"
+	"github.com/goharbor/harbor/src/core/middlewares/util"
 	"github.com/goharbor/harbor/src/core/notifier/event"
 	jjob "github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/pkg/notification"
 	"github.com/goharbor/harbor/src/pkg/retention"
+	"github.com/goharbor/harbor/src/pkg/scan/quarantine"
 	"github.com/goharbor/harbor/src/replication"
 	"github.com/goharbor/harbor/src/replication/operation/hook"
 	"github.com/goharbor/harbor/src/replication/policy/scheduler"
@@ -107,6 +109,13 @@ func (h *Handler) HandleScan() {
 		h.SendInternalServerError(err)
 		return
 	}
+
+	// Promote or reject the image quarantined on push once its scan is done
+	if h.status == models.JobFinished || h.status == models.JobError {
+		if err := quarantine.Evaluate(h.id, h.status == models.JobFinished, util.GetPolicyChecker().VulnerablePolicy); err != nil {
+			log.Errorf("Failed to evaluate the quarantined image of scan job %d, error: %v", h.id, err)
+		}
+	}
 }
 
 // HandleReplicationScheduleJob handles the webhook of replication schedule job
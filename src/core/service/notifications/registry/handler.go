@@ -30,6 +30,7 @@ import (
 	"github.com/goharbor/harbor/src/core/config"
 	notifierEvt "github.com/goharbor/harbor/src/core/notifier/event"
 	coreutils "github.com/goharbor/harbor/src/core/utils"
+	"github.com/goharbor/harbor/src/pkg/scan/scanner"
 	"github.com/goharbor/harbor/src/replication"
 	"github.com/goharbor/harbor/src/replication/adapter"
 	rep_event "github.com/goharbor/harbor/src/replication/event"
@@ -286,12 +287,13 @@ func checkEvent(event *models.Event) bool {
 }
 
 func autoScanEnabled(project *models.Project) bool {
-	if !config.WithClair() {
-		log.Debugf("Auto Scan disabled because Harbor is not deployed with Clair")
+	if !scanner.Enabled() {
+		log.Debugf("Auto Scan disabled because no scanner is available")
 		return false
 	}
 
-	return project.AutoScan()
+	// the images pushed to the project with quarantine on push enabled are scanned to get them promoted or rejected
+	return project.AutoScan() || project.QuarantineOnPush()
 }
 
 // Render returns nil as it won't render any template.
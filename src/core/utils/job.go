@@ -20,8 +20,10 @@ import (
 	"github.com/goharbor/harbor/src/common/job"
 	jobmodels "github.com/goharbor/harbor/src/common/job/models"
 	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/pkg/scan/scanner"
 
 	"encoding/json"
 	"fmt"
@@ -61,6 +63,10 @@ func TriggerImageScan(repository string, tag string) error {
 }
 
 func triggerImageScan(repository, tag, digest string, client job.Client) error {
+	scannerUUID, err := projectScanner(repository)
+	if err != nil {
+		return err
+	}
 	id, err := dao.AddScanJob(models.ScanJob{
 		Repository: repository,
 		Digest:     digest,
@@ -74,7 +80,7 @@ func triggerImageScan(repository, tag, digest string, client job.Client) error {
 	if err != nil {
 		return err
 	}
-	data, err := buildScanJobData(id, repository, tag, digest)
+	data, err := buildScanJobData(id, repository, tag, digest, scannerUUID)
 	if err != nil {
 		return err
 	}
@@ -89,12 +95,39 @@ func triggerImageScan(repository, tag, digest string, client job.Client) error {
 	return nil
 }
 
-func buildScanJobData(jobID int64, repository, tag, digest string) (*jobmodels.JobData, error) {
+// projectScanner returns the UUID of the scanner registration used by the project of the repository,
+// empty string is returned if the project uses the built-in Clair
+func projectScanner(repository string) (string, error) {
+	projectName, _ := utils.ParseRepository(repository)
+	project, err := config.GlobalProjectMgr.Get(projectName)
+	if err != nil {
+		return "", err
+	}
+	if project == nil {
+		return "", fmt.Errorf("project %s not found", projectName)
+	}
+
+	r, err := scanner.NewDefaultManager().GetProjectScanner(project.ProjectID)
+	if err != nil {
+		return "", err
+	}
+	if r == nil {
+		return "", fmt.Errorf("unable to perform scan: no scanner is available for project %s", projectName)
+	}
+	if r.UUID == scanner.BuiltinClairUUID {
+		return "", nil
+	}
+
+	return r.UUID, nil
+}
+
+func buildScanJobData(jobID int64, repository, tag, digest, scannerUUID string) (*jobmodels.JobData, error) {
 	parms := job.ScanJobParms{
-		JobID:      jobID,
-		Repository: repository,
-		Digest:     digest,
-		Tag:        tag,
+		JobID:       jobID,
+		Repository:  repository,
+		Digest:      digest,
+		Tag:         tag,
+		ScannerUUID: scannerUUID,
 	}
 	parmsMap := make(map[string]interface{})
 	b, err := json.Marshal(parms)
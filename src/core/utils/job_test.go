@@ -41,7 +41,7 @@ func TestBuildScanJobData(t *testing.T) {
 		},
 	}
 	for _, d := range testData {
-		r, err := buildScanJobData(d.input.JobID, d.input.Repository, d.input.Tag, d.input.Digest)
+		r, err := buildScanJobData(d.input.JobID, d.input.Repository, d.input.Tag, d.input.Digest, d.input.ScannerUUID)
 		assert.Nil(err)
 		assert.Equal(d.expect.Name, r.Name)
 		//		assert.Equal(d.expect.Parameters, r.Parameters)
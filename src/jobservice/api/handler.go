@@ -20,6 +20,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
@@ -30,6 +31,7 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/errs"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/jobservice/logger/getter"
 	"github.com/pkg/errors"
 	"strconv"
 )
@@ -37,6 +39,13 @@ import (
 const (
 	totalHeaderKey = "Total-Count"
 	nextCursorKey  = "Next-Cursor"
+
+	sseContentType = "text/event-stream"
+
+	// The interval of checking the new log data when following job log
+	logFollowInterval = 1 * time.Second
+	// The max duration of following job log in one request
+	maxLogFollowDuration = 2 * time.Hour
 )
 
 // Handler defines approaches to handle the http requests.
@@ -61,6 +70,9 @@ type Handler interface {
 
 	// HandleGetJobsReq is used to handle the request of getting jobs
 	HandleGetJobsReq(w http.ResponseWriter, req *http.Request)
+
+	// HandlePurgeJobsReq is used to handle the request of purging the finished jobs
+	HandlePurgeJobsReq(w http.ResponseWriter, req *http.Request)
 }
 
 // DefaultHandler is the default request handler which implements the Handler interface.
@@ -152,22 +164,33 @@ func (dh *DefaultHandler) HandleJobActionReq(w http.ResponseWriter, req *http.Re
 		return
 	}
 
-	// Only support stop command now
-	cmd := job.OPCommand(jobActionReq.Action)
-	if !cmd.IsStop() {
+	var (
+		cmd     = job.OPCommand(jobActionReq.Action)
+		wrapErr func(err error) error
+	)
+	switch {
+	case cmd.IsStop():
+		err = dh.controller.StopJob(jobID)
+		wrapErr = errs.StopJobError
+	case cmd.IsPause():
+		err = dh.controller.PauseJob(jobID)
+		wrapErr = errs.PauseJobError
+	case cmd.IsResume():
+		err = dh.controller.ResumeJob(jobID)
+		wrapErr = errs.ResumeJobError
+	default:
 		dh.handleError(w, req, http.StatusNotImplemented, errs.UnknownActionNameError(errors.Errorf("command: %s", jobActionReq.Action)))
 		return
 	}
 
-	// Stop job
-	if err := dh.controller.StopJob(jobID); err != nil {
+	if err != nil {
 		code := http.StatusInternalServerError
 		if errs.IsObjectNotFoundError(err) {
 			code = http.StatusNotFound
 		} else if errs.IsBadRequestError(err) {
 			code = http.StatusBadRequest
 		} else {
-			err = errs.StopJobError(err)
+			err = wrapErr(err)
 		}
 		dh.handleError(w, req, code, err)
 		return
@@ -199,7 +222,21 @@ func (dh *DefaultHandler) HandleJobLogReq(w http.ResponseWriter, req *http.Reque
 		return
 	}
 
-	logData, err := dh.controller.GetJobLogData(jobID)
+	if follow, _ := strconv.ParseBool(req.URL.Query().Get("follow")); follow {
+		dh.followJobLog(w, req, jobID)
+		return
+	}
+
+	var (
+		logData []byte
+		err     error
+	)
+	// Filter the log lines by the minimum level and the substring if they're specified
+	if filter := logFilter(req); filter != nil {
+		logData, err = dh.controller.GetJobLogDataWithFilter(jobID, filter)
+	} else {
+		logData, err = dh.controller.GetJobLogData(jobID)
+	}
 	if err != nil {
 		code := http.StatusInternalServerError
 		if errs.IsObjectNotFoundError(err) {
@@ -219,6 +256,103 @@ func (dh *DefaultHandler) HandleJobLogReq(w http.ResponseWriter, req *http.Reque
 	writeDate(w, logData)
 }
 
+// logFilter extracts the log filter from the query parameters 'level' and 'q'.
+// Nil is returned if no filter is specified.
+func logFilter(req *http.Request) *getter.Filter {
+	level := req.URL.Query().Get("level")
+	keyword := req.URL.Query().Get("q")
+	if len(level) == 0 && len(keyword) == 0 {
+		return nil
+	}
+
+	return &getter.Filter{
+		Level:   level,
+		Keyword: keyword,
+	}
+}
+
+// followJobLog streams the log of the job until the job is done.
+// The log lines are sent as server-sent events if the client accepts 'text/event-stream',
+// otherwise the log text is sent with chunked transfer encoding.
+func (dh *DefaultHandler) followJobLog(w http.ResponseWriter, req *http.Request, jobID string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		dh.handleError(w, req, http.StatusInternalServerError, errs.GetJobLogError(errors.New("streaming is not supported")))
+		return
+	}
+
+	// Make sure the job exists before streaming
+	if _, err := dh.controller.GetJob(jobID); err != nil {
+		code := http.StatusInternalServerError
+		if errs.IsObjectNotFoundError(err) {
+			code = http.StatusNotFound
+		} else if errs.IsBadRequestError(err) {
+			code = http.StatusBadRequest
+		} else {
+			err = errs.GetJobLogError(err)
+		}
+		dh.handleError(w, req, code, err)
+		return
+	}
+
+	sse := strings.Contains(req.Header.Get("Accept"), sseContentType)
+	if sse {
+		w.Header().Set("Content-Type", sseContentType)
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	dh.log(req, http.StatusOK, "follow")
+
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(logFollowInterval)
+	defer ticker.Stop()
+	timeout := time.After(maxLogFollowDuration)
+
+	var (
+		offset int64
+		done   bool
+	)
+	for {
+		// Check the status before reading the log to not miss the last lines
+		if theJob, err := dh.controller.GetJob(jobID); err != nil || job.RunningStatus.Compare(job.Status(theJob.Info.Status)) < 0 {
+			// The log is still read once more as the logger may be closed after the status is changed
+			if done {
+				return
+			}
+			done = true
+		}
+
+		data, err := dh.controller.GetJobLogDataFrom(jobID, offset)
+		if err != nil && !errs.IsObjectNotFoundError(err) {
+			logger.Errorf("Follow log of job %s error: %s", jobID, err)
+			return
+		}
+
+		if len(data) > 0 {
+			offset += int64(len(data))
+			if sse {
+				writeEvents(w, data)
+			} else {
+				writeDate(w, data)
+			}
+			flusher.Flush()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return
+		case <-timeout:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // HandlePeriodicExecutions is implementation of method defined in interface 'Handler'
 func (dh *DefaultHandler) HandlePeriodicExecutions(w http.ResponseWriter, req *http.Request) {
 	// Get param
@@ -268,6 +402,26 @@ func (dh *DefaultHandler) HandleGetJobsReq(w http.ResponseWriter, req *http.Requ
 	dh.handleJSONData(w, req, http.StatusOK, jobs)
 }
 
+// HandlePurgeJobsReq is implementation of method defined in interface 'Handler'
+func (dh *DefaultHandler) HandlePurgeJobsReq(w http.ResponseWriter, req *http.Request) {
+	// Get query parameters
+	q := extractQuery(req)
+	purged, err := dh.controller.PurgeJobs(q)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errs.IsBadRequestError(err) {
+			code = http.StatusBadRequest
+		} else {
+			err = errs.PurgeJobsError(err)
+		}
+		dh.handleError(w, req, code, err)
+		return
+	}
+
+	w.Header().Add(totalHeaderKey, fmt.Sprintf("%d", len(purged)))
+	dh.handleJSONData(w, req, http.StatusOK, purged)
+}
+
 func (dh *DefaultHandler) handleJSONData(w http.ResponseWriter, req *http.Request, code int, object interface{}) {
 	data, err := json.Marshal(object)
 	if err != nil {
@@ -337,6 +491,27 @@ func extractQuery(req *http.Request) *query.Parameter {
 		q.Extras.Set(query.ExtraParamKeyKind, jobKind)
 	}
 
+	// Extra job filters
+	if name := queries.Get(query.ParamKeyJobName); !utils.IsEmptyStr(name) {
+		q.Extras.Set(query.ExtraParamKeyJobName, name)
+	}
+
+	if status := queries.Get(query.ParamKeyJobStatus); !utils.IsEmptyStr(status) {
+		q.Extras.Set(query.ExtraParamKeyJobStatus, status)
+	}
+
+	if startTime := queries.Get(query.ParamKeyStartTime); !utils.IsEmptyStr(startTime) {
+		if start, err := strconv.ParseInt(startTime, 10, 64); err == nil {
+			q.Extras.Set(query.ExtraParamKeyStartTime, start)
+		}
+	}
+
+	if endTime := queries.Get(query.ParamKeyEndTime); !utils.IsEmptyStr(endTime) {
+		if end, err := strconv.ParseInt(endTime, 10, 64); err == nil {
+			q.Extras.Set(query.ExtraParamKeyEndTime, end)
+		}
+	}
+
 	// Extra query cursor
 	cursorV := queries.Get(query.ParamKeyCursor)
 	if !utils.IsEmptyStr(cursorV) {
@@ -348,6 +523,16 @@ func extractQuery(req *http.Request) *query.Parameter {
 	return q
 }
 
+// writeEvents writes the log lines as server-sent events
+func writeEvents(w http.ResponseWriter, data []byte) {
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", line); err != nil {
+			logger.Errorf("writer write error: %s", err)
+			return
+		}
+	}
+}
+
 func writeDate(w http.ResponseWriter, bytes []byte) {
 	if _, err := w.Write(bytes); err != nil {
 		logger.Errorf("writer write error: %s", err)
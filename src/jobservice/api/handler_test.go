@@ -21,6 +21,7 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/common/query"
 	"github.com/goharbor/harbor/src/jobservice/errs"
 	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger/getter"
 	"github.com/goharbor/harbor/src/jobservice/worker"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -29,6 +30,7 @@ import (
 	"io/ioutil"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -194,6 +196,26 @@ func (suite *APIHandlerTestSuite) TestJobActionSucceed() {
 	assert.Equal(suite.T(), 204, code, "expected 204 no content but got %d", code)
 }
 
+// TestJobPauseAndResume ...
+func (suite *APIHandlerTestSuite) TestJobPauseAndResume() {
+	fc := &fakeController{}
+	fc.On("PauseJob", "fake_periodic_job_ID").Return(nil)
+	fc.On("ResumeJob", "fake_periodic_job_ID").Return(nil)
+	fc.On("PauseJob", "fake_job_ID").Return(errs.BadRequestError("fake_job_ID"))
+	suite.controller = fc
+
+	data, _ := json.Marshal(createJobActionReq("pause"))
+	_, code := suite.postReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_periodic_job_ID"), data)
+	assert.Equal(suite.T(), 204, code, "expected 204 no content but got %d", code)
+
+	_, code = suite.postReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID"), data)
+	assert.Equal(suite.T(), 400, code, "expected 400 bad request but got %d", code)
+
+	data, _ = json.Marshal(createJobActionReq("resume"))
+	_, code = suite.postReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_periodic_job_ID"), data)
+	assert.Equal(suite.T(), 204, code, "expected 204 no content but got %d", code)
+}
+
 // TestCheckStatus ...
 func (suite *APIHandlerTestSuite) TestCheckStatus() {
 	statsRes := &worker.Stats{
@@ -240,6 +262,58 @@ func (suite *APIHandlerTestSuite) TestGetJobLog() {
 	assert.Equal(suite.T(), "hello log", string(resData))
 }
 
+// TestGetJobLogWithFilter ...
+func (suite *APIHandlerTestSuite) TestGetJobLogWithFilter() {
+	fc := &fakeController{}
+	fc.On("GetJobLogDataWithFilter", "fake_job_ID", &getter.Filter{Level: "error", Keyword: "sha256"}).
+		Return([]byte("error log"), nil)
+	fc.On("GetJobLogDataWithFilter", "fake_job_ID", &getter.Filter{Level: "bad"}).
+		Return(nil, errs.BadRequestError("invalid log level: bad"))
+	suite.controller = fc
+
+	resData, code := suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID/log?level=error&q=sha256"))
+	require.Equal(suite.T(), 200, code, "expected 200 ok but got %d", code)
+	assert.Equal(suite.T(), "error log", string(resData))
+
+	_, code = suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID/log?level=bad"))
+	assert.Equal(suite.T(), 400, code, "expected 400 bad request but got %d", code)
+}
+
+// TestFollowJobLog ...
+func (suite *APIHandlerTestSuite) TestFollowJobLog() {
+	stats := createJobStats("sample", job.KindGeneric, "")
+	stats.Info.Status = job.SuccessStatus.String()
+
+	fc := &fakeController{}
+	fc.On("GetJob", "fake_job_ID").Return(stats, nil)
+	fc.On("GetJobLogDataFrom", "fake_job_ID", int64(0)).Return([]byte("hello log\n"), nil)
+	fc.On("GetJob", "fake_job_ID_not").Return(nil, errs.NoObjectFoundError("fake_job_ID_not"))
+	suite.controller = fc
+
+	resData, code := suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID/log?follow=true"))
+	require.Equal(suite.T(), 200, code, "expected 200 ok but got %d", code)
+	assert.Equal(suite.T(), "hello log\n", string(resData))
+
+	_, code = suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID_not/log?follow=true"))
+	assert.Equal(suite.T(), 404, code, "expected 404 not found but got %d", code)
+}
+
+// TestWriteTimeout tests the write timeout is not applied to following job logs
+func (suite *APIHandlerTestSuite) TestWriteTimeout() {
+	h := withWriteTimeout(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}), 10*time.Millisecond)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/fake_job_ID/log", nil))
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, w.Code, "expected 503 as timeout but got %d", w.Code)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/fake_job_ID/log?follow=true", nil))
+	assert.Equal(suite.T(), http.StatusOK, w.Code, "expected 200 ok but got %d", w.Code)
+}
+
 // TestGetPeriodicExecutionsWithoutQuery ...
 func (suite *APIHandlerTestSuite) TestGetPeriodicExecutionsWithoutQuery() {
 	q := &query.Parameter{
@@ -302,6 +376,35 @@ func (suite *APIHandlerTestSuite) TestGetJobs() {
 	assert.Equal(suite.T(), 200, code, "expected 200 ok but got %d", code)
 }
 
+// TestPurgeJobs ...
+func (suite *APIHandlerTestSuite) TestPurgeJobs() {
+	q := &query.Parameter{
+		PageNumber: 1,
+		PageSize:   query.DefaultPageSize,
+		Extras:     make(query.ExtraParameters),
+	}
+	q.Extras.Set(query.ExtraParamKeyJobName, "sample")
+	q.Extras.Set(query.ExtraParamKeyJobStatus, job.SuccessStatus.String())
+	q.Extras.Set(query.ExtraParamKeyStartTime, int64(1000))
+	q.Extras.Set(query.ExtraParamKeyEndTime, int64(2000))
+
+	fc := &fakeController{}
+	fc.On("PurgeJobs", q).Return([]string{"fake_job_ID"}, nil)
+	suite.controller = fc
+
+	resData, code := suite.deleteReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs?name=sample&status=Success&start_time=1000&end_time=2000"))
+	require.Equal(suite.T(), 200, code, "expected 200 ok but got %d", code)
+	assert.Equal(suite.T(), `["fake_job_ID"]`, string(resData))
+
+	q.Extras = make(query.ExtraParameters)
+	fc = &fakeController{}
+	fc.On("PurgeJobs", q).Return(nil, errs.BadRequestError("end time of purging jobs is required"))
+	suite.controller = fc
+
+	_, code = suite.deleteReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs"))
+	assert.Equal(suite.T(), 400, code, "expected 400 bad request but got %d", code)
+}
+
 // createServer ...
 func (suite *APIHandlerTestSuite) createServer() {
 	port := uint(30000 + rand.Intn(1000))
@@ -378,6 +481,32 @@ func (suite *APIHandlerTestSuite) getReq(url string) ([]byte, int) {
 	return data, res.StatusCode
 }
 
+// deleteReq ...
+func (suite *APIHandlerTestSuite) deleteReq(url string) ([]byte, int) {
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return nil, 0
+	}
+
+	req.Header.Set(authHeader, fmt.Sprintf("%s %s", secretPrefix, fakeSecret))
+
+	res, err := suite.client.Do(req)
+	if err != nil {
+		return nil, 0
+	}
+
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, 0
+	}
+
+	return data, res.StatusCode
+}
+
 func (suite *APIHandlerTestSuite) LaunchJob(req *job.Request) (*job.Stats, error) {
 	return suite.controller.LaunchJob(req)
 }
@@ -390,6 +519,14 @@ func (suite *APIHandlerTestSuite) StopJob(jobID string) error {
 	return suite.controller.StopJob(jobID)
 }
 
+func (suite *APIHandlerTestSuite) PauseJob(jobID string) error {
+	return suite.controller.PauseJob(jobID)
+}
+
+func (suite *APIHandlerTestSuite) ResumeJob(jobID string) error {
+	return suite.controller.ResumeJob(jobID)
+}
+
 func (suite *APIHandlerTestSuite) RetryJob(jobID string) error {
 	return suite.controller.RetryJob(jobID)
 }
@@ -402,6 +539,14 @@ func (suite *APIHandlerTestSuite) GetJobLogData(jobID string) ([]byte, error) {
 	return suite.controller.GetJobLogData(jobID)
 }
 
+func (suite *APIHandlerTestSuite) GetJobLogDataFrom(jobID string, offset int64) ([]byte, error) {
+	return suite.controller.GetJobLogDataFrom(jobID, offset)
+}
+
+func (suite *APIHandlerTestSuite) GetJobLogDataWithFilter(jobID string, filter *getter.Filter) ([]byte, error) {
+	return suite.controller.GetJobLogDataWithFilter(jobID, filter)
+}
+
 func (suite *APIHandlerTestSuite) GetPeriodicExecutions(periodicJobID string, query *query.Parameter) ([]*job.Stats, int64, error) {
 	return suite.controller.GetPeriodicExecutions(periodicJobID, query)
 }
@@ -410,6 +555,10 @@ func (suite *APIHandlerTestSuite) GetJobs(query *query.Parameter) ([]*job.Stats,
 	return suite.controller.GetJobs(query)
 }
 
+func (suite *APIHandlerTestSuite) PurgeJobs(query *query.Parameter) ([]string, error) {
+	return suite.controller.PurgeJobs(query)
+}
+
 type fakeController struct {
 	mock.Mock
 }
@@ -437,6 +586,16 @@ func (fc *fakeController) StopJob(jobID string) error {
 	return args.Error(0)
 }
 
+func (fc *fakeController) PauseJob(jobID string) error {
+	args := fc.Called(jobID)
+	return args.Error(0)
+}
+
+func (fc *fakeController) ResumeJob(jobID string) error {
+	args := fc.Called(jobID)
+	return args.Error(0)
+}
+
 func (fc *fakeController) RetryJob(jobID string) error {
 	args := fc.Called(jobID)
 	return args.Error(0)
@@ -460,6 +619,24 @@ func (fc *fakeController) GetJobLogData(jobID string) ([]byte, error) {
 	return args.Get(0).([]byte), nil
 }
 
+func (fc *fakeController) GetJobLogDataFrom(jobID string, offset int64) ([]byte, error) {
+	args := fc.Called(jobID, offset)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]byte), nil
+}
+
+func (fc *fakeController) GetJobLogDataWithFilter(jobID string, filter *getter.Filter) ([]byte, error) {
+	args := fc.Called(jobID, filter)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]byte), nil
+}
+
 func (fc *fakeController) GetPeriodicExecutions(periodicJobID string, query *query.Parameter) ([]*job.Stats, int64, error) {
 	args := fc.Called(periodicJobID, query)
 	if args.Error(2) != nil {
@@ -478,6 +655,15 @@ func (fc *fakeController) GetJobs(query *query.Parameter) ([]*job.Stats, int64,
 	return args.Get(0).([]*job.Stats), args.Get(1).(int64), nil
 }
 
+func (fc *fakeController) PurgeJobs(query *query.Parameter) ([]string, error) {
+	args := fc.Called(query)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]string), nil
+}
+
 func createJobStats(name, kind, cron string) *job.Stats {
 	now := time.Now()
 	params := make(job.Parameters)
@@ -18,6 +18,8 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"context"
@@ -25,6 +27,9 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/logger"
 )
 
+// The timeout of writing the response of the API requests except following job logs
+const writeTimeout = 15 * time.Second
+
 // Server serves the http requests.
 type Server struct {
 	// The real backend http server to serve the requests
@@ -64,11 +69,11 @@ func NewServer(ctx context.Context, router Router, cfg ServerConfig) *Server {
 	}
 
 	srv := &http.Server{
-		Addr:         fmt.Sprintf(":%d", cfg.Port),
-		Handler:      http.HandlerFunc(router.ServeHTTP),
-		WriteTimeout: 15 * time.Second,
-		ReadTimeout:  15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		Addr: fmt.Sprintf(":%d", cfg.Port),
+		// The write timeout is applied per request as the requests following job logs are long-lived
+		Handler:     withWriteTimeout(http.HandlerFunc(router.ServeHTTP), writeTimeout),
+		ReadTimeout: 15 * time.Second,
+		IdleTimeout: 60 * time.Second,
 	}
 
 	// Initialize TLS/SSL config if protocol is https
@@ -115,3 +120,29 @@ func (s *Server) Stop() error {
 
 	return s.httpServer.Shutdown(shutDownCtx)
 }
+
+// withWriteTimeout wraps the handler to time out the requests except the ones following job logs,
+// which are bounded by maxLogFollowDuration instead
+func withWriteTimeout(h http.Handler, timeout time.Duration) http.Handler {
+	timeoutHandler := http.TimeoutHandler(h, timeout, "")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if isFollowingJobLog(req) {
+			h.ServeHTTP(w, req)
+			return
+		}
+
+		timeoutHandler.ServeHTTP(w, req)
+	})
+}
+
+// isFollowingJobLog checks if the request is following job log: GET /api/v1/jobs/{job_id}/log?follow=true
+func isFollowingJobLog(req *http.Request) bool {
+	if req.Method != http.MethodGet || !strings.HasSuffix(req.URL.Path, "/log") {
+		return false
+	}
+
+	follow, _ := strconv.ParseBool(req.URL.Query().Get("follow"))
+
+	return follow
+}
@@ -29,12 +29,28 @@ const (
 	ParamKeyCursor = "cursor"
 	// ParamKeyJobKind defines query param of job kind
 	ParamKeyJobKind = "kind"
+	// ParamKeyJobName defines query param of job name
+	ParamKeyJobName = "name"
+	// ParamKeyJobStatus defines query param of job status
+	ParamKeyJobStatus = "status"
+	// ParamKeyStartTime defines query param of the start of the enqueue time range with unix timestamp
+	ParamKeyStartTime = "start_time"
+	// ParamKeyEndTime defines query param of the end of the enqueue time range with unix timestamp
+	ParamKeyEndTime = "end_time"
 	// ExtraParamKeyNonStoppedOnly defines extra parameter key for querying non stopped periodic executions
 	ExtraParamKeyNonStoppedOnly = "NonDeadOnly"
 	// ExtraParamKeyCursor defines extra parameter key for the cursor of fetching job stats with batches
 	ExtraParamKeyCursor = "Cursor"
 	// ExtraParamKeyKind defines extra parameter key for the job kind
 	ExtraParamKeyKind = "Kind"
+	// ExtraParamKeyJobName defines extra parameter key for the job name
+	ExtraParamKeyJobName = "JobName"
+	// ExtraParamKeyJobStatus defines extra parameter key for the job status
+	ExtraParamKeyJobStatus = "JobStatus"
+	// ExtraParamKeyStartTime defines extra parameter key for the start of the enqueue time range
+	ExtraParamKeyStartTime = "StartTime"
+	// ExtraParamKeyEndTime defines extra parameter key for the end of the enqueue time range
+	ExtraParamKeyEndTime = "EndTime"
 )
 
 // ExtraParameters to keep non pagination query parameters
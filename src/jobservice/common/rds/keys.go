@@ -86,3 +86,28 @@ func KeyHookEventRetryQueue(namespace string) string {
 func KeyStatusUpdateRetryQueue(namespace string) string {
 	return fmt.Sprintf("%s%s", KeyNamespacePrefix(namespace), "status_change_events")
 }
+
+// RedisKeyJobs returns the key of the queue of the jobs with the specified name.
+func RedisKeyJobs(namespace, jobName string) string {
+	return RedisNamespacePrefix(namespace) + "jobs:" + jobName
+}
+
+// KeyJobUpstreams returns the key of the upstream jobs the job is still waiting for
+func KeyJobUpstreams(namespace, jobID string) string {
+	return fmt.Sprintf("%s%s:%s", KeyNamespacePrefix(namespace), "job_upstreams", jobID)
+}
+
+// KeyJobDependents returns the key of the jobs depending on the job
+func KeyJobDependents(namespace, jobID string) string {
+	return fmt.Sprintf("%s%s:%s", KeyNamespacePrefix(namespace), "job_dependents", jobID)
+}
+
+// KeyRunningJobs returns the key of the running jobs scored by their heartbeat time
+func KeyRunningJobs(namespace string) string {
+	return fmt.Sprintf("%s%s", KeyNamespacePrefix(namespace), "running_jobs")
+}
+
+// KeyZombieReaperLock returns the key of the lock held by the node reaping the zombie jobs
+func KeyZombieReaperLock(namespace string) string {
+	return fmt.Sprintf("%s%s", KeyNamespacePrefix(namespace), "zombie_reaper_lock")
+}
@@ -38,6 +38,12 @@ const (
 	jobServiceRedisURL                   = "JOB_SERVICE_POOL_REDIS_URL"
 	jobServiceRedisNamespace             = "JOB_SERVICE_POOL_REDIS_NAMESPACE"
 	jobServiceRedisIdleConnTimeoutSecond = "JOB_SERVICE_POOL_REDIS_CONN_IDLE_TIMEOUT_SECOND"
+	jobServicePostgreSQLHost             = "JOB_SERVICE_POOL_POSTGRESQL_HOST"
+	jobServicePostgreSQLPort             = "JOB_SERVICE_POOL_POSTGRESQL_PORT"
+	jobServicePostgreSQLUsername         = "JOB_SERVICE_POOL_POSTGRESQL_USERNAME"
+	jobServicePostgreSQLPassword         = "JOB_SERVICE_POOL_POSTGRESQL_PASSWORD"
+	jobServicePostgreSQLDatabase         = "JOB_SERVICE_POOL_POSTGRESQL_DATABASE"
+	jobServicePostgreSQLSSLMode          = "JOB_SERVICE_POOL_POSTGRESQL_SSLMODE"
 	jobServiceAuthSecret                 = "JOBSERVICE_SECRET"
 	coreURL                              = "CORE_URL"
 
@@ -48,12 +54,26 @@ const (
 
 	// JobServicePoolBackendRedis represents redis backend
 	JobServicePoolBackendRedis = "redis"
+	// JobServicePoolBackendPostgreSQL represents postgresql backend
+	JobServicePoolBackendPostgreSQL = "postgresql"
 
 	// secret of UI
 	uiAuthSecret = "CORE_SECRET"
 
 	// redis protocol schema
 	redisSchema = "redis://"
+
+	// DefaultQueueName is the name of the queue keeping the jobs not put to any named queue
+	DefaultQueueName = "default"
+
+	// the max priority of job queue supported by the worker
+	maxQueuePriority = 100000
+
+	// ExternalJobTypeExec is the type of the external job runner which runs an executable
+	ExternalJobTypeExec = "exec"
+
+	// ExternalJobTypeHTTP is the type of the external job runner which calls an HTTP endpoint
+	ExternalJobTypeHTTP = "http"
 )
 
 // DefaultConfig is the default configuration reference
@@ -78,6 +98,9 @@ type Configuration struct {
 
 	// Logger configurations
 	LoggerConfigs []*LoggerConfig `yaml:"loggers,omitempty"`
+
+	// Runners of the external jobs
+	ExternalJobs []*ExternalJobConfig `yaml:"external_jobs,omitempty"`
 }
 
 // HTTPSConfig keeps additional configurations when using https protocol
@@ -96,12 +119,81 @@ type RedisPoolConfig struct {
 	IdleTimeoutSecond int64 `yaml:"idle_timeout_second"`
 }
 
+// PostgreSQLPoolConfig keeps postgresql worker info.
+type PostgreSQLPoolConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	Database string `yaml:"database"`
+	SSLMode  string `yaml:"sslmode"`
+	// MaxOpenConns limits the connections opened to the database, 0 means it's decided by the worker count
+	MaxOpenConns int `yaml:"max_open_conns"`
+}
+
+// DataSourceName returns the data source name of the postgresql database
+func (p *PostgreSQLPoolConfig) DataSourceName() string {
+	sslMode := p.SSLMode
+	if utils.IsEmptyStr(sslMode) {
+		sslMode = "disable"
+	}
+
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		p.Host, p.Port, p.Username, p.Password, p.Database, sslMode)
+}
+
 // PoolConfig keeps worker worker configurations.
 type PoolConfig struct {
 	// Worker concurrency
-	WorkerCount  uint             `yaml:"workers"`
-	Backend      string           `yaml:"backend"`
-	RedisPoolCfg *RedisPoolConfig `yaml:"redis_pool,omitempty"`
+	WorkerCount       uint                  `yaml:"workers"`
+	Backend           string                `yaml:"backend"`
+	RedisPoolCfg      *RedisPoolConfig      `yaml:"redis_pool,omitempty"`
+	PostgreSQLPoolCfg *PostgreSQLPoolConfig `yaml:"postgresql_pool,omitempty"`
+	// Named queues to separate the jobs, the jobs not in any queue are put to the default queue
+	Queues []*QueueConfig `yaml:"queues,omitempty"`
+}
+
+// QueueConfig keeps the settings of the named job queue.
+type QueueConfig struct {
+	Name string `yaml:"name"`
+	// Jobs of the queue with higher priority are picked up more frequently, range is 1~100000
+	Priority uint `yaml:"priority"`
+	// Limit the running jobs of the queue on each node, 0 means no limit
+	MaxConcurrency uint `yaml:"max_concurrency"`
+	// Names of the jobs put to the queue
+	Jobs []string `yaml:"jobs"`
+}
+
+// ExternalJobConfig keeps the configurations of an external job runner.
+// The runner is specified with the 'runner' parameter of the external job.
+type ExternalJobConfig struct {
+	// Unique name of the runner
+	Name string `yaml:"name"`
+	// Type of the runner, "exec" or "http"
+	Type string `yaml:"type"`
+	// The executable and its arguments run by the "exec" runner
+	Command []string `yaml:"command,omitempty"`
+	// Extra environment variables of the executable
+	Env map[string]string `yaml:"env,omitempty"`
+	// The endpoint called by the "http" runner
+	URL string `yaml:"url,omitempty"`
+	// The value of the Authorization header sent to the endpoint
+	AuthHeader string `yaml:"auth_header,omitempty"`
+	// Skip verifying the certificate of the endpoint
+	SkipCertVerify bool `yaml:"skip_cert_verify,omitempty"`
+	// The max running seconds of the runner, 0 means no limit
+	Timeout uint `yaml:"timeout"`
+}
+
+// ExternalJob returns the external job runner with the specified name, nil is returned if it doesn't exist
+func (c *Configuration) ExternalJob(name string) *ExternalJobConfig {
+	for _, ej := range c.ExternalJobs {
+		if ej != nil && ej.Name == name {
+			return ej
+		}
+	}
+
+	return nil
 }
 
 // CustomizedSettings keeps the customized settings of logger
@@ -237,7 +329,12 @@ func (c *Configuration) loadEnvs() {
 		}
 	}
 
-	if c.PoolConfig != nil && c.PoolConfig.Backend == JobServicePoolBackendRedis {
+	if c.PoolConfig != nil && c.PoolConfig.Backend == JobServicePoolBackendPostgreSQL {
+		c.loadPostgreSQLEnvs()
+	}
+
+	// Redis is also required by the postgresql backend to keep the job stats
+	if c.PoolConfig != nil {
 		redisURL := utils.ReadEnv(jobServiceRedisURL)
 		if !utils.IsEmptyStr(redisURL) {
 			if c.PoolConfig.RedisPoolCfg == nil {
@@ -270,6 +367,42 @@ func (c *Configuration) loadEnvs() {
 
 }
 
+// Load env variables of the postgresql worker
+func (c *Configuration) loadPostgreSQLEnvs() {
+	if c.PoolConfig.PostgreSQLPoolCfg == nil {
+		c.PoolConfig.PostgreSQLPoolCfg = &PostgreSQLPoolConfig{}
+	}
+	cfg := c.PoolConfig.PostgreSQLPoolCfg
+
+	if host := utils.ReadEnv(jobServicePostgreSQLHost); !utils.IsEmptyStr(host) {
+		cfg.Host = host
+	}
+
+	if port := utils.ReadEnv(jobServicePostgreSQLPort); !utils.IsEmptyStr(port) {
+		if po, err := strconv.Atoi(port); err == nil {
+			cfg.Port = po
+		} else {
+			log.Warningf("Invalid postgresql port: %s, ignored", port)
+		}
+	}
+
+	if username := utils.ReadEnv(jobServicePostgreSQLUsername); !utils.IsEmptyStr(username) {
+		cfg.Username = username
+	}
+
+	if password := utils.ReadEnv(jobServicePostgreSQLPassword); !utils.IsEmptyStr(password) {
+		cfg.Password = password
+	}
+
+	if database := utils.ReadEnv(jobServicePostgreSQLDatabase); !utils.IsEmptyStr(database) {
+		cfg.Database = database
+	}
+
+	if sslMode := utils.ReadEnv(jobServicePostgreSQLSSLMode); !utils.IsEmptyStr(sslMode) {
+		cfg.SSLMode = sslMode
+	}
+}
+
 // Check if the configurations are valid settings.
 func (c *Configuration) validate() error {
 	if c.Protocol != JobServiceProtocolHTTPS &&
@@ -301,32 +434,62 @@ func (c *Configuration) validate() error {
 		return errors.New("no worker worker is configured")
 	}
 
-	if c.PoolConfig.Backend != JobServicePoolBackendRedis {
+	if c.PoolConfig.Backend != JobServicePoolBackendRedis &&
+		c.PoolConfig.Backend != JobServicePoolBackendPostgreSQL {
 		return fmt.Errorf("worker worker backend %s does not support", c.PoolConfig.Backend)
 	}
 
-	// When backend is redis
-	if c.PoolConfig.Backend == JobServicePoolBackendRedis {
-		if c.PoolConfig.RedisPoolCfg == nil {
-			return fmt.Errorf("redis worker must be configured when backend is set to '%s'", c.PoolConfig.Backend)
+	// When backend is postgresql
+	if c.PoolConfig.Backend == JobServicePoolBackendPostgreSQL {
+		pgCfg := c.PoolConfig.PostgreSQLPoolCfg
+		if pgCfg == nil {
+			return fmt.Errorf("postgresql worker must be configured when backend is set to '%s'", c.PoolConfig.Backend)
 		}
-		if utils.IsEmptyStr(c.PoolConfig.RedisPoolCfg.RedisURL) {
-			return errors.New("URL of redis worker is empty")
+
+		if utils.IsEmptyStr(pgCfg.Host) {
+			return errors.New("host of postgresql worker is empty")
 		}
 
-		if !strings.HasPrefix(c.PoolConfig.RedisPoolCfg.RedisURL, redisSchema) {
-			return errors.New("invalid redis URL")
+		if pgCfg.Port <= 0 || !utils.IsValidPort(uint(pgCfg.Port)) {
+			return fmt.Errorf("invalid port of postgresql worker: %d", pgCfg.Port)
 		}
 
-		if _, err := url.Parse(c.PoolConfig.RedisPoolCfg.RedisURL); err != nil {
-			return fmt.Errorf("invalid redis URL: %s", err.Error())
+		if utils.IsEmptyStr(pgCfg.Username) {
+			return errors.New("username of postgresql worker is empty")
 		}
 
-		if utils.IsEmptyStr(c.PoolConfig.RedisPoolCfg.Namespace) {
-			return errors.New("namespace of redis worker is required")
+		if utils.IsEmptyStr(pgCfg.Database) {
+			return errors.New("database of postgresql worker is empty")
 		}
 	}
 
+	// Redis keeps the job stats for both of the backends and the queue for the redis backend
+	if c.PoolConfig.RedisPoolCfg == nil {
+		if c.PoolConfig.Backend == JobServicePoolBackendPostgreSQL {
+			return fmt.Errorf("redis worker must be configured with the '%s' backend too, it keeps the job stats, status hooks and periodic executions", c.PoolConfig.Backend)
+		}
+		return fmt.Errorf("redis worker must be configured when backend is set to '%s'", c.PoolConfig.Backend)
+	}
+	if utils.IsEmptyStr(c.PoolConfig.RedisPoolCfg.RedisURL) {
+		return errors.New("URL of redis worker is empty")
+	}
+
+	if !strings.HasPrefix(c.PoolConfig.RedisPoolCfg.RedisURL, redisSchema) {
+		return errors.New("invalid redis URL")
+	}
+
+	if _, err := url.Parse(c.PoolConfig.RedisPoolCfg.RedisURL); err != nil {
+		return fmt.Errorf("invalid redis URL: %s", err.Error())
+	}
+
+	if utils.IsEmptyStr(c.PoolConfig.RedisPoolCfg.Namespace) {
+		return errors.New("namespace of redis worker is required")
+	}
+
+	if err := c.PoolConfig.validateQueues(); err != nil {
+		return err
+	}
+
 	// Job service loggers
 	if len(c.LoggerConfigs) == 0 {
 		return errors.New("missing logger config of job service")
@@ -337,5 +500,89 @@ func (c *Configuration) validate() error {
 		return errors.New("missing logger config of job")
 	}
 
+	for _, lc := range c.JobLoggerConfigs {
+		if format, ok := lc.Settings["format"]; ok && format != "text" && format != "json" {
+			return fmt.Errorf("invalid log format of job logger %s: %v", lc.Name, format)
+		}
+	}
+
+	if err := c.validateExternalJobs(); err != nil {
+		return err
+	}
+
 	return nil // valid
 }
+
+// validateExternalJobs checks the runners of the external jobs
+func (c *Configuration) validateExternalJobs() error {
+	names := make(map[string]bool)
+
+	for _, ej := range c.ExternalJobs {
+		if ej == nil || utils.IsEmptyStr(ej.Name) {
+			return errors.New("name of external job runner is empty")
+		}
+
+		if names[ej.Name] {
+			return fmt.Errorf("duplicated external job runner: %s", ej.Name)
+		}
+		names[ej.Name] = true
+
+		switch ej.Type {
+		case ExternalJobTypeExec:
+			if len(ej.Command) == 0 || utils.IsEmptyStr(ej.Command[0]) {
+				return fmt.Errorf("command of external job runner %s is empty", ej.Name)
+			}
+		case ExternalJobTypeHTTP:
+			if _, err := url.ParseRequestURI(ej.URL); err != nil {
+				return fmt.Errorf("invalid url of external job runner %s: %s", ej.Name, err)
+			}
+		default:
+			return fmt.Errorf("type of external job runner %s should be '%s' or '%s', but current is '%s'",
+				ej.Name, ExternalJobTypeExec, ExternalJobTypeHTTP, ej.Type)
+		}
+	}
+
+	return nil
+}
+
+// validateQueues checks the named queues, each job can only be put to one queue
+func (p *PoolConfig) validateQueues() error {
+	if len(p.Queues) > 0 && p.Backend == JobServicePoolBackendPostgreSQL {
+		return fmt.Errorf("job queues are not supported by the %s backend of worker pool", JobServicePoolBackendPostgreSQL)
+	}
+
+	queues := make(map[string]bool)
+	jobs := make(map[string]string)
+
+	for _, q := range p.Queues {
+		if q == nil || utils.IsEmptyStr(q.Name) {
+			return errors.New("name of job queue is empty")
+		}
+
+		if q.Name == DefaultQueueName {
+			return fmt.Errorf("job queue name '%s' is reserved", DefaultQueueName)
+		}
+
+		if queues[q.Name] {
+			return fmt.Errorf("duplicated job queue: %s", q.Name)
+		}
+		queues[q.Name] = true
+
+		if q.Priority > maxQueuePriority {
+			return fmt.Errorf("priority of job queue %s should be between 1 and %d, but current is %d", q.Name, maxQueuePriority, q.Priority)
+		}
+
+		if len(q.Jobs) == 0 {
+			return fmt.Errorf("no jobs are put to job queue %s", q.Name)
+		}
+
+		for _, jobName := range q.Jobs {
+			if queue, ok := jobs[jobName]; ok {
+				return fmt.Errorf("job %s is put to both job queue %s and %s", jobName, queue, q.Name)
+			}
+			jobs[jobName] = q.Name
+		}
+	}
+
+	return nil
+}
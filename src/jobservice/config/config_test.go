@@ -88,6 +88,102 @@ func (suite *ConfigurationTestSuite) TestConfigLoadingWithEnv() {
 	assert.Equal(suite.T(), "core_url", GetCoreURL(), "expect core url 'core_url' but got '%s'", GetCoreURL())
 }
 
+// TestConfigLoadingPostgreSQLBackend ...
+func (suite *ConfigurationTestSuite) TestConfigLoadingPostgreSQLBackend() {
+	err := setENV()
+	require.Nil(suite.T(), err, "set envs: expect nil error but got error '%s'", err)
+
+	defer func() {
+		err := unsetENV()
+		require.Nil(suite.T(), err, "unset envs: expect nil error but got error '%s'", err)
+	}()
+
+	_ = os.Setenv("JOB_SERVICE_POOL_BACKEND", "postgresql")
+
+	cfg := &Configuration{}
+	err = cfg.Load("../config_test.yml", true)
+	assert.NotNil(suite.T(), err, "load config without postgresql host, expect non nil error but got nil")
+
+	err = setPostgreSQLENV()
+	require.Nil(suite.T(), err, "set postgresql envs: expect nil error but got error '%s'", err)
+
+	// The named queues in the yaml file are not supported by the postgresql backend
+	cfg = &Configuration{}
+	err = cfg.Load("../config_test.yml", true)
+	require.NotNil(suite.T(), err, "load config with job queues, expect non nil error but got nil")
+
+	cfg.PoolConfig.Queues = nil
+	err = cfg.validate()
+	require.Nil(suite.T(), err, "validate config without job queues, expect nil error but got error '%s'", err)
+
+	pgCfg := cfg.PoolConfig.PostgreSQLPoolCfg
+	require.NotNil(suite.T(), pgCfg, "expect non nil postgresql pool config but got nil")
+	assert.Equal(suite.T(), "postgresql", cfg.PoolConfig.Backend)
+	assert.Equal(suite.T(), "host=8.8.8.8 port=5432 user=postgres password=root123 dbname=registry sslmode=disable", pgCfg.DataSourceName())
+	assert.Equal(suite.T(), "ut_namespace", cfg.PoolConfig.RedisPoolCfg.Namespace)
+
+	// Redis is still required by the postgresql backend
+	cfg.PoolConfig.RedisPoolCfg = nil
+	err = cfg.validate()
+	require.NotNil(suite.T(), err, "validate config without redis, expect non nil error but got nil")
+	assert.Contains(suite.T(), err.Error(), "job stats")
+}
+
+// TestQueuesValidation ...
+func (suite *ConfigurationTestSuite) TestQueuesValidation() {
+	p := &PoolConfig{
+		Queues: []*QueueConfig{
+			{Name: "scan", Priority: 1, MaxConcurrency: 2, Jobs: []string{"IMAGE_SCAN"}},
+			{Name: "webhook", Priority: 10, Jobs: []string{"WEBHOOK"}},
+		},
+	}
+	assert.Nil(suite.T(), p.validateQueues(), "expect nil error of valid job queues")
+
+	p.Queues[1].Jobs = []string{"IMAGE_SCAN"}
+	assert.NotNil(suite.T(), p.validateQueues(), "expect non nil error of job in multiple queues")
+
+	p.Queues[1].Jobs = []string{"WEBHOOK"}
+	p.Queues[1].Name = "scan"
+	assert.NotNil(suite.T(), p.validateQueues(), "expect non nil error of duplicated queues")
+
+	p.Queues[1].Name = DefaultQueueName
+	assert.NotNil(suite.T(), p.validateQueues(), "expect non nil error of reserved queue name")
+
+	p.Queues[1].Name = "webhook"
+	p.Queues[1].Priority = 100001
+	assert.NotNil(suite.T(), p.validateQueues(), "expect non nil error of too large priority")
+
+	p.Queues[1].Priority = 10
+	p.Backend = JobServicePoolBackendPostgreSQL
+	assert.NotNil(suite.T(), p.validateQueues(), "expect non nil error of queues with postgresql backend")
+}
+
+// TestExternalJobsValidation ...
+func (suite *ConfigurationTestSuite) TestExternalJobsValidation() {
+	c := &Configuration{
+		ExternalJobs: []*ExternalJobConfig{
+			{Name: "cleanup", Type: ExternalJobTypeExec, Command: []string{"/bin/cleanup"}},
+			{Name: "notify", Type: ExternalJobTypeHTTP, URL: "http://housekeeper:8080/run"},
+		},
+	}
+	assert.Nil(suite.T(), c.validateExternalJobs(), "expect nil error of valid external jobs")
+	assert.NotNil(suite.T(), c.ExternalJob("notify"), "expect external job runner 'notify'")
+	assert.Nil(suite.T(), c.ExternalJob("none"), "expect nil external job runner 'none'")
+
+	c.ExternalJobs[1].Name = "cleanup"
+	assert.NotNil(suite.T(), c.validateExternalJobs(), "expect non nil error of duplicated runners")
+
+	c.ExternalJobs[1].Name = "notify"
+	c.ExternalJobs[1].URL = "housekeeper"
+	assert.NotNil(suite.T(), c.validateExternalJobs(), "expect non nil error of invalid url")
+
+	c.ExternalJobs[1].Type = "grpc"
+	assert.NotNil(suite.T(), c.validateExternalJobs(), "expect non nil error of unknown type")
+
+	c.ExternalJobs[1].Type = ExternalJobTypeExec
+	assert.NotNil(suite.T(), c.validateExternalJobs(), "expect non nil error of empty command")
+}
+
 // TestDefaultConfig ...
 func (suite *ConfigurationTestSuite) TestDefaultConfig() {
 	err := DefaultConfig.Load("../config_test.yml", true)
@@ -96,6 +192,12 @@ func (suite *ConfigurationTestSuite) TestDefaultConfig() {
 	redisURL := DefaultConfig.PoolConfig.RedisPoolCfg.RedisURL
 	assert.Equal(suite.T(), "redis://localhost:6379", redisURL, "expect redisURL '%s' but got '%s'", "redis://localhost:6379", redisURL)
 
+	queues := DefaultConfig.PoolConfig.Queues
+	require.Equal(suite.T(), 2, len(queues), "expect 2 job queues configured but got %d", len(queues))
+	assert.Equal(suite.T(), "scan", queues[0].Name, "expect job queue 'scan' but got %s", queues[0].Name)
+	assert.Equal(suite.T(), uint(2), queues[0].MaxConcurrency, "expect max concurrency 2 of job queue 'scan' but got %d", queues[0].MaxConcurrency)
+	assert.Equal(suite.T(), []string{"IMAGE_SCAN", "IMAGE_SCAN_ALL"}, queues[0].Jobs)
+
 	jLoggerCount := len(DefaultConfig.JobLoggerConfigs)
 	assert.Equal(suite.T(), 2, jLoggerCount, "expect 2 job loggers configured but got %d", jLoggerCount)
 
@@ -141,6 +243,16 @@ func setENV() error {
 	return err
 }
 
+func setPostgreSQLENV() error {
+	err := os.Setenv("JOB_SERVICE_POOL_POSTGRESQL_HOST", "8.8.8.8")
+	err = os.Setenv("JOB_SERVICE_POOL_POSTGRESQL_PORT", "5432")
+	err = os.Setenv("JOB_SERVICE_POOL_POSTGRESQL_USERNAME", "postgres")
+	err = os.Setenv("JOB_SERVICE_POOL_POSTGRESQL_PASSWORD", "root123")
+	err = os.Setenv("JOB_SERVICE_POOL_POSTGRESQL_DATABASE", "registry")
+
+	return err
+}
+
 func unsetENV() error {
 	err := os.Unsetenv("JOB_SERVICE_PROTOCOL")
 	err = os.Unsetenv("JOB_SERVICE_PORT")
@@ -150,6 +262,11 @@ func unsetENV() error {
 	err = os.Unsetenv("JOB_SERVICE_POOL_WORKERS")
 	err = os.Unsetenv("JOB_SERVICE_POOL_REDIS_URL")
 	err = os.Unsetenv("JOB_SERVICE_POOL_REDIS_NAMESPACE")
+	err = os.Unsetenv("JOB_SERVICE_POOL_POSTGRESQL_HOST")
+	err = os.Unsetenv("JOB_SERVICE_POOL_POSTGRESQL_PORT")
+	err = os.Unsetenv("JOB_SERVICE_POOL_POSTGRESQL_USERNAME")
+	err = os.Unsetenv("JOB_SERVICE_POOL_POSTGRESQL_PASSWORD")
+	err = os.Unsetenv("JOB_SERVICE_POOL_POSTGRESQL_DATABASE")
 	err = os.Unsetenv("JOBSERVICE_SECRET")
 	err = os.Unsetenv("CORE_SECRET")
 
@@ -16,6 +16,7 @@ package core
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/robfig/cron"
@@ -25,6 +26,7 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/errs"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/jobservice/logger/getter"
 	"github.com/goharbor/harbor/src/jobservice/mgt"
 	"github.com/goharbor/harbor/src/jobservice/worker"
 )
@@ -63,6 +65,13 @@ func (bc *basicController) LaunchJob(req *job.Request) (res *job.Stats, err erro
 		return nil, errs.BadRequestError(err)
 	}
 
+	// Validate the catch-up policy which may be not supported by the backend worker
+	if req.Job.Metadata.JobKind == job.KindPeriodic {
+		if err := bc.backendWorker.ValidateCatchUp(req.Job.Metadata.CatchUp); err != nil {
+			return nil, errs.BadRequestError(err)
+		}
+	}
+
 	// Enqueue job regarding of the kind
 	switch req.Job.Metadata.JobKind {
 	case job.KindScheduled:
@@ -78,10 +87,23 @@ func (bc *basicController) LaunchJob(req *job.Request) (res *job.Stats, err erro
 			req.Job.Name,
 			req.Job.Parameters,
 			req.Job.Metadata.Cron,
+			req.Job.Metadata.TimeZone,
+			req.Job.Metadata.CatchUp,
 			req.Job.Metadata.IsUnique,
 			req.Job.StatusHook,
 		)
 	default:
+		if len(req.Job.DependsOn) > 0 {
+			// The stats of the waiting job is saved by the worker before depending on the upstream jobs,
+			// saving it again here may overwrite the status of the job launched in the meantime
+			return bc.backendWorker.EnqueueAfter(
+				req.Job.Name,
+				req.Job.Parameters,
+				req.Job.DependsOn,
+				req.Job.StatusHook,
+			)
+		}
+
 		res, err = bc.backendWorker.Enqueue(
 			req.Job.Name,
 			req.Job.Parameters,
@@ -118,6 +140,42 @@ func (bc *basicController) StopJob(jobID string) error {
 	return bc.backendWorker.StopJob(jobID)
 }
 
+// PauseJob is implementation of same method in core interface.
+func (bc *basicController) PauseJob(jobID string) error {
+	if err := bc.checkPeriodicJob(jobID); err != nil {
+		return err
+	}
+
+	return bc.backendWorker.PauseJob(jobID)
+}
+
+// ResumeJob is implementation of same method in core interface.
+func (bc *basicController) ResumeJob(jobID string) error {
+	if err := bc.checkPeriodicJob(jobID); err != nil {
+		return err
+	}
+
+	return bc.backendWorker.ResumeJob(jobID)
+}
+
+// checkPeriodicJob checks if the job is an existing periodic job
+func (bc *basicController) checkPeriodicJob(jobID string) error {
+	if utils.IsEmptyStr(jobID) {
+		return errs.BadRequestError(errors.New("empty job ID"))
+	}
+
+	theJob, err := bc.manager.GetJob(jobID)
+	if err != nil {
+		return err
+	}
+
+	if theJob.Info.JobKind != job.KindPeriodic {
+		return errs.BadRequestError(errors.Errorf("job %s is not a periodic job but %s", jobID, theJob.Info.JobKind))
+	}
+
+	return nil
+}
+
 // RetryJob is implementation of same method in core interface.
 func (bc *basicController) RetryJob(jobID string) error {
 	if utils.IsEmptyStr(jobID) {
@@ -141,6 +199,32 @@ func (bc *basicController) GetJobLogData(jobID string) ([]byte, error) {
 	return logData, nil
 }
 
+// GetJobLogDataFrom is used to return the log text data after the offset for the specified job if exists
+func (bc *basicController) GetJobLogDataFrom(jobID string, offset int64) ([]byte, error) {
+	if utils.IsEmptyStr(jobID) {
+		return nil, errs.BadRequestError(errors.New("empty job ID"))
+	}
+
+	if offset < 0 {
+		return nil, errs.BadRequestError(errors.Errorf("bad log offset: %d", offset))
+	}
+
+	return logger.RetrieveFrom(jobID, offset)
+}
+
+// GetJobLogDataWithFilter is used to return the log text data matched with the filter for the specified job if exists
+func (bc *basicController) GetJobLogDataWithFilter(jobID string, filter *getter.Filter) ([]byte, error) {
+	if utils.IsEmptyStr(jobID) {
+		return nil, errs.BadRequestError(errors.New("empty job ID"))
+	}
+
+	if err := filter.Validate(); err != nil {
+		return nil, errs.BadRequestError(err)
+	}
+
+	return logger.RetrieveWithFilter(jobID, filter)
+}
+
 // CheckStatus is implementation of same method in core interface.
 func (bc *basicController) CheckStatus() (*worker.Stats, error) {
 	return bc.backendWorker.Stats()
@@ -173,6 +257,29 @@ func (bc *basicController) GetJobs(q *query.Parameter) ([]*job.Stats, int64, err
 	return bc.manager.GetJobs(q)
 }
 
+// PurgeJobs is implementation of same method in core interface.
+func (bc *basicController) PurgeJobs(q *query.Parameter) ([]string, error) {
+	if q == nil || q.Extras == nil {
+		return nil, errs.BadRequestError(errors.New("nil query parameters of purging jobs"))
+	}
+
+	if _, ok := q.Extras.Get(query.ExtraParamKeyEndTime); !ok {
+		return nil, errs.BadRequestError(errors.New("end time of purging jobs is required"))
+	}
+
+	purged, err := bc.manager.PurgeJobs(q)
+	// Sweep the logs of the jobs which have been purged even if error occurred
+	if len(purged) > 0 {
+		count, er := logger.SweepJobs(purged...)
+		if er != nil {
+			logger.Errorf("sweep logs of the purged jobs error: %s", er)
+		}
+		logger.Infof("%d jobs are purged with %d log entries swept", len(purged), count)
+	}
+
+	return purged, err
+}
+
 func validJobReq(req *job.Request) error {
 	if req == nil || req.Job == nil {
 		return errors.New("empty job request is not allowed")
@@ -210,6 +317,38 @@ func validJobReq(req *job.Request) error {
 		if _, err := cron.Parse(req.Job.Metadata.Cron); err != nil {
 			return fmt.Errorf("'cron_spec' is not correctly set: %s: %s", req.Job.Metadata.Cron, err)
 		}
+
+		if !utils.IsEmptyStr(req.Job.Metadata.TimeZone) {
+			if _, err := time.LoadLocation(req.Job.Metadata.TimeZone); err != nil {
+				return fmt.Errorf("'time_zone' is not correctly set: %s: %s", req.Job.Metadata.TimeZone, err)
+			}
+		}
+
+		if !job.IsValidCatchUp(req.Job.Metadata.CatchUp) {
+			return errors.Errorf("'catch_up' is not correctly set: %s, only support '%s','%s','%s'",
+				req.Job.Metadata.CatchUp,
+				job.CatchUpSkip,
+				job.CatchUpOnce,
+				job.CatchUpAll)
+		}
+	} else if !utils.IsEmptyStr(req.Job.Metadata.TimeZone) || !utils.IsEmptyStr(req.Job.Metadata.CatchUp) {
+		return errors.Errorf("'time_zone' and 'catch_up' are only supported by the %s job", job.KindPeriodic)
+	}
+
+	if len(req.Job.DependsOn) > 0 {
+		if req.Job.Metadata.JobKind != job.KindGeneric {
+			return errors.Errorf("'depends_on' is only supported by the %s job", job.KindGeneric)
+		}
+
+		if req.Job.Metadata.IsUnique {
+			return errors.New("'depends_on' is not supported by the unique job")
+		}
+
+		for _, upstreamJobID := range req.Job.DependsOn {
+			if utils.IsEmptyStr(upstreamJobID) {
+				return errors.New("empty upstream job ID in 'depends_on'")
+			}
+		}
 	}
 
 	return nil
@@ -14,8 +14,10 @@
 package core
 
 import (
+	"errors"
 	"github.com/goharbor/harbor/src/jobservice/common/query"
 	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/errs"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/sample"
 	"github.com/goharbor/harbor/src/jobservice/worker"
@@ -24,6 +26,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 // ControllerTestSuite tests functions of core controller
@@ -101,11 +104,58 @@ func (suite *ControllerTestSuite) TestLaunchScheduledJob() {
 func (suite *ControllerTestSuite) TestLaunchPeriodicJob() {
 	req := createJobReq("Periodic")
 
-	suite.worker.On("PeriodicallyEnqueue", job.SampleJob, suite.params, "5 * * * * *", true, req.Job.StatusHook).Return(suite.res, nil)
+	suite.worker.On("ValidateCatchUp", "").Return(nil)
+	suite.worker.On("PeriodicallyEnqueue", job.SampleJob, suite.params, "5 * * * * *", "", "", true, req.Job.StatusHook).Return(suite.res, nil)
 
 	res, err := suite.ctl.LaunchJob(req)
 	require.Nil(suite.T(), err, "launch periodic job: nil error expected but got %s", err)
 	assert.Equal(suite.T(), suite.jobID, res.Info.JobID, "mismatch job ID")
+
+	req = createJobReq("Periodic")
+	req.Job.Metadata.TimeZone = "Asia/Shanghai"
+	req.Job.Metadata.CatchUp = job.CatchUpOnce
+	suite.worker.On("ValidateCatchUp", job.CatchUpOnce).Return(nil)
+	suite.worker.On("PeriodicallyEnqueue", job.SampleJob, suite.params, "5 * * * * *", "Asia/Shanghai", job.CatchUpOnce, true, req.Job.StatusHook).Return(suite.res, nil)
+
+	_, err = suite.ctl.LaunchJob(req)
+	require.Nil(suite.T(), err, "launch periodic job with time zone: nil error expected but got %s", err)
+
+	req.Job.Metadata.TimeZone = "Nowhere/Fake"
+	_, err = suite.ctl.LaunchJob(req)
+	assert.NotNil(suite.T(), err, "launch periodic job with bad time zone: non nil error expected but got nil")
+
+	req.Job.Metadata.TimeZone = ""
+	req.Job.Metadata.CatchUp = "twice"
+	_, err = suite.ctl.LaunchJob(req)
+	assert.NotNil(suite.T(), err, "launch periodic job with bad catch-up: non nil error expected but got nil")
+
+	req.Job.Metadata.CatchUp = job.CatchUpAll
+	suite.worker.On("ValidateCatchUp", job.CatchUpAll).Return(errors.New("not supported"))
+	_, err = suite.ctl.LaunchJob(req)
+	require.NotNil(suite.T(), err, "launch periodic job with unsupported catch-up: non nil error expected but got nil")
+	assert.True(suite.T(), errs.IsBadRequestError(err), "expect bad request error but got %s", err)
+}
+
+// TestLaunchDependentJob ...
+func (suite *ControllerTestSuite) TestLaunchDependentJob() {
+	req := createJobReq("Generic")
+	req.Job.Metadata.IsUnique = false
+	req.Job.DependsOn = []string{"upstream-job"}
+
+	suite.worker.On("EnqueueAfter", job.SampleJob, suite.params, req.Job.DependsOn, req.Job.StatusHook).Return(suite.res, nil)
+
+	res, err := suite.ctl.LaunchJob(req)
+	require.Nil(suite.T(), err, "launch dependent job: nil error expected but got %s", err)
+	assert.Equal(suite.T(), suite.jobID, res.Info.JobID, "mismatch job ID")
+
+	req.Job.Metadata.IsUnique = true
+	_, err = suite.ctl.LaunchJob(req)
+	assert.NotNil(suite.T(), err, "unique dependent job: error expected but got nil")
+
+	req.Job.Metadata.IsUnique = false
+	req.Job.Metadata.JobKind = job.KindScheduled
+	_, err = suite.ctl.LaunchJob(req)
+	assert.NotNil(suite.T(), err, "scheduled dependent job: error expected but got nil")
 }
 
 // TestGetJobStats ...
@@ -126,6 +176,28 @@ func (suite *ControllerTestSuite) TestJobActions() {
 	assert.Nil(suite.T(), err, "job action: nil error expected but got %s", err)
 }
 
+// TestPauseAndResumeJob ...
+func (suite *ControllerTestSuite) TestPauseAndResumeJob() {
+	err := suite.ctl.PauseJob(suite.jobID)
+	require.NotNil(suite.T(), err, "pause non periodic job: non nil error expected but got nil")
+	assert.True(suite.T(), errs.IsBadRequestError(err), "pause non periodic job: bad request error expected")
+
+	periodicJobID := utils.MakeIdentifier()
+	suite.manager.On("GetJob", periodicJobID).Return(&job.Stats{
+		Info: &job.StatsInfo{
+			JobID:   periodicJobID,
+			JobKind: job.KindPeriodic,
+		},
+	}, nil)
+	suite.worker.On("PauseJob", periodicJobID).Return(nil)
+	suite.worker.On("ResumeJob", periodicJobID).Return(nil)
+
+	err = suite.ctl.PauseJob(periodicJobID)
+	require.Nil(suite.T(), err, "pause periodic job: nil error expected but got %s", err)
+	err = suite.ctl.ResumeJob(periodicJobID)
+	require.Nil(suite.T(), err, "resume periodic job: nil error expected but got %s", err)
+}
+
 // TestCheckStatus ...
 func (suite *ControllerTestSuite) TestCheckStatus() {
 	suite.worker.On("Stats").Return(&worker.Stats{
@@ -187,6 +259,25 @@ func (suite *ControllerTestSuite) TestGetScheduledJobs() {
 	assert.Equal(suite.T(), int64(1), total, "expected 1 item but got 0")
 }
 
+// TestPurgeJobs tests PurgeJobs
+func (suite *ControllerTestSuite) TestPurgeJobs() {
+	q := &query.Parameter{
+		Extras: make(query.ExtraParameters),
+	}
+
+	_, err := suite.ctl.PurgeJobs(q)
+	assert.True(suite.T(), errs.IsBadRequestError(err), "purge jobs without end time: bad request error expected")
+
+	q.Extras.Set(query.ExtraParamKeyEndTime, time.Now().Unix())
+	fakeMgr := &fakeManager{}
+	fakeMgr.On("PurgeJobs", q).Return([]string{suite.jobID}, nil)
+	suite.manager = fakeMgr
+
+	purged, err := suite.ctl.PurgeJobs(q)
+	require.Nil(suite.T(), err, "purge jobs: nil error expected but got %s", err)
+	assert.Equal(suite.T(), []string{suite.jobID}, purged)
+}
+
 // TestGetPeriodicExecutions tests GetPeriodicExecutions
 func (suite *ControllerTestSuite) TestGetPeriodicExecutions() {
 	q := &query.Parameter{
@@ -237,12 +328,16 @@ func (suite *ControllerTestSuite) Enqueue(jobName string, params job.Parameters,
 	return suite.worker.Enqueue(jobName, params, isUnique, webHook)
 }
 
+func (suite *ControllerTestSuite) EnqueueAfter(jobName string, params job.Parameters, upstreamJobIDs []string, webHook string) (*job.Stats, error) {
+	return suite.worker.EnqueueAfter(jobName, params, upstreamJobIDs, webHook)
+}
+
 func (suite *ControllerTestSuite) Schedule(jobName string, params job.Parameters, runAfterSeconds uint64, isUnique bool, webHook string) (*job.Stats, error) {
 	return suite.worker.Schedule(jobName, params, runAfterSeconds, isUnique, webHook)
 }
 
-func (suite *ControllerTestSuite) PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, isUnique bool, webHook string) (*job.Stats, error) {
-	return suite.worker.PeriodicallyEnqueue(jobName, params, cronSetting, isUnique, webHook)
+func (suite *ControllerTestSuite) PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, timeZone string, catchUp string, isUnique bool, webHook string) (*job.Stats, error) {
+	return suite.worker.PeriodicallyEnqueue(jobName, params, cronSetting, timeZone, catchUp, isUnique, webHook)
 }
 
 func (suite *ControllerTestSuite) Stats() (*worker.Stats, error) {
@@ -257,10 +352,22 @@ func (suite *ControllerTestSuite) ValidateJobParameters(jobType interface{}, par
 	return suite.worker.ValidateJobParameters(jobType, params)
 }
 
+func (suite *ControllerTestSuite) ValidateCatchUp(catchUp string) error {
+	return suite.worker.ValidateCatchUp(catchUp)
+}
+
 func (suite *ControllerTestSuite) StopJob(jobID string) error {
 	return suite.worker.StopJob(jobID)
 }
 
+func (suite *ControllerTestSuite) PauseJob(jobID string) error {
+	return suite.worker.PauseJob(jobID)
+}
+
+func (suite *ControllerTestSuite) ResumeJob(jobID string) error {
+	return suite.worker.ResumeJob(jobID)
+}
+
 func (suite *ControllerTestSuite) RetryJob(jobID string) error {
 	return suite.worker.RetryJob(jobID)
 }
@@ -286,6 +393,10 @@ func (suite *ControllerTestSuite) SaveJob(j *job.Stats) error {
 	return suite.manager.SaveJob(j)
 }
 
+func (suite *ControllerTestSuite) PurgeJobs(q *query.Parameter) ([]string, error) {
+	return suite.manager.PurgeJobs(q)
+}
+
 // fake worker
 type fakeWorker struct {
 	mock.Mock
@@ -308,6 +419,15 @@ func (f *fakeWorker) Enqueue(jobName string, params job.Parameters, isUnique boo
 	return args.Get(0).(*job.Stats), nil
 }
 
+func (f *fakeWorker) EnqueueAfter(jobName string, params job.Parameters, upstreamJobIDs []string, webHook string) (*job.Stats, error) {
+	args := f.Called(jobName, params, upstreamJobIDs, webHook)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*job.Stats), nil
+}
+
 func (f *fakeWorker) Schedule(jobName string, params job.Parameters, runAfterSeconds uint64, isUnique bool, webHook string) (*job.Stats, error) {
 	args := f.Called(jobName, params, runAfterSeconds, isUnique, webHook)
 	if args.Error(1) != nil {
@@ -317,8 +437,8 @@ func (f *fakeWorker) Schedule(jobName string, params job.Parameters, runAfterSec
 	return args.Get(0).(*job.Stats), nil
 }
 
-func (f *fakeWorker) PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, isUnique bool, webHook string) (*job.Stats, error) {
-	args := f.Called(jobName, params, cronSetting, isUnique, webHook)
+func (f *fakeWorker) PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, timeZone string, catchUp string, isUnique bool, webHook string) (*job.Stats, error) {
+	args := f.Called(jobName, params, cronSetting, timeZone, catchUp, isUnique, webHook)
 	if args.Error(1) != nil {
 		return nil, args.Error(1)
 	}
@@ -348,10 +468,22 @@ func (f *fakeWorker) ValidateJobParameters(jobType interface{}, params job.Param
 	return f.Called(jobType, params).Error(0)
 }
 
+func (f *fakeWorker) ValidateCatchUp(catchUp string) error {
+	return f.Called(catchUp).Error(0)
+}
+
 func (f *fakeWorker) StopJob(jobID string) error {
 	return f.Called(jobID).Error(0)
 }
 
+func (f *fakeWorker) PauseJob(jobID string) error {
+	return f.Called(jobID).Error(0)
+}
+
+func (f *fakeWorker) ResumeJob(jobID string) error {
+	return f.Called(jobID).Error(0)
+}
+
 func (f *fakeWorker) RetryJob(jobID string) error {
 	return f.Called(jobID).Error(0)
 }
@@ -401,3 +533,12 @@ func (fm *fakeManager) SaveJob(j *job.Stats) error {
 	args := fm.Called(j)
 	return args.Error(0)
 }
+
+func (fm *fakeManager) PurgeJobs(q *query.Parameter) ([]string, error) {
+	args := fm.Called(q)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]string), nil
+}
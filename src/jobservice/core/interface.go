@@ -18,6 +18,7 @@ package core
 import (
 	"github.com/goharbor/harbor/src/jobservice/common/query"
 	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger/getter"
 	"github.com/goharbor/harbor/src/jobservice/worker"
 )
 
@@ -49,6 +50,22 @@ type Interface interface {
 	//  error   : Error returned if failed to stop the specified job.
 	StopJob(jobID string) error
 
+	// PauseJob is used to handle the periodic job pausing request.
+	//
+	// jobID	string: ID of the periodic job.
+	//
+	// Return:
+	//  error   : Error returned if failed to pause the specified job.
+	PauseJob(jobID string) error
+
+	// ResumeJob is used to handle the periodic job resuming request.
+	//
+	// jobID	string: ID of the periodic job.
+	//
+	// Return:
+	//  error   : Error returned if failed to resume the specified job.
+	ResumeJob(jobID string) error
+
 	// RetryJob is used to handle the job retrying request.
 	//
 	// jobID	string        : ID of job.
@@ -63,6 +80,13 @@ type Interface interface {
 	// GetJobLogData is used to return the log text data for the specified job if exists
 	GetJobLogData(jobID string) ([]byte, error)
 
+	// GetJobLogDataFrom is used to return the log text data after the offset for the specified job if exists.
+	// It's used to follow the log of the running job.
+	GetJobLogDataFrom(jobID string, offset int64) ([]byte, error)
+
+	// GetJobLogDataWithFilter is used to return the log text data matched with the filter for the specified job if exists.
+	GetJobLogDataWithFilter(jobID string, filter *getter.Filter) ([]byte, error)
+
 	// Get the periodic executions for the specified periodic job.
 	// Pagination by query is supported.
 	// The total number is also returned.
@@ -75,4 +99,9 @@ type Interface interface {
 	// For other cases, query the jobs with cursor, not standard pagination. The int64 is next cursor.
 	// The total number is also returned.
 	GetJobs(query *query.Parameter) ([]*job.Stats, int64, error)
+
+	// PurgeJobs is used to purge the stats and logs of the finished jobs matched with the query.
+	// The end of the enqueue time range is required to avoid purging the recent jobs unexpectedly.
+	// The IDs of the purged jobs are returned.
+	PurgeJobs(query *query.Parameter) ([]string, error)
 }
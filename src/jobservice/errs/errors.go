@@ -56,6 +56,12 @@ const (
 	GetPeriodicExecutionErrorCode
 	// StatusMismatchErrorCode is code for the error of mismatching status
 	StatusMismatchErrorCode
+	// PauseJobErrorCode is code for the error of pausing job
+	PauseJobErrorCode
+	// ResumeJobErrorCode is code for the error of resuming job
+	ResumeJobErrorCode
+	// PurgeJobsErrorCode is code for the error of purging jobs
+	PurgeJobsErrorCode
 )
 
 // baseError ...
@@ -123,6 +129,21 @@ func RetryJobError(err error) error {
 	return New(RetryJobErrorCode, "retry job failed with error", err.Error())
 }
 
+// PauseJobError is error for the case of pausing job failed
+func PauseJobError(err error) error {
+	return New(PauseJobErrorCode, "pause job failed with error", err.Error())
+}
+
+// ResumeJobError is error for the case of resuming job failed
+func ResumeJobError(err error) error {
+	return New(ResumeJobErrorCode, "resume job failed with error", err.Error())
+}
+
+// PurgeJobsError is error for the case of purging jobs failed
+func PurgeJobsError(err error) error {
+	return New(PurgeJobsErrorCode, "purge jobs failed with error", err.Error())
+}
+
 // UnknownActionNameError is error for the case of getting unknown job action
 func UnknownActionNameError(err error) error {
 	return New(UnknownActionNameErrorCode, "unknown job action name", err.Error())
@@ -0,0 +1,35 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+const (
+	// CatchUpSkip : Skip the executions of the periodic job missed while the jobservice is down
+	CatchUpSkip = "skip"
+	// CatchUpOnce : Run the periodic job only once for all the missed executions
+	CatchUpOnce = "once"
+	// CatchUpAll : Run all the missed executions of the periodic job
+	CatchUpAll = "all"
+)
+
+// IsValidCatchUp checks if the catch-up policy is supported.
+// The empty one is treated as CatchUpSkip.
+func IsValidCatchUp(catchUp string) bool {
+	switch catchUp {
+	case "", CatchUpSkip, CatchUpOnce, CatchUpAll:
+		return true
+	default:
+		return false
+	}
+}
@@ -56,6 +56,16 @@ type Context interface {
 	//  error if meet any problems
 	Checkin(status string) error
 
+	// SetProgress reports the structured progress of the job
+	//
+	// phase string  : name of the current phase
+	// current int64 : the amount of the work done in the phase
+	// total int64   : the total amount of the work in the phase, 0 if it's unknown
+	//
+	// Returns:
+	//  error if meet any problems
+	SetProgress(phase string, current, total int64) error
+
 	// OPCommand return the control operational command like stop if have
 	//
 	// Returns:
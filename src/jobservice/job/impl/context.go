@@ -148,6 +148,11 @@ func (c *Context) Checkin(status string) error {
 	return c.tracker.CheckIn(status)
 }
 
+// SetProgress is bridge func for reporting the structured progress
+func (c *Context) SetProgress(phase string, current, total int64) error {
+	return c.tracker.UpdateProgress(phase, current, total)
+}
+
 // OPCommand return the control operational command like stop/cancel if have
 func (c *Context) OPCommand() (job.OPCommand, bool) {
 	latest, err := c.tracker.Status()
@@ -159,6 +164,11 @@ func (c *Context) OPCommand() (job.OPCommand, bool) {
 		return job.StopCommand, true
 	}
 
+	// The running job is marked as failed by the zombie reaper, ask it to stop
+	if job.ErrorStatus == latest {
+		return job.StopCommand, true
+	}
+
 	return job.NilCommand, false
 }
 
@@ -194,6 +204,8 @@ func createLoggers(jobID string) (logger.Interface, error) {
 			if lc.Name == logger.NameFile {
 				// Append file name param
 				fSettings["filename"] = fmt.Sprintf("%s.log", jobID)
+				// Append job ID param for the JSON format
+				fSettings["job_id"] = jobID
 				lOptions = append(lOptions, logger.BackendOption(lc.Name, lc.Level, fSettings))
 			} else { // DB Logger
 				// Append DB key
@@ -123,4 +123,11 @@ func (suite *ContextImplTestSuite) TestContextImpl() {
 
 	_, ok = jCtx.OPCommand()
 	assert.Equal(suite.T(), false, ok)
+
+	// The job reaped as a zombie is asked to stop
+	err = suite.tracker.Fail()
+	require.NoError(suite.T(), err)
+	cmd, ok := jCtx.OPCommand()
+	assert.Equal(suite.T(), true, ok)
+	assert.Equal(suite.T(), job.StopCommand, cmd)
 }
@@ -88,6 +88,11 @@ func (dc *DefaultContext) Checkin(status string) error {
 	return dc.tracker.CheckIn(status)
 }
 
+// SetProgress is bridge func for reporting the structured progress
+func (dc *DefaultContext) SetProgress(phase string, current, total int64) error {
+	return dc.tracker.UpdateProgress(phase, current, total)
+}
+
 // OPCommand return the control operational command like stop if have
 func (dc *DefaultContext) OPCommand() (job.OPCommand, bool) {
 	latest, err := dc.tracker.Status()
@@ -99,6 +104,11 @@ func (dc *DefaultContext) OPCommand() (job.OPCommand, bool) {
 		return job.StopCommand, true
 	}
 
+	// The running job is marked as failed by the zombie reaper, ask it to stop
+	if job.ErrorStatus == latest {
+		return job.StopCommand, true
+	}
+
 	return job.NilCommand, false
 }
 
@@ -0,0 +1,310 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+)
+
+const (
+	// ParamKeyRunner is the parameter key of the name of the configured runner.
+	// The other parameters are passed to the runner.
+	ParamKeyRunner = "runner"
+
+	// The max size of one output line of the runner
+	maxOutputLineSize = 1024 * 1024
+)
+
+// The interval of checking the stop command of the running job
+var opCheckInterval = 5 * time.Second
+
+// Payload is the data passed to the runner.
+// It's written to the stdin of the executable, or sent as the body of the HTTP request.
+type Payload struct {
+	JobID      string         `json:"job_id"`
+	Parameters job.Parameters `json:"parameters"`
+}
+
+// Job runs an external executable or calls an HTTP endpoint with the job parameters.
+// The runners are configured with the 'external_jobs' of the job service configuration.
+// The output of the runner is written to the job logger line by line. The job fails if
+// the executable exits with non zero status or the endpoint responds with non 2xx status code.
+type Job struct{}
+
+// MaxFails is implementation of same method in Interface.
+func (j *Job) MaxFails() uint {
+	return 1
+}
+
+// ShouldRetry is implementation of same method in Interface.
+// The external job is not retried as it's not known whether it can be run repeatedly.
+func (j *Job) ShouldRetry() bool {
+	return false
+}
+
+// MaxRuntime is implementation of same method in Interface.
+// The timeout of each runner is applied when running it, the job itself is limited by the default max runtime.
+func (j *Job) MaxRuntime() time.Duration {
+	return 0
+}
+
+// Validate is implementation of same method in Interface.
+func (j *Job) Validate(params job.Parameters) error {
+	_, err := getRunner(params)
+	return err
+}
+
+// Run is implementation of same method in Interface.
+func (j *Job) Run(ctx job.Context, params job.Parameters) error {
+	runner, err := getRunner(params)
+	if err != nil {
+		return err
+	}
+
+	log := ctx.GetLogger()
+
+	jobID := ""
+	if t := ctx.Tracker(); t != nil && t.Job() != nil {
+		jobID = t.Job().Info.JobID
+	}
+
+	// Pass the parameters except the runner name
+	jobParams := make(job.Parameters)
+	for k, v := range params {
+		if k != ParamKeyRunner {
+			jobParams[k] = v
+		}
+	}
+
+	payload, err := json.Marshal(&Payload{
+		JobID:      jobID,
+		Parameters: jobParams,
+	})
+	if err != nil {
+		return err
+	}
+
+	parent := ctx.SystemContext()
+	if parent == nil {
+		parent = context.Background()
+	}
+	var (
+		runCtx context.Context
+		cancel context.CancelFunc
+	)
+	if runner.Timeout > 0 {
+		runCtx, cancel = context.WithTimeout(parent, time.Duration(runner.Timeout)*time.Second)
+	} else {
+		runCtx, cancel = context.WithCancel(parent)
+	}
+	defer cancel()
+
+	// Cancel the runner if the job is stopped
+	stopped := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(opCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				if cmd, ok := ctx.OPCommand(); ok && cmd == job.StopCommand {
+					close(stopped)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	log.Infof("Run external job with %s runner %s", runner.Type, runner.Name)
+
+	switch runner.Type {
+	case config.ExternalJobTypeExec:
+		err = runExec(runCtx, runner, jobID, payload, log)
+	case config.ExternalJobTypeHTTP:
+		err = runHTTP(runCtx, runner, payload, log)
+	default:
+		err = errors.Errorf("unknown type of external job runner %s: %s", runner.Name, runner.Type)
+	}
+
+	select {
+	case <-stopped:
+		log.Info("External job is stopped")
+		return nil
+	default:
+	}
+
+	if runCtx.Err() == context.DeadlineExceeded {
+		return errors.Errorf("external job runner %s timeout after %d seconds", runner.Name, runner.Timeout)
+	}
+
+	return err
+}
+
+// getRunner returns the configured runner specified by the parameters
+func getRunner(params job.Parameters) (*config.ExternalJobConfig, error) {
+	v, ok := params[ParamKeyRunner]
+	if !ok {
+		return nil, errors.Errorf("missing parameter '%s'", ParamKeyRunner)
+	}
+
+	name, ok := v.(string)
+	if !ok || len(name) == 0 {
+		return nil, errors.Errorf("invalid parameter '%s': %v", ParamKeyRunner, v)
+	}
+
+	runner := config.DefaultConfig.ExternalJob(name)
+	if runner == nil {
+		return nil, errors.Errorf("external job runner %s is not configured", name)
+	}
+
+	return runner, nil
+}
+
+// runExec runs the executable with the payload as stdin
+func runExec(ctx context.Context, runner *config.ExternalJobConfig, jobID string, payload []byte, log logger.Interface) error {
+	cmd := exec.Command(runner.Command[0], runner.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(payload)
+	// Run in a new process group to kill the processes forked by the runner too when the job is
+	// stopped or timeout, otherwise they keep the output pipes open and the reading never ends
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	// Not inherit the environment of the job service to avoid leaking the secrets
+	cmd.Env = []string{
+		fmt.Sprintf("PATH=%s", os.Getenv("PATH")),
+		fmt.Sprintf("HARBOR_JOB_ID=%s", jobID),
+	}
+	for k, v := range runner.Env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return errors.Wrapf(err, "start external job runner %s", runner.Name)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			// The negative pid means all the processes in the group
+			if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL); err != nil {
+				log.Errorf("Kill external job runner %s error: %s", runner.Name, err)
+			}
+		case <-done:
+		}
+	}()
+
+	// All the output must be read before waiting the command
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		writeLines(stdout, log.Info, log)
+	}()
+	go func() {
+		defer wg.Done()
+		writeLines(stderr, log.Warning, log)
+	}()
+	wg.Wait()
+
+	if err := cmd.Wait(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return errors.Errorf("external job runner %s exited with status %d", runner.Name, exitErr.ExitCode())
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// runHTTP posts the payload to the endpoint
+func runHTTP(ctx context.Context, runner *config.ExternalJobConfig, payload []byte, log logger.Interface) error {
+	req, err := http.NewRequest(http.MethodPost, runner.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	if len(runner.AuthHeader) > 0 {
+		req.Header.Set("Authorization", runner.AuthHeader)
+	}
+
+	client := &http.Client{
+		Transport: commonhttp.GetHTTPTransport(runner.SkipCertVerify),
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "call external job runner %s", runner.Name)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// The endpoint can stream its output with the response body
+	writeLines(resp.Body, log.Info, log)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("external job runner %s responded with status code %d", runner.Name, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// writeLines writes the output of the runner to the job logger line by line
+func writeLines(r io.Reader, write func(v ...interface{}), log logger.Interface) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxOutputLineSize)
+	for scanner.Scan() {
+		write(scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Errorf("read output of external job runner error: %s", err)
+		// Drain the left output to not block the runner
+		_, _ = io.Copy(ioutil.Discard, r)
+	}
+}
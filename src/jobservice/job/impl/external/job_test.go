@@ -0,0 +1,209 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/jobservice/logger/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// ExternalJobTestSuite tests the external job
+type ExternalJobTestSuite struct {
+	suite.Suite
+
+	server *httptest.Server
+}
+
+// TestExternalJobTestSuite is entry of go test
+func TestExternalJobTestSuite(t *testing.T) {
+	suite.Run(t, new(ExternalJobTestSuite))
+}
+
+// SetupSuite prepares the test suite
+func (suite *ExternalJobTestSuite) SetupSuite() {
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Header.Get("Authorization") != "Basic abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+		_, _ = w.Write(body)
+	}))
+
+	config.DefaultConfig.ExternalJobs = []*config.ExternalJobConfig{
+		{Name: "cat", Type: config.ExternalJobTypeExec, Command: []string{"sh", "-c", "cat; echo; echo $RUNNER_ENV"}, Env: map[string]string{"RUNNER_ENV": "env_value"}},
+		{Name: "fail", Type: config.ExternalJobTypeExec, Command: []string{"sh", "-c", "echo failed >&2; exit 3"}},
+		{Name: "sleep", Type: config.ExternalJobTypeExec, Command: []string{"sleep", "10"}, Timeout: 1},
+		{Name: "fork", Type: config.ExternalJobTypeExec, Command: []string{"sh", "-c", "sleep 10 & wait"}, Timeout: 1},
+		{Name: "http", Type: config.ExternalJobTypeHTTP, URL: suite.server.URL, AuthHeader: "Basic abc"},
+		{Name: "http_unauthorized", Type: config.ExternalJobTypeHTTP, URL: suite.server.URL},
+	}
+	opCheckInterval = 10 * time.Millisecond
+}
+
+// TearDownSuite clears the test suite
+func (suite *ExternalJobTestSuite) TearDownSuite() {
+	suite.server.Close()
+	config.DefaultConfig.ExternalJobs = nil
+}
+
+// TestValidate tests Validate
+func (suite *ExternalJobTestSuite) TestValidate() {
+	j := &Job{}
+	assert.Error(suite.T(), j.Validate(job.Parameters{}), "missing runner: error expected")
+	assert.Error(suite.T(), j.Validate(job.Parameters{ParamKeyRunner: "none"}), "unknown runner: error expected")
+	assert.NoError(suite.T(), j.Validate(job.Parameters{ParamKeyRunner: "cat"}))
+}
+
+// TestRunExec tests running the executable
+func (suite *ExternalJobTestSuite) TestRunExec() {
+	j := &Job{}
+
+	ctx := newFakeContext()
+	err := j.Run(ctx, job.Parameters{ParamKeyRunner: "cat", "days": 7})
+	require.NoError(suite.T(), err)
+	assert.Contains(suite.T(), ctx.lines(), `"parameters":{"days":7}`)
+	assert.Contains(suite.T(), ctx.lines(), "env_value")
+
+	ctx = newFakeContext()
+	err = j.Run(ctx, job.Parameters{ParamKeyRunner: "fail"})
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "exited with status 3")
+	assert.Contains(suite.T(), ctx.lines(), "failed")
+
+	err = j.Run(newFakeContext(), job.Parameters{ParamKeyRunner: "sleep"})
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "timeout")
+
+	// The forked process holding the output is killed too
+	start := time.Now()
+	err = j.Run(newFakeContext(), job.Parameters{ParamKeyRunner: "fork"})
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "timeout")
+	assert.True(suite.T(), time.Since(start) < 5*time.Second, "expect the forked process is killed at timeout")
+}
+
+// TestRunHTTP tests calling the HTTP endpoint
+func (suite *ExternalJobTestSuite) TestRunHTTP() {
+	j := &Job{}
+
+	ctx := newFakeContext()
+	err := j.Run(ctx, job.Parameters{ParamKeyRunner: "http", "days": 7})
+	require.NoError(suite.T(), err)
+	assert.Contains(suite.T(), ctx.lines(), `"parameters":{"days":7}`)
+
+	err = j.Run(newFakeContext(), job.Parameters{ParamKeyRunner: "http_unauthorized"})
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "401")
+}
+
+// TestStop tests stopping the running external job
+func (suite *ExternalJobTestSuite) TestStop() {
+	ctx := newFakeContext()
+	ctx.stopped = true
+
+	start := time.Now()
+	err := (&Job{}).Run(ctx, job.Parameters{ParamKeyRunner: "sleep"})
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), time.Since(start) < time.Second, "expect the runner is stopped immediately")
+}
+
+// fakeContext is a job context recording the logged lines
+type fakeContext struct {
+	*backend.StdOutputLogger
+
+	lock    *sync.Mutex
+	logged  []string
+	stopped bool
+}
+
+func newFakeContext() *fakeContext {
+	return &fakeContext{
+		StdOutputLogger: backend.NewStdOutputLogger("DEBUG", backend.StdOut, 4),
+		lock:            new(sync.Mutex),
+	}
+}
+
+func (fc *fakeContext) lines() string {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+
+	return strings.Join(fc.logged, "\n")
+}
+
+func (fc *fakeContext) Info(v ...interface{}) {
+	fc.record(v...)
+}
+
+func (fc *fakeContext) Warning(v ...interface{}) {
+	fc.record(v...)
+}
+
+func (fc *fakeContext) record(v ...interface{}) {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+
+	fc.logged = append(fc.logged, fmt.Sprint(v...))
+}
+
+func (fc *fakeContext) Build(tracker job.Tracker) (job.Context, error) {
+	return fc, nil
+}
+
+func (fc *fakeContext) Get(prop string) (interface{}, bool) {
+	return nil, false
+}
+
+func (fc *fakeContext) SystemContext() context.Context {
+	return context.TODO()
+}
+
+func (fc *fakeContext) Checkin(status string) error {
+	return nil
+}
+
+func (fc *fakeContext) SetProgress(phase string, current, total int64) error {
+	return nil
+}
+
+func (fc *fakeContext) OPCommand() (job.OPCommand, bool) {
+	if fc.stopped {
+		return job.StopCommand, true
+	}
+
+	return job.NilCommand, false
+}
+
+func (fc *fakeContext) GetLogger() logger.Interface {
+	return fc
+}
+
+func (fc *fakeContext) Tracker() job.Tracker {
+	return nil
+}
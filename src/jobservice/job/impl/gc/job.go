@@ -39,6 +39,10 @@ const (
 	blobPrefix            = "blobs::*"
 	repoPrefix            = "repository::*"
 	uploadSizePattern     = "upload:*:size"
+
+	// The phase name of the progress and the steps of gc job
+	gcPhase      = "garbage collection"
+	gcTotalSteps = 3
 )
 
 // GarbageCollector is the struct to run registry's garbage collection
@@ -60,6 +64,11 @@ func (gc *GarbageCollector) ShouldRetry() bool {
 	return false
 }
 
+// MaxRuntime implements the interface in job/Interface
+func (gc *GarbageCollector) MaxRuntime() time.Duration {
+	return 0
+}
+
 // Validate implements the interface in job/Interface
 func (gc *GarbageCollector) Validate(params job.Parameters) error {
 	return nil
@@ -85,22 +94,33 @@ func (gc *GarbageCollector) Run(ctx job.Context, params job.Parameters) error {
 		return err
 	}
 	gc.logger.Infof("start to run gc in job.")
+	gc.reportProgress(ctx, 0)
 	gcr, err := gc.registryCtlClient.StartGC()
 	if err != nil {
 		gc.logger.Errorf("failed to get gc result: %v", err)
 		return err
 	}
+	gc.reportProgress(ctx, 1)
 	if err := gc.cleanCache(); err != nil {
 		return err
 	}
+	gc.reportProgress(ctx, 2)
 	if err := gc.ensureQuota(); err != nil {
 		gc.logger.Warningf("failed to align quota data in gc job, with error: %v", err)
 	}
+	gc.reportProgress(ctx, gcTotalSteps)
 	gc.logger.Infof("GC results: status: %t, message: %s, start: %s, end: %s.", gcr.Status, gcr.Msg, gcr.StartTime, gcr.EndTime)
 	gc.logger.Infof("success to run gc in job.")
 	return nil
 }
 
+// reportProgress reports the done steps of gc job, failure only logged
+func (gc *GarbageCollector) reportProgress(ctx job.Context, doneSteps int64) {
+	if err := ctx.SetProgress(gcPhase, doneSteps, gcTotalSteps); err != nil {
+		gc.logger.Warningf("failed to report progress of gc job: %v", err)
+	}
+}
+
 func (gc *GarbageCollector) init(ctx job.Context, params job.Parameters) error {
 	registryctl.Init()
 	gc.registryCtlClient = registryctl.RegistryCtlClient
@@ -216,7 +236,7 @@ func (gc *GarbageCollector) ensureQuota() error {
 			gc.logger.Errorf("Error occurred when to new quota manager %v, just skip it.", err)
 			continue
 		}
-		if err := quotaMgr.SetResourceUsage(types.ResourceStorage, pSize); err != nil {
+		if err := quotaMgr.WithOperator(common_quota.OperatorGC).SetResourceUsage(types.ResourceStorage, pSize); err != nil {
 			gc.logger.Errorf("cannot ensure quota for the project: %d, err: %v, just skip it.", project.ProjectID, err)
 			continue
 		}
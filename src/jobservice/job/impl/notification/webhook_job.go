@@ -6,14 +6,21 @@ import (
 	commonhttp "github.com/goharbor/harbor/src/common/http"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/pkg/notification/policy"
+	"github.com/goharbor/harbor/src/pkg/notification/policy/manager"
+	"github.com/goharbor/harbor/src/pkg/notification/signature"
 	"net/http"
 	"os"
 	"strconv"
+	"time"
 )
 
 // Max retry has the same meaning as max fails.
 const maxFails = "JOBSERVICE_WEBHOOK_JOB_MAX_RETRY"
 
+// policyMgr loads the secrets of the targets, which are not passed by the job parameters
+var policyMgr policy.Manager = manager.NewDefaultManger()
+
 // WebhookJob implements the job interface, which send notification by http or https.
 type WebhookJob struct {
 	client *http.Client
@@ -41,6 +48,11 @@ func (wj *WebhookJob) ShouldRetry() bool {
 	return true
 }
 
+// MaxRuntime of the webhook job, a hung notification endpoint should not block the worker
+func (wj *WebhookJob) MaxRuntime() time.Duration {
+	return 10 * time.Minute
+}
+
 // Validate implements the interface in job/Interface
 func (wj *WebhookJob) Validate(params job.Parameters) error {
 	return nil
@@ -84,7 +96,17 @@ func (wj *WebhookJob) execute(ctx job.Context, params map[string]interface{}) er
 	if v, ok := params["auth_header"]; ok && len(v.(string)) > 0 {
 		req.Header.Set("Authorization", v.(string))
 	}
-	req.Header.Set("Content-Type", "application/json")
+	contentType := "application/json"
+	if v, ok := params["content_type"]; ok && len(v.(string)) > 0 {
+		contentType = v.(string)
+	}
+	req.Header.Set("Content-Type", contentType)
+	// sign the delivery on each attempt, so the timestamp of a retry is still fresh
+	secret, err := targetSecret(params, address)
+	if err != nil {
+		return err
+	}
+	signature.SignRequest(req, secret, []byte(payload))
 
 	resp, err := wj.client.Do(req)
 	if err != nil {
@@ -97,3 +119,37 @@ func (wj *WebhookJob) execute(ctx job.Context, params map[string]interface{}) er
 
 	return nil
 }
+
+// targetSecret loads the secret of the target with the address from the policy
+func targetSecret(params map[string]interface{}, address string) (string, error) {
+	v, ok := params["policy_id"]
+	if !ok {
+		return "", nil
+	}
+
+	var policyID int64
+	switch id := v.(type) {
+	case float64: // the numbers are decoded as float64 from the job stats
+		policyID = int64(id)
+	case int64:
+		policyID = id
+	default:
+		return "", fmt.Errorf("invalid notification policy ID: %v", v)
+	}
+
+	p, err := policyMgr.Get(policyID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get notification policy %d: %v", policyID, err)
+	}
+	if p == nil {
+		return "", fmt.Errorf("notification policy %d not found", policyID)
+	}
+
+	for _, target := range p.Targets {
+		if target.Address == address {
+			return target.Secret, nil
+		}
+	}
+
+	return "", nil
+}
@@ -1,13 +1,17 @@
 package notification
 
 import (
+	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/jobservice/job/impl"
+	"github.com/goharbor/harbor/src/pkg/notification/policy"
+	"github.com/goharbor/harbor/src/pkg/notification/signature"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestMaxFails(t *testing.T) {
@@ -46,6 +50,7 @@ func TestRun(t *testing.T) {
 			assert.Equal(t, http.MethodPost, r.Method)
 			// test request header
 			assert.Equal(t, "auth_test", r.Header.Get("Authorization"))
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
 			// test request body
 			assert.Equal(t, string(body), `{"key": "value"}`)
 		}))
@@ -73,3 +78,68 @@ func TestRun(t *testing.T) {
 	// test incorrect webhook response
 	assert.NotNil(t, rep.Run(&impl.Context{}, paramsWrong))
 }
+
+func TestRunWithContentType(t *testing.T) {
+	rep := &WebhookJob{}
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+			assert.Empty(t, r.Header.Get(signature.HeaderSignature))
+			assert.Equal(t, "Image pushed", string(body))
+		}))
+	defer ts.Close()
+	params := map[string]interface{}{
+		"skip_cert_verify": true,
+		"payload":          "Image pushed",
+		"content_type":     "text/plain",
+		"address":          ts.URL,
+	}
+	assert.Nil(t, rep.Run(&impl.Context{}, params))
+}
+
+func TestRunWithSecret(t *testing.T) {
+	rep := &WebhookJob{}
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Nil(t, signature.Verify("secret", r.Header.Get(signature.HeaderTimestamp),
+				r.Header.Get(signature.HeaderSignature), body, 5*time.Minute))
+		}))
+	defer ts.Close()
+	defer func(mgr policy.Manager) {
+		policyMgr = mgr
+	}(policyMgr)
+	policyMgr = &fakePolicyManager{
+		policy: &models.NotificationPolicy{
+			ID:      1,
+			Targets: []models.EventTarget{{Address: ts.URL, Secret: "secret"}},
+		},
+	}
+
+	// the secret is loaded from the policy rather than the parameters
+	params := map[string]interface{}{
+		"skip_cert_verify": true,
+		"payload":          `{"type":"pushImage"}`,
+		"policy_id":        float64(1),
+		"address":          ts.URL,
+	}
+	assert.Nil(t, rep.Run(&impl.Context{}, params))
+
+	params["policy_id"] = float64(2)
+	assert.NotNil(t, rep.Run(&impl.Context{}, params))
+}
+
+type fakePolicyManager struct {
+	policy.Manager
+	policy *models.NotificationPolicy
+}
+
+func (f *fakePolicyManager) Get(id int64) (*models.NotificationPolicy, error) {
+	if f.policy.ID != id {
+		return nil, nil
+	}
+	return f.policy, nil
+}
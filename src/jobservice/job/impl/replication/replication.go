@@ -17,6 +17,7 @@ package replication
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/replication/model"
@@ -59,6 +60,11 @@ func (r *Replication) ShouldRetry() bool {
 	return true
 }
 
+// MaxRuntime returns a generous time limit as the replication of large artifacts may take a long time
+func (r *Replication) MaxRuntime() time.Duration {
+	return 24 * time.Hour
+}
+
 // Validate does nothing
 func (r *Replication) Validate(params job.Parameters) error {
 	return nil
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"time"
 
 	common_http "github.com/goharbor/harbor/src/common/http"
 	"github.com/goharbor/harbor/src/common/http/modifier/auth"
@@ -38,6 +39,11 @@ func (s *Scheduler) ShouldRetry() bool {
 	return false
 }
 
+// MaxRuntime ...
+func (s *Scheduler) MaxRuntime() time.Duration {
+	return 0
+}
+
 // MaxFails ...
 func (s *Scheduler) MaxFails() uint {
 	return 0
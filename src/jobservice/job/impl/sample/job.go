@@ -37,6 +37,11 @@ func (j *Job) ShouldRetry() bool {
 	return true
 }
 
+// MaxRuntime is implementation of same method in Interface.
+func (j *Job) MaxRuntime() time.Duration {
+	return 0
+}
+
 // Validate is implementation of same method in Interface.
 func (j *Job) Validate(params job.Parameters) error {
 	if params == nil || len(params) == 0 {
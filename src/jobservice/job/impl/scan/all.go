@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"time"
 
 	"net/http"
 	"os"
@@ -27,8 +28,12 @@ import (
 	"github.com/goharbor/harbor/src/common/dao"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/utils"
+	"github.com/goharbor/harbor/src/jobservice/logger"
 )
 
+// The phase name of the progress reported by scan all job
+const scanAllPhase = "trigger scanning"
+
 // All query the DB and Registry for all image and tags,
 // then call Harbor's API to scan each of them.
 type All struct {
@@ -49,6 +54,11 @@ func (sa *All) ShouldRetry() bool {
 	return false
 }
 
+// MaxRuntime implements the interface in job/Interface
+func (sa *All) MaxRuntime() time.Duration {
+	return 0
+}
+
 // Validate implements the interface in job/Interface
 func (sa *All) Validate(params job.Parameters) error {
 	if len(params) > 0 {
@@ -73,7 +83,12 @@ func (sa *All) Run(ctx job.Context, params job.Parameters) error {
 		return err
 	}
 
-	for _, r := range repos {
+	for i, r := range repos {
+		// Report the progress of the repository being handled
+		if err := ctx.SetProgress(scanAllPhase, int64(i), int64(len(repos))); err != nil {
+			logger.Warningf("Failed to report progress, error: %v", err)
+		}
+
 		repoClient, err := utils.NewRepositoryClientForJobservice(r.Name, sa.registryURL, sa.secret, sa.tokenServiceEndpoint)
 		if err != nil {
 			logger.Errorf("Failed to get repo client for repo: %s, error: %v", r.Name, err)
@@ -85,25 +100,15 @@ func (sa *All) Run(ctx job.Context, params job.Parameters) error {
 			continue
 		}
 		for _, t := range tags {
-			logger.Infof("Calling harbor-core API to scan image, %s:%s", r.Name, t)
-			resp, err := sa.coreClient.Post(fmt.Sprintf("%s/repositories/%s/tags/%s/scan", sa.harborAPIEndpoint, r.Name, t),
-				"application/json",
-				bytes.NewReader([]byte("{}")))
-			if err != nil {
-				logger.Errorf("Failed to trigger image scan, error: %v", err)
-			} else {
-				data, err := ioutil.ReadAll(resp.Body)
-				if err != nil {
-					logger.Errorf("Failed to read response, error: %v", err)
-				} else if resp.StatusCode != http.StatusOK {
-					logger.Errorf("Unexpected response code: %d, data: %v", resp.StatusCode, data)
-				}
-				resp.Body.Close()
-			}
+			triggerScan(logger, sa.coreClient, sa.harborAPIEndpoint, r.Name, t)
 		}
 
 	}
 
+	if err := ctx.SetProgress(scanAllPhase, int64(len(repos)), int64(len(repos))); err != nil {
+		logger.Warningf("Failed to report progress, error: %v", err)
+	}
+
 	return nil
 }
 
@@ -133,6 +138,26 @@ func (sa *All) init(ctx job.Context) error {
 	return nil
 }
 
+// triggerScan calls Harbor's API to scan the image, the error is logged only
+func triggerScan(logger logger.Interface, client *http.Client, harborAPIEndpoint, repository, tag string) {
+	logger.Infof("Calling harbor-core API to scan image, %s:%s", repository, tag)
+	resp, err := client.Post(fmt.Sprintf("%s/repositories/%s/tags/%s/scan", harborAPIEndpoint, repository, tag),
+		"application/json",
+		bytes.NewReader([]byte("{}")))
+	if err != nil {
+		logger.Errorf("Failed to trigger image scan, error: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		logger.Errorf("Failed to read response, error: %v", err)
+	} else if resp.StatusCode != http.StatusOK {
+		logger.Errorf("Unexpected response code: %d, data: %v", resp.StatusCode, data)
+	}
+}
+
 func getAttrFromCtx(ctx job.Context, key string) (string, error) {
 	if v, ok := ctx.Get(key); ok && len(v.(string)) > 0 {
 		return v.(string), nil
@@ -0,0 +1,240 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/dao"
+	cjob "github.com/goharbor/harbor/src/common/job"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/utils"
+	"github.com/goharbor/harbor/src/pkg/scan"
+	"github.com/goharbor/harbor/src/pkg/scan/adapter"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom"
+	"github.com/goharbor/harbor/src/pkg/scan/scanner"
+)
+
+// The max interval of fetching the report from the scanner
+const maxRetryAfter = time.Minute
+
+// Job is the struct to scan Harbor's Image with the scanner specified by the parameters,
+// the built-in Clair is used if no scanner is specified.
+type Job struct {
+	registryURL   string
+	secret        string
+	tokenEndpoint string
+	clairEndpoint string
+}
+
+// MaxFails implements the interface in job/Interface
+func (sj *Job) MaxFails() uint {
+	return 1
+}
+
+// ShouldRetry implements the interface in job/Interface
+func (sj *Job) ShouldRetry() bool {
+	return false
+}
+
+// MaxRuntime implements the interface in job/Interface
+func (sj *Job) MaxRuntime() time.Duration {
+	return time.Hour
+}
+
+// Validate implements the interface in job/Interface
+func (sj *Job) Validate(params job.Parameters) error {
+	_, err := transformParam(params)
+	return err
+}
+
+// Run implements the interface in job/Interface
+func (sj *Job) Run(ctx job.Context, params job.Parameters) error {
+	logger := ctx.GetLogger()
+	jobParms, err := transformParam(params)
+	if err != nil {
+		logger.Errorf("Failed to prepare parms for scan job, error: %v", err)
+		return err
+	}
+
+	if err := sj.init(ctx, jobParms); err != nil {
+		logger.Errorf("Failed to initialize the job, error: %v", err)
+		return err
+	}
+
+	scannerUUID, scanAdapter, err := sj.adapter(ctx, jobParms)
+	if err != nil {
+		logger.Errorf("Failed to create the scanner adapter, error: %v", err)
+		return err
+	}
+
+	token, err := utils.GetTokenForRepo(jobParms.Repository, sj.secret, sj.tokenEndpoint)
+	if err != nil {
+		logger.Errorf("Failed to get token, error: %v", err)
+		return err
+	}
+	req := &adapter.ScanRequest{
+		Registry: &adapter.Registry{
+			URL:           sj.registryURL,
+			Authorization: fmt.Sprintf("Bearer %s", token),
+		},
+		Artifact: &adapter.Artifact{
+			Repository: jobParms.Repository,
+			Digest:     jobParms.Digest,
+			Tag:        jobParms.Tag,
+			MimeType:   adapter.MimeTypeDockerArtifact,
+		},
+	}
+
+	logger.Infof("Scanning image %s:%s(%s) with scanner %s", jobParms.Repository, jobParms.Tag, jobParms.Digest, scannerUUID)
+	resp, err := scanAdapter.SubmitScan(req)
+	if err != nil {
+		logger.Errorf("Failed to submit the scan request, error: %v", err)
+		return err
+	}
+
+	report, err := sj.waitForReport(ctx, scanAdapter, resp.ID)
+	if err != nil {
+		logger.Errorf("Failed to get the scan report, error: %v", err)
+		return err
+	}
+	if report == nil {
+		logger.Info("Exit for receiving stop signal")
+		return nil
+	}
+
+	compOverview, sev := scan.OverviewFromReport(report)
+	if err := dao.UpdateImgScanOverview(jobParms.Digest, resp.ID, sev, compOverview); err != nil {
+		return err
+	}
+
+	// The vulnerabilities of the image scanned by the built-in Clair are always fetched from Clair
+	// with the details key, as they are updated when the vulnerability database of Clair is updated
+	reportData := ""
+	if scannerUUID != scanner.BuiltinClairUUID {
+		report.Artifact = req.Artifact
+		data, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+		reportData = string(data)
+	}
+	if err := dao.SetImgScanReport(jobParms.Digest, scannerUUID, reportData); err != nil {
+		return err
+	}
+
+	// The software bill of materials is captured from the report again when it's requested, so don't fail the scan
+	if err := sbom.Save(jobParms.Digest, report); err != nil {
+		logger.Errorf("Failed to save the software bill of materials, error: %v", err)
+	}
+	if err := scan.IndexReport(jobParms.Digest, report); err != nil {
+		logger.Errorf("Failed to index the vulnerabilities, error: %v", err)
+		return err
+	}
+	return nil
+}
+
+// waitForReport fetches the report until it's ready, nil report is returned if the job is stopped
+func (sj *Job) waitForReport(ctx job.Context, scanAdapter adapter.Adapter, scanRequestID string) (*adapter.VulnerabilityReport, error) {
+	logger := ctx.GetLogger()
+	for {
+		report, err := scanAdapter.GetScanReport(scanRequestID)
+		if err == nil {
+			return report, nil
+		}
+		notReady, ok := adapter.IsReportNotReady(err)
+		if !ok {
+			return nil, err
+		}
+
+		retryAfter := notReady.RetryAfter
+		if retryAfter > maxRetryAfter {
+			retryAfter = maxRetryAfter
+		}
+		logger.Debugf("The scan report %s is not ready, retry after %s", scanRequestID, retryAfter)
+		<-time.After(retryAfter)
+
+		if cmd, ok := ctx.OPCommand(); ok && cmd == job.StopCommand {
+			return nil, nil
+		}
+	}
+}
+
+// adapter returns the UUID of the scanner and the adapter to talk with it
+func (sj *Job) adapter(ctx job.Context, jobParms *cjob.ScanJobParms) (string, adapter.Adapter, error) {
+	if len(jobParms.ScannerUUID) == 0 || jobParms.ScannerUUID == scanner.BuiltinClairUUID {
+		loggerImpl, ok := ctx.GetLogger().(*log.Logger)
+		if !ok {
+			loggerImpl = log.DefaultLogger()
+		}
+		return scanner.BuiltinClairUUID, adapter.NewClairAdapter(sj.clairEndpoint, loggerImpl), nil
+	}
+
+	// the registration is loaded here rather than passed by the parameters, which are kept in the job stats
+	r, err := dao.GetScannerRegistration(jobParms.ScannerUUID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get scanner registration %s: %v", jobParms.ScannerUUID, err)
+	}
+	if r == nil {
+		return "", nil, fmt.Errorf("scanner registration %s not found", jobParms.ScannerUUID)
+	}
+	a, err := scanner.NewAdapter(r)
+	return r.UUID, a, err
+}
+
+func (sj *Job) init(ctx job.Context, jobParms *cjob.ScanJobParms) error {
+	errTpl := "failed to get required property: %s"
+	if v, ok := ctx.Get(common.RegistryURL); ok && len(v.(string)) > 0 {
+		sj.registryURL = v.(string)
+	} else {
+		return fmt.Errorf(errTpl, common.RegistryURL)
+	}
+
+	if v := os.Getenv("JOBSERVICE_SECRET"); len(v) > 0 {
+		sj.secret = v
+	} else {
+		return fmt.Errorf(errTpl, "JOBSERVICE_SECRET")
+	}
+	if v, ok := ctx.Get(common.TokenServiceURL); ok && len(v.(string)) > 0 {
+		sj.tokenEndpoint = v.(string)
+	} else {
+		return fmt.Errorf(errTpl, common.TokenServiceURL)
+	}
+	// Clair is only required when scanning with the built-in Clair
+	if len(jobParms.ScannerUUID) > 0 && jobParms.ScannerUUID != scanner.BuiltinClairUUID {
+		return nil
+	}
+	if v, ok := ctx.Get(common.ClairURL); ok && len(v.(string)) > 0 {
+		sj.clairEndpoint = v.(string)
+	} else {
+		return fmt.Errorf(errTpl, common.ClairURL)
+	}
+	return nil
+}
+
+func transformParam(params job.Parameters) (*cjob.ScanJobParms, error) {
+	res := cjob.ScanJobParms{}
+	parmsBytes, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(parmsBytes, &res)
+	return &res, err
+}
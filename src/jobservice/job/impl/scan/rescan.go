@@ -0,0 +1,133 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/dao"
+	cjob "github.com/goharbor/harbor/src/common/job"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/utils"
+)
+
+const (
+	// The phase name of the progress reported by rescan job
+	rescanPhase = "trigger rescanning"
+	// The interval between triggering the scans of two images, so the scanner isn't flooded by the rescans
+	rescanInterval = time.Second
+)
+
+// Rescan queries the images scanned before the vulnerability database of the scanner is updated,
+// then call Harbor's API to scan each of them one by one.
+type Rescan struct {
+	harborAPIEndpoint string
+	coreClient        *http.Client
+}
+
+// MaxFails implements the interface in job/Interface
+func (r *Rescan) MaxFails() uint {
+	return 1
+}
+
+// ShouldRetry implements the interface in job/Interface
+func (r *Rescan) ShouldRetry() bool {
+	return false
+}
+
+// MaxRuntime implements the interface in job/Interface
+func (r *Rescan) MaxRuntime() time.Duration {
+	return 0
+}
+
+// Validate implements the interface in job/Interface
+func (r *Rescan) Validate(params job.Parameters) error {
+	parms, err := transformRescanParam(params)
+	if err != nil {
+		return err
+	}
+	if len(parms.ScannerUUIDs) == 0 {
+		return fmt.Errorf("missing the scanner of rescan job")
+	}
+	if parms.ScannedBefore <= 0 {
+		return fmt.Errorf("invalid scanned_before of rescan job: %d", parms.ScannedBefore)
+	}
+	return nil
+}
+
+// Run implements the interface in job/Interface
+func (r *Rescan) Run(ctx job.Context, params job.Parameters) error {
+	logger := ctx.GetLogger()
+	parms, err := transformRescanParam(params)
+	if err != nil {
+		logger.Errorf("Failed to prepare parms for rescan job, error: %v", err)
+		return err
+	}
+	if v, err := getAttrFromCtx(ctx, common.CoreURL); err == nil {
+		r.harborAPIEndpoint = strings.TrimSuffix(v, "/") + "/api"
+	} else {
+		logger.Errorf("Failed to initialize the job handler, error: %v", err)
+		return err
+	}
+	r.coreClient, _ = utils.GetClient()
+
+	query := &models.RescanQuery{
+		ScannerUUIDs:  parms.ScannerUUIDs,
+		ScannedBefore: time.Unix(parms.ScannedBefore, 0),
+	}
+	if parms.PulledAfter > 0 {
+		query.PulledAfter = time.Unix(parms.PulledAfter, 0)
+	}
+	images, err := dao.ListImagesToRescan(query)
+	if err != nil {
+		logger.Errorf("Failed to get the images to rescan, error: %v", err)
+		return err
+	}
+	logger.Infof("Rescanning %d images scanned by %v before %s", len(images), parms.ScannerUUIDs, query.ScannedBefore)
+
+	for i, img := range images {
+		if cmd, ok := ctx.OPCommand(); ok && cmd == job.StopCommand {
+			logger.Info("The rescan job is stopped")
+			return nil
+		}
+		if err := ctx.SetProgress(rescanPhase, int64(i), int64(len(images))); err != nil {
+			logger.Warningf("Failed to report progress, error: %v", err)
+		}
+
+		triggerScan(logger, r.coreClient, r.harborAPIEndpoint, img.Repository, img.Tag)
+		<-time.After(rescanInterval)
+	}
+
+	if err := ctx.SetProgress(rescanPhase, int64(len(images)), int64(len(images))); err != nil {
+		logger.Warningf("Failed to report progress, error: %v", err)
+	}
+	return nil
+}
+
+func transformRescanParam(params job.Parameters) (*cjob.RescanJobParms, error) {
+	res := &cjob.RescanJobParms{}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, res)
+	return res, err
+}
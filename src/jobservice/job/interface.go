@@ -14,6 +14,11 @@
 
 package job
 
+import "time"
+
+// DefaultMaxRuntime is the time limit of the job which doesn't declare its own max runtime
+const DefaultMaxRuntime = 24 * time.Hour
+
 // Interface defines the related injection and run entry methods.
 type Interface interface {
 	// Declare how many times the job can be retried if failed.
@@ -29,6 +34,13 @@ type Interface interface {
 	//  true for retry and false for none-retry
 	ShouldRetry() bool
 
+	// Declare how long the job can run at most.
+	//
+	// Return:
+	// time.Duration: the max runtime. If it is set to 0, then default value DefaultMaxRuntime is used.
+	// The job running longer than it is marked as failed and asked to stop.
+	MaxRuntime() time.Duration
+
 	// Indicate whether the parameters of job are valid.
 	//
 	// Return:
@@ -24,6 +24,8 @@ const (
 	ImageScanJob = "IMAGE_SCAN"
 	// ImageScanAllJob is the name of "scanall" job in job service
 	ImageScanAllJob = "IMAGE_SCAN_ALL"
+	// ImageRescanJob is the name of the job rescanning the images when the vulnerability database is updated
+	ImageRescanJob = "IMAGE_RESCAN"
 	// ImageGC the name of image garbage collection job in job service
 	ImageGC = "IMAGE_GC"
 	// Replication : the name of the replication job in job service
@@ -34,4 +36,6 @@ const (
 	WebhookJob = "WEBHOOK"
 	// Retention : the name of the retention job
 	Retention = "RETENTION"
+	// ExternalJob : the name of the job running the configured external executable or HTTP endpoint
+	ExternalJob = "EXTERNAL"
 )
@@ -33,6 +33,8 @@ type RequestBody struct {
 	Parameters Parameters `json:"parameters"`
 	Metadata   *Metadata  `json:"metadata"`
 	StatusHook string     `json:"status_hook"`
+	// IDs of the upstream jobs, the job is launched only after all of them succeed
+	DependsOn []string `json:"depends_on,omitempty"`
 }
 
 // Metadata stores the metadata of job.
@@ -40,6 +42,8 @@ type Metadata struct {
 	JobKind       string `json:"kind"`
 	ScheduleDelay uint64 `json:"schedule_delay,omitempty"`
 	Cron          string `json:"cron_spec,omitempty"`
+	TimeZone      string `json:"time_zone,omitempty"` // The time zone to evaluate the cron spec in, local time zone by default
+	CatchUp       string `json:"catch_up,omitempty"`  // How to handle the executions missed while the jobservice is down
 	IsUnique      bool   `json:"unique"`
 }
 
@@ -57,17 +61,37 @@ type StatsInfo struct {
 	IsUnique      bool       `json:"unique"`
 	RefLink       string     `json:"ref_link,omitempty"`
 	CronSpec      string     `json:"cron_spec,omitempty"`
+	TimeZone      string     `json:"time_zone,omitempty"`
+	CatchUp       string     `json:"catch_up,omitempty"`
+	Paused        bool       `json:"paused,omitempty"` // The periodic job is paused and no executions are scheduled
 	EnqueueTime   int64      `json:"enqueue_time"`
 	UpdateTime    int64      `json:"update_time"`
 	RunAt         int64      `json:"run_at,omitempty"`
+	ClaimedAt     int64      `json:"claimed_at,omitempty"` // The time when the scheduled job is picked up by the worker
 	CheckIn       string     `json:"check_in,omitempty"`
 	CheckInAt     int64      `json:"check_in_at,omitempty"`
 	DieAt         int64      `json:"die_at,omitempty"`
+	HeartbeatAt   int64      `json:"heartbeat_at,omitempty"` // The last time the running job reports it's alive
+	TimeoutAt     int64      `json:"timeout_at,omitempty"`   // The running job is failed if it's not done before it
 	WebHookURL    string     `json:"web_hook_url,omitempty"`
 	UpstreamJobID string     `json:"upstream_job_id,omitempty"`   // Ref the upstream job if existing
 	NumericPID    int64      `json:"numeric_policy_id,omitempty"` // The numeric policy ID of the periodic job
 	Parameters    Parameters `json:"parameters,omitempty"`
-	Revision      int64      `json:"revision,omitempty"` // For differentiating the each retry of the same job
+	Revision      int64      `json:"revision,omitempty"`   // For differentiating the each retry of the same job
+	DependsOn     []string   `json:"depends_on,omitempty"` // The upstream jobs which the job depends on
+	Progress      *Progress  `json:"progress,omitempty"`   // The progress reported by the running job
+	Retrying      bool       `json:"retrying,omitempty"`   // The failed job is going to be retried, so the failure is not final
+}
+
+// Progress keeps the structured progress of the running job.
+type Progress struct {
+	Phase   string `json:"phase,omitempty"`
+	Current int64  `json:"current"`
+	Total   int64  `json:"total"` // 0 means the total is unknown
+	// The time when the current phase is started
+	PhaseStartAt int64 `json:"phase_start_at"`
+	// The estimated time when the current phase is done, 0 means it can not be estimated
+	ETA int64 `json:"eta,omitempty"`
 }
 
 // ActionRequest defines for triggering job action like stop/cancel.
@@ -17,6 +17,10 @@ package job
 const (
 	// StopCommand is const for stop command
 	StopCommand OPCommand = "stop"
+	// PauseCommand is const for pause command
+	PauseCommand OPCommand = "pause"
+	// ResumeCommand is const for resume command
+	ResumeCommand OPCommand = "resume"
 	// NilCommand is const for a nil command
 	NilCommand OPCommand = "nil"
 )
@@ -28,3 +32,13 @@ type OPCommand string
 func (oc OPCommand) IsStop() bool {
 	return oc == "stop"
 }
+
+// IsPause return if the op command is pause
+func (oc OPCommand) IsPause() bool {
+	return oc == PauseCommand
+}
+
+// IsResume return if the op command is resume
+func (oc OPCommand) IsResume() bool {
+	return oc == ResumeCommand
+}
@@ -79,6 +79,12 @@ type Tracker interface {
 	// Check in message
 	CheckIn(message string) error
 
+	// Report the running job is alive
+	Heartbeat() error
+
+	// Update the progress of the running job
+	UpdateProgress(phase string, current, total int64) error
+
 	// Update status with retry enabled
 	UpdateStatusWithRetry(targetStatus Status) error
 
@@ -97,6 +103,10 @@ type Tracker interface {
 	// Switch the status to error
 	Fail() error
 
+	// Switch the status to error and mark the job is going to be retried,
+	// the jobs depending on it keep waiting for the retry
+	FailForRetry() error
+
 	// Switch the status to success
 	Succeed() error
 
@@ -234,6 +244,32 @@ func (bt *basicTracker) CheckIn(message string) error {
 		"update_time", now,
 	)
 
+	// Checking in also means the job is alive
+	if err == nil && current == RunningStatus {
+		err = bt.Heartbeat()
+	}
+
+	return err
+}
+
+// Heartbeat reports the running job is alive
+func (bt *basicTracker) Heartbeat() error {
+	conn := bt.pool.Get()
+	defer func() {
+		closeConn(conn)
+	}()
+
+	now := time.Now().Unix()
+
+	err := conn.Send("MULTI")
+	err = conn.Send("HSET", rds.KeyJobStats(bt.namespace, bt.jobID), "heartbeat_at", now)
+	err = conn.Send("ZADD", rds.KeyRunningJobs(bt.namespace), now, bt.jobID)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Do("EXEC")
+
 	return err
 }
 
@@ -263,6 +299,8 @@ func (bt *basicTracker) Stop() error {
 	err := bt.UpdateStatusWithRetry(StoppedStatus)
 	if !errs.IsStatusMismatchError(err) {
 		bt.refresh(StoppedStatus)
+		bt.removeFromRunning()
+
 		if er := bt.fireHookEvent(StoppedStatus); err == nil && er != nil {
 			return er
 		}
@@ -278,6 +316,8 @@ func (bt *basicTracker) Fail() error {
 	err := bt.UpdateStatusWithRetry(ErrorStatus)
 	if !errs.IsStatusMismatchError(err) {
 		bt.refresh(ErrorStatus)
+		bt.removeFromRunning()
+
 		if er := bt.fireHookEvent(ErrorStatus); err == nil && er != nil {
 			return er
 		}
@@ -286,6 +326,16 @@ func (bt *basicTracker) Fail() error {
 	return err
 }
 
+// FailForRetry marks the job failed while it's going to be retried
+func (bt *basicTracker) FailForRetry() error {
+	if err := bt.Update("retrying", true); err != nil {
+		return err
+	}
+	bt.jobStats.Info.Retrying = true
+
+	return bt.Fail()
+}
+
 // Succeed job
 // Succeed is final status, if failed to do, retry should be enforced.
 // Either one is failed, the final return will be marked as failed.
@@ -293,6 +343,7 @@ func (bt *basicTracker) Succeed() error {
 	err := bt.UpdateStatusWithRetry(SuccessStatus)
 	if !errs.IsStatusMismatchError(err) {
 		bt.refresh(SuccessStatus)
+		bt.removeFromRunning()
 
 		// Expire the stat data of the successful job
 		if er := bt.expire(statDataExpireTimeForSuccess); er != nil {
@@ -352,11 +403,25 @@ func (bt *basicTracker) Save() (err error) {
 		args = append(args, "upstream_job_id", stats.Info.UpstreamJobID)
 	}
 
+	if !utils.IsEmptyStr(stats.Info.TimeZone) {
+		args = append(args, "time_zone", stats.Info.TimeZone)
+	}
+
+	if !utils.IsEmptyStr(stats.Info.CatchUp) {
+		args = append(args, "catch_up", stats.Info.CatchUp)
+	}
+
 	if len(stats.Info.Parameters) > 0 {
 		if bytes, err := json.Marshal(&stats.Info.Parameters); err == nil {
 			args = append(args, "parameters", string(bytes))
 		}
 	}
+
+	if len(stats.Info.DependsOn) > 0 {
+		if bytes, err := json.Marshal(&stats.Info.DependsOn); err == nil {
+			args = append(args, "depends_on", string(bytes))
+		}
+	}
 	// Set update timestamp
 	args = append(args, "update_time", time.Now().Unix())
 	// Set the first revision
@@ -433,15 +498,78 @@ func (bt *basicTracker) Reset() error {
 		PendingStatus.String(),
 		"revision",
 		now,
+		"retrying",
+		false,
 	)
 	if err == nil {
 		bt.refresh(PendingStatus)
 		bt.jobStats.Info.Revision = now
+		bt.jobStats.Info.Retrying = false
 	}
 
 	return err
 }
 
+// UpdateProgress updates the progress of the running job and estimates when the current phase is done
+func (bt *basicTracker) UpdateProgress(phase string, current, total int64) error {
+	if current < 0 || total < 0 || (total > 0 && current > total) {
+		return errors.Errorf("invalid progress: %d/%d", current, total)
+	}
+
+	now := time.Now().Unix()
+	progress := &Progress{
+		Phase:        phase,
+		Current:      current,
+		Total:        total,
+		PhaseStartAt: now,
+	}
+
+	// Keep the start time of the same phase for estimating
+	if last := bt.jobStats.Info.Progress; last != nil && last.Phase == phase {
+		progress.PhaseStartAt = last.PhaseStartAt
+	}
+
+	if total > 0 && current > 0 && now > progress.PhaseStartAt {
+		elapsed := now - progress.PhaseStartAt
+		progress.ETA = now + elapsed*(total-current)/current
+	}
+
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	if err := bt.Update(
+		"progress", string(data),
+		"update_time", now,
+	); err != nil {
+		return err
+	}
+
+	bt.jobStats.Info.Progress = progress
+	bt.jobStats.Info.UpdateTime = now
+
+	// Reporting progress also means the job is alive
+	if Status(bt.jobStats.Info.Status) == RunningStatus {
+		return bt.Heartbeat()
+	}
+
+	return nil
+}
+
+// removeFromRunning stops watching the heartbeat of the job in the final status
+func (bt *basicTracker) removeFromRunning() {
+	conn := bt.pool.Get()
+	defer func() {
+		closeConn(conn)
+	}()
+
+	if _, err := conn.Do("ZREM", rds.KeyRunningJobs(bt.namespace), bt.jobID); err != nil {
+		// Only logged
+		logger.Errorf("Remove job %s from the running jobs error: %s", bt.jobID, err)
+	}
+}
+
 // Refresh the job stats in mem
 func (bt *basicTracker) refresh(targetStatus Status, checkIn ...string) {
 	now := time.Now().Unix()
@@ -455,13 +583,9 @@ func (bt *basicTracker) refresh(targetStatus Status, checkIn ...string) {
 }
 
 // FireHookEvent fires the hook event
+// The callback is triggered even the hook URL is not registered,
+// it's up to the callback to decide whether to send the hook event.
 func (bt *basicTracker) fireHookEvent(status Status, checkIn ...string) error {
-	// Check if hook URL is registered
-	if utils.IsEmptyStr(bt.jobStats.Info.WebHookURL) {
-		// Do nothing
-		return nil
-	}
-
 	change := &StatusChange{
 		JobID:    bt.jobID,
 		Status:   status.String(),
@@ -602,6 +726,9 @@ func (bt *basicTracker) retrieve() error {
 		case "run_at":
 			res.Info.RunAt = parseInt64(value)
 			break
+		case "claimed_at":
+			res.Info.ClaimedAt = parseInt64(value)
+			break
 		case "check_in_at":
 			res.Info.CheckInAt = parseInt64(value)
 			break
@@ -614,8 +741,27 @@ func (bt *basicTracker) retrieve() error {
 		case "web_hook_url":
 			res.Info.WebHookURL = value
 			break
+		case "time_zone":
+			res.Info.TimeZone = value
+			break
+		case "catch_up":
+			res.Info.CatchUp = value
+			break
+		case "paused":
+			v, err := strconv.ParseBool(value)
+			if err != nil {
+				v = false
+			}
+			res.Info.Paused = v
+			break
 		case "die_at":
 			res.Info.DieAt = parseInt64(value)
+		case "heartbeat_at":
+			res.Info.HeartbeatAt = parseInt64(value)
+			break
+		case "timeout_at":
+			res.Info.TimeoutAt = parseInt64(value)
+			break
 		case "upstream_job_id":
 			res.Info.UpstreamJobID = value
 			break
@@ -631,6 +777,25 @@ func (bt *basicTracker) retrieve() error {
 		case "revision":
 			res.Info.Revision = parseInt64(value)
 			break
+		case "retrying":
+			v, err := strconv.ParseBool(value)
+			if err != nil {
+				v = false
+			}
+			res.Info.Retrying = v
+			break
+		case "progress":
+			progress := &Progress{}
+			if err := json.Unmarshal([]byte(value), progress); err == nil {
+				res.Info.Progress = progress
+			}
+			break
+		case "depends_on":
+			dependsOn := make([]string, 0)
+			if err := json.Unmarshal([]byte(value), &dependsOn); err == nil {
+				res.Info.DependsOn = dependsOn
+			}
+			break
 		default:
 			break
 		}
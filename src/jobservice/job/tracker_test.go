@@ -130,6 +130,48 @@ func (suite *TrackerTestSuite) TestTracker() {
 	assert.NoError(suite.T(), err)
 }
 
+// TestProgress tests the progress reporting of tracker
+func (suite *TrackerTestSuite) TestProgress() {
+	jobID := utils.MakeIdentifier()
+	mockJobStats := &Stats{
+		Info: &StatsInfo{
+			JobID:    jobID,
+			Status:   RunningStatus.String(),
+			JobKind:  KindGeneric,
+			JobName:  SampleJob,
+			IsUnique: false,
+		},
+	}
+
+	tracker := NewBasicTrackerWithStats(
+		context.TODO(),
+		mockJobStats,
+		suite.namespace,
+		suite.pool,
+		nil,
+	)
+
+	err := tracker.Save()
+	require.Nil(suite.T(), err, "save: nil error expected but got %s", err)
+
+	err = tracker.UpdateProgress("phase1", 3, 2)
+	assert.Error(suite.T(), err, "update progress: non nil error expected but got nil")
+
+	err = tracker.UpdateProgress("phase1", 1, 2)
+	require.NoError(suite.T(), err, "update progress: nil error expected but got %s", err)
+
+	t := NewBasicTrackerWithID(context.TODO(), jobID, suite.namespace, suite.pool, nil)
+	err = t.Load()
+	require.NoError(suite.T(), err)
+
+	progress := t.Job().Info.Progress
+	require.NotNil(suite.T(), progress, "expect non nil progress but got nil")
+	assert.Equal(suite.T(), "phase1", progress.Phase)
+	assert.Equal(suite.T(), int64(1), progress.Current)
+	assert.Equal(suite.T(), int64(2), progress.Total)
+	assert.True(suite.T(), progress.PhaseStartAt > 0, "expect the start time of phase set")
+}
+
 // TestPeriodicTracker tests tracker of periodic
 func (suite *TrackerTestSuite) TestPeriodicTracker() {
 	jobID := utils.MakeIdentifier()
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/goharbor/harbor/src/jobservice/common/rds"
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
 	"github.com/goharbor/harbor/src/jobservice/env"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/logger"
@@ -44,14 +45,26 @@ type Controller interface {
 
 	// Track the life cycle of the specified existing job
 	Track(jobID string) (job.Tracker, error)
+
+	// Depend makes the job wait for the upstream jobs.
+	// The job is launched once all the upstream jobs succeed, and it's
+	// failed or stopped once any upstream job fails or is stopped.
+	Depend(jobID string, upstreamJobIDs []string) error
+
+	// SetLauncher sets the launcher to launch the job whose upstream jobs are all succeeded
+	SetLauncher(launcher Launcher)
 }
 
+// Launcher launches the job which is waiting for its upstream jobs
+type Launcher func(stats *job.Stats) error
+
 // basicController is default implementation of Controller based on redis
 type basicController struct {
 	context   context.Context
 	namespace string
 	pool      *redis.Pool
 	callback  job.HookCallback
+	launcher  Launcher
 	wg        *sync.WaitGroup
 }
 
@@ -73,6 +86,11 @@ func (bc *basicController) Serve() error {
 
 	logger.Info("Status restoring loop is started")
 
+	bc.wg.Add(1)
+	go bc.loopForReapingZombies()
+
+	logger.Info("Zombie job reaping loop is started")
+
 	return nil
 }
 
@@ -86,7 +104,7 @@ func (bc *basicController) New(stats *job.Stats) (job.Tracker, error) {
 		return nil, errors.Errorf("error occurred when creating job tracker: %s", err)
 	}
 
-	bt := job.NewBasicTrackerWithStats(bc.context, stats, bc.namespace, bc.pool, bc.callback)
+	bt := job.NewBasicTrackerWithStats(bc.context, stats, bc.namespace, bc.pool, bc.onStatusChange)
 	if err := bt.Save(); err != nil {
 		return nil, err
 	}
@@ -96,7 +114,7 @@ func (bc *basicController) New(stats *job.Stats) (job.Tracker, error) {
 
 // Track and attache with the job
 func (bc *basicController) Track(jobID string) (job.Tracker, error) {
-	bt := job.NewBasicTrackerWithID(bc.context, jobID, bc.namespace, bc.pool, bc.callback)
+	bt := job.NewBasicTrackerWithID(bc.context, jobID, bc.namespace, bc.pool, bc.onStatusChange)
 	if err := bt.Load(); err != nil {
 		return nil, err
 	}
@@ -104,6 +122,31 @@ func (bc *basicController) Track(jobID string) (job.Tracker, error) {
 	return bt, nil
 }
 
+// SetLauncher ...
+func (bc *basicController) SetLauncher(launcher Launcher) {
+	bc.launcher = launcher
+}
+
+// onStatusChange handles the dependent jobs when the job is done and
+// then sends the hook event if the hook URL is registered
+func (bc *basicController) onStatusChange(hookURL string, change *job.StatusChange) error {
+	status := job.Status(change.Status)
+	// The failure of the job going to be retried is not final, the dependents keep waiting for the retry
+	retrying := status == job.ErrorStatus && change.Metadata != nil && change.Metadata.Retrying
+	if utils.IsEmptyStr(change.CheckIn) && job.RunningStatus.Compare(status) < 0 && !retrying {
+		// Only logged, it should not block the status hook
+		if err := bc.handleDependents(change.JobID, status); err != nil {
+			logger.Errorf("handle dependent jobs of job %s error: %s", change.JobID, err)
+		}
+	}
+
+	if utils.IsEmptyStr(hookURL) || bc.callback == nil {
+		return nil
+	}
+
+	return bc.callback(hookURL, change)
+}
+
 // loopForRestoreDeadStatus is a loop to restore the dead states of jobs
 func (bc *basicController) loopForRestoreDeadStatus() {
 	defer func() {
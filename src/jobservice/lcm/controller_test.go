@@ -105,6 +105,173 @@ func (suite *LcmControllerTestSuite) TestServe() {
 	assert.Equal(suite.T(), 0, count)
 }
 
+// TestDepend tests controller.Depend()
+func (suite *LcmControllerTestSuite) TestDepend() {
+	upstreamJobID := utils.MakeIdentifier()
+	suite.newsStats(upstreamJobID)
+	jobID := utils.MakeIdentifier()
+	suite.newsStats(jobID)
+
+	launched := make(chan string, 1)
+	suite.ctl.SetLauncher(func(stats *job.Stats) error {
+		launched <- stats.Info.JobID
+		return nil
+	})
+
+	err := suite.ctl.Depend(jobID, []string{upstreamJobID})
+	require.NoError(suite.T(), err, "lcm depend: nil error expected but got %s", err)
+
+	t, err := suite.ctl.Track(upstreamJobID)
+	require.NoError(suite.T(), err, "lcm track: nil error expected but got %s", err)
+	err = t.Run()
+	require.NoError(suite.T(), err, "run upstream job: nil error expected but got %s", err)
+	err = t.Succeed()
+	require.NoError(suite.T(), err, "succeed upstream job: nil error expected but got %s", err)
+
+	select {
+	case id := <-launched:
+		assert.Equal(suite.T(), jobID, id, "lcm depend: expect job %s launched but got %s", jobID, id)
+	case <-time.After(5 * time.Second):
+		suite.T().Errorf("lcm depend: job %s is not launched after the upstream job succeeded", jobID)
+	}
+}
+
+// TestDependOnFailedJob tests the failure of upstream job is cascaded to the dependent job
+func (suite *LcmControllerTestSuite) TestDependOnFailedJob() {
+	upstreamJobID := utils.MakeIdentifier()
+	suite.newsStats(upstreamJobID)
+	jobID := utils.MakeIdentifier()
+	suite.newsStats(jobID)
+
+	t, err := suite.ctl.Track(upstreamJobID)
+	require.NoError(suite.T(), err, "lcm track: nil error expected but got %s", err)
+	err = t.Fail()
+	require.NoError(suite.T(), err, "fail upstream job: nil error expected but got %s", err)
+
+	err = suite.ctl.Depend(jobID, []string{upstreamJobID})
+	require.NoError(suite.T(), err, "lcm depend: nil error expected but got %s", err)
+
+	t, err = suite.ctl.Track(jobID)
+	require.NoError(suite.T(), err, "lcm track: nil error expected but got %s", err)
+	assert.Equal(suite.T(), job.ErrorStatus.String(), t.Job().Info.Status, "expect dependent job failed")
+}
+
+// TestDependOnRetriedJob tests the failure of upstream job going to be retried is not cascaded to the dependent job,
+// which is launched once the retry succeeds
+func (suite *LcmControllerTestSuite) TestDependOnRetriedJob() {
+	upstreamJobID := utils.MakeIdentifier()
+	suite.newsStats(upstreamJobID)
+	jobID := utils.MakeIdentifier()
+	suite.newsStats(jobID)
+
+	launched := make(chan string, 1)
+	suite.ctl.SetLauncher(func(stats *job.Stats) error {
+		launched <- stats.Info.JobID
+		return nil
+	})
+
+	err := suite.ctl.Depend(jobID, []string{upstreamJobID})
+	require.NoError(suite.T(), err, "lcm depend: nil error expected but got %s", err)
+
+	t, err := suite.ctl.Track(upstreamJobID)
+	require.NoError(suite.T(), err, "lcm track: nil error expected but got %s", err)
+	err = t.Run()
+	require.NoError(suite.T(), err, "run upstream job: nil error expected but got %s", err)
+	err = t.FailForRetry()
+	require.NoError(suite.T(), err, "fail upstream job for retry: nil error expected but got %s", err)
+
+	dt, err := suite.ctl.Track(jobID)
+	require.NoError(suite.T(), err, "lcm track: nil error expected but got %s", err)
+	assert.Equal(suite.T(), job.PendingStatus.String(), dt.Job().Info.Status, "expect dependent job still pending")
+
+	// Depending on the upstream job failed for retry keeps waiting as well
+	anotherJobID := utils.MakeIdentifier()
+	suite.newsStats(anotherJobID)
+	err = suite.ctl.Depend(anotherJobID, []string{upstreamJobID})
+	require.NoError(suite.T(), err, "lcm depend: nil error expected but got %s", err)
+	dt, err = suite.ctl.Track(anotherJobID)
+	require.NoError(suite.T(), err, "lcm track: nil error expected but got %s", err)
+	assert.Equal(suite.T(), job.PendingStatus.String(), dt.Job().Info.Status, "expect dependent job still pending")
+
+	// The retry succeeds
+	err = t.Reset()
+	require.NoError(suite.T(), err, "reset upstream job: nil error expected but got %s", err)
+	assert.False(suite.T(), t.Job().Info.Retrying)
+	err = t.Run()
+	require.NoError(suite.T(), err, "run upstream job: nil error expected but got %s", err)
+	err = t.Succeed()
+	require.NoError(suite.T(), err, "succeed upstream job: nil error expected but got %s", err)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case id := <-launched:
+			assert.Contains(suite.T(), []string{jobID, anotherJobID}, id)
+		case <-time.After(5 * time.Second):
+			suite.T().Errorf("lcm depend: dependent job is not launched after the retry of the upstream job succeeded")
+		}
+	}
+}
+
+// TestReapZombies tests the running jobs lost heartbeat or ran out of time limit are failed
+func (suite *LcmControllerTestSuite) TestReapZombies() {
+	lostJobID := utils.MakeIdentifier()
+	suite.newsStats(lostJobID)
+	timeoutJobID := utils.MakeIdentifier()
+	suite.newsStats(timeoutJobID)
+	silentJobID := utils.MakeIdentifier()
+	suite.newsStats(silentJobID)
+
+	// The job never reporting its progress is only limited by its max runtime
+	t, err := suite.ctl.Track(silentJobID)
+	require.NoError(suite.T(), err, "lcm track: nil error expected but got %s", err)
+	err = t.Run()
+	require.NoError(suite.T(), err, "run job: nil error expected but got %s", err)
+	err = t.Heartbeat()
+	require.NoError(suite.T(), err, "heartbeat: nil error expected but got %s", err)
+
+	for _, jobID := range []string{lostJobID, timeoutJobID} {
+		t, err := suite.ctl.Track(jobID)
+		require.NoError(suite.T(), err, "lcm track: nil error expected but got %s", err)
+		err = t.Run()
+		require.NoError(suite.T(), err, "run job: nil error expected but got %s", err)
+		err = t.CheckIn("in progress")
+		require.NoError(suite.T(), err, "check in: nil error expected but got %s", err)
+	}
+
+	conn := suite.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// Make the heartbeat stale
+	staleAt := time.Now().Add(-2 * heartbeatStaleTime).Unix()
+	for _, jobID := range []string{lostJobID, silentJobID} {
+		_, err = conn.Do("ZADD", rds.KeyRunningJobs(suite.namespace), staleAt, jobID)
+		require.NoError(suite.T(), err, "mock stale heartbeat: nil error expected but got %s", err)
+	}
+
+	t, err = suite.ctl.Track(timeoutJobID)
+	require.NoError(suite.T(), err, "lcm track: nil error expected but got %s", err)
+	err = t.Update("timeout_at", time.Now().Unix()-1)
+	require.NoError(suite.T(), err, "mock time limit: nil error expected but got %s", err)
+
+	_, err = conn.Do("DEL", rds.KeyZombieReaperLock(suite.namespace))
+	require.NoError(suite.T(), err, "release reaper lock: nil error expected but got %s", err)
+
+	err = suite.ctl.(*basicController).reapZombies()
+	require.NoError(suite.T(), err, "reap zombies: nil error expected but got %s", err)
+
+	for _, jobID := range []string{lostJobID, timeoutJobID} {
+		t, err := suite.ctl.Track(jobID)
+		require.NoError(suite.T(), err, "lcm track: nil error expected but got %s", err)
+		assert.Equal(suite.T(), job.ErrorStatus.String(), t.Job().Info.Status, "expect zombie job %s failed", jobID)
+	}
+
+	t, err = suite.ctl.Track(silentJobID)
+	require.NoError(suite.T(), err, "lcm track: nil error expected but got %s", err)
+	assert.Equal(suite.T(), job.RunningStatus.String(), t.Job().Info.Status, "expect silent job %s still running", silentJobID)
+}
+
 // newsStats create job stats
 func (suite *LcmControllerTestSuite) newsStats(jobID string) {
 	stats := &job.Stats{
@@ -0,0 +1,204 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lcm
+
+import (
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Keep the dependencies as long as the job stats
+	dependencyExpireTime = 7 * 24 * 3600
+)
+
+// Depend makes the job wait for the upstream jobs.
+// The upstream jobs are kept in a set of the job and the job is kept in the dependents set of each
+// upstream job, the upstream job is removed from the set once it succeeds and the job is launched
+// by whoever removes the last one.
+func (bc *basicController) Depend(jobID string, upstreamJobIDs []string) error {
+	if len(upstreamJobIDs) == 0 {
+		return errors.Errorf("no upstream jobs specified for job %s", jobID)
+	}
+
+	conn := bc.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	upstreamsKey := rds.KeyJobUpstreams(bc.namespace, jobID)
+
+	err := conn.Send("MULTI")
+	for _, upstreamJobID := range upstreamJobIDs {
+		err = conn.Send("SADD", upstreamsKey, upstreamJobID)
+
+		dependentsKey := rds.KeyJobDependents(bc.namespace, upstreamJobID)
+		err = conn.Send("SADD", dependentsKey, jobID)
+		err = conn.Send("EXPIRE", dependentsKey, dependencyExpireTime)
+	}
+	err = conn.Send("EXPIRE", upstreamsKey, dependencyExpireTime)
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.Do("EXEC"); err != nil {
+		return err
+	}
+
+	// The upstream jobs may be done before the dependencies are recorded
+	for _, upstreamJobID := range upstreamJobIDs {
+		t, err := bc.Track(upstreamJobID)
+		if err != nil {
+			// Cancel the waiting job as it'll never be launched
+			if er := bc.cascade(jobID, job.StoppedStatus); er != nil {
+				logger.Errorf("stop job %s waiting for missing upstream job %s error: %s", jobID, upstreamJobID, er)
+			}
+
+			return errors.Wrapf(err, "track upstream job %s", upstreamJobID)
+		}
+
+		status := job.Status(t.Job().Info.Status)
+		switch status {
+		case job.SuccessStatus:
+			if err := bc.release(jobID, upstreamJobID); err != nil {
+				return err
+			}
+		case job.ErrorStatus:
+			// Keep waiting for the retry of the upstream job
+			if !t.Job().Info.Retrying {
+				return bc.cascade(jobID, status)
+			}
+		case job.StoppedStatus:
+			return bc.cascade(jobID, status)
+		}
+	}
+
+	return nil
+}
+
+// handleDependents launches or cascades the final status of the job to the jobs depending on it
+func (bc *basicController) handleDependents(jobID string, status job.Status) error {
+	conn := bc.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	dependents, err := redis.Strings(conn.Do("SMEMBERS", rds.KeyJobDependents(bc.namespace, jobID)))
+	if err != nil {
+		return err
+	}
+
+	for _, dependent := range dependents {
+		if status == job.SuccessStatus {
+			err = bc.release(dependent, jobID)
+		} else {
+			err = bc.cascade(dependent, status)
+		}
+
+		if err != nil {
+			// Logged and go on with other dependents
+			logger.Errorf("handle dependent job %s of job %s error: %s", dependent, jobID, err)
+		}
+	}
+
+	return nil
+}
+
+// release removes the succeeded upstream job from the waiting set of the job,
+// the job is launched once no upstream job left
+func (bc *basicController) release(jobID string, upstreamJobID string) error {
+	conn := bc.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	upstreamsKey := rds.KeyJobUpstreams(bc.namespace, jobID)
+
+	err := conn.Send("MULTI")
+	err = conn.Send("SREM", upstreamsKey, upstreamJobID)
+	err = conn.Send("SCARD", upstreamsKey)
+	if err != nil {
+		return err
+	}
+
+	values, err := redis.Int64s(conn.Do("EXEC"))
+	if err != nil {
+		return err
+	}
+
+	// Only the one removing the last upstream job launches the job
+	if len(values) != 2 || values[0] != 1 || values[1] != 0 {
+		return nil
+	}
+
+	t, err := bc.Track(jobID)
+	if err != nil {
+		return err
+	}
+
+	if job.Status(t.Job().Info.Status) != job.PendingStatus {
+		// The waiting job has been stopped
+		return nil
+	}
+
+	if bc.launcher == nil {
+		return errors.Errorf("no launcher to launch job %s", jobID)
+	}
+
+	if err := bc.launcher(t.Job()); err != nil {
+		if er := t.Fail(); er != nil {
+			logger.Errorf("mark job %s failed error: %s", jobID, er)
+		}
+
+		return errors.Wrapf(err, "launch job %s", jobID)
+	}
+
+	logger.Infof("Job %s is launched as all the upstream jobs succeed", jobID)
+
+	return nil
+}
+
+// cascade makes the waiting job failed or stopped as its upstream job,
+// the dependents of the job are handled in turn by the status hook of the job
+func (bc *basicController) cascade(jobID string, status job.Status) error {
+	conn := bc.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// The job is never going to be launched
+	if _, err := conn.Do("DEL", rds.KeyJobUpstreams(bc.namespace, jobID)); err != nil {
+		return err
+	}
+
+	t, err := bc.Track(jobID)
+	if err != nil {
+		return err
+	}
+
+	if job.Status(t.Job().Info.Status) != job.PendingStatus {
+		// Already launched or done
+		return nil
+	}
+
+	if status == job.ErrorStatus {
+		return t.Fail()
+	}
+
+	return t.Stop()
+}
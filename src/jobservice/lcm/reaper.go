@@ -0,0 +1,138 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lcm
+
+import (
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/errs"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// The interval of checking the running jobs
+	reapInterval = time.Minute
+	// The running job reporting its progress is treated as zombie if it doesn't check in or
+	// report progress in this duration. The jobs never reporting are limited by their max runtime
+	// or job.DefaultMaxRuntime.
+	heartbeatStaleTime = 30 * time.Minute
+)
+
+// loopForReapingZombies is a loop to fail the running jobs which lost heartbeat or ran out of the time limit
+func (bc *basicController) loopForReapingZombies() {
+	defer func() {
+		logger.Info("Zombie job reaping loop is stopped")
+		bc.wg.Done()
+	}()
+
+	ticker := time.NewTicker(reapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := bc.reapZombies(); err != nil {
+				logger.Errorf("reap zombie jobs error: %s", err)
+			}
+		case <-bc.context.Done():
+			return
+		}
+	}
+}
+
+// reapZombies checks the running jobs and marks the zombie ones as failed,
+// only one node does it in each round
+func (bc *basicController) reapZombies() error {
+	conn := bc.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// The lock is not released and it expires before the next round
+	lockExpireTime := int64((reapInterval - time.Second) / time.Second)
+	if err := rds.AcquireLock(conn, rds.KeyZombieReaperLock(bc.namespace), utils.MakeIdentifier(), lockExpireTime); err != nil {
+		logger.Debugf("Zombie jobs are being reaped by other node: %s", err)
+		return nil
+	}
+
+	values, err := redis.Int64Map(conn.Do("ZRANGE", rds.KeyRunningJobs(bc.namespace), 0, -1, "WITHSCORES"))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	staleBefore := now.Add(-heartbeatStaleTime).Unix()
+
+	for jobID, heartbeatAt := range values {
+		if err := bc.reap(jobID, heartbeatAt < staleBefore, now.Unix()); err != nil {
+			// Logged and go on with other jobs
+			logger.Errorf("reap zombie job %s error: %s", jobID, err)
+		}
+	}
+
+	return nil
+}
+
+// reap marks the running job as failed if it lost heartbeat or ran out of the time limit
+func (bc *basicController) reap(jobID string, heartbeatLost bool, now int64) error {
+	t, err := bc.Track(jobID)
+	if err != nil {
+		if errs.IsObjectNotFoundError(err) {
+			// The stats of the job is expired
+			return bc.forget(jobID)
+		}
+
+		return err
+	}
+
+	info := t.Job().Info
+	if job.Status(info.Status) != job.RunningStatus {
+		// The job is done but was not removed from the running jobs
+		return bc.forget(jobID)
+	}
+
+	// Only the job which has reported its progress is expected to keep reporting
+	heartbeatLost = heartbeatLost && (info.CheckInAt > 0 || info.Progress != nil)
+	timeout := info.TimeoutAt > 0 && now > info.TimeoutAt
+	if !heartbeatLost && !timeout {
+		return nil
+	}
+
+	if heartbeatLost {
+		logger.Warningf("Job %s:%s lost heartbeat since %d, mark it as failed", info.JobName, jobID, info.HeartbeatAt)
+	} else {
+		logger.Warningf("Job %s:%s ran out of the time limit at %d, mark it as failed", info.JobName, jobID, info.TimeoutAt)
+	}
+
+	// The status hook is fired by the tracker, and the job is asked to stop by the
+	// op command of its context if it's still running somewhere
+	return t.Fail()
+}
+
+// forget removes the job from the running jobs
+func (bc *basicController) forget(jobID string) error {
+	conn := bc.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_, err := conn.Do("ZREM", rds.KeyRunningJobs(bc.namespace), jobID)
+
+	return err
+}
@@ -1,59 +1,135 @@
 package backend
 
 import (
-	"bufio"
 	"bytes"
+	"sync"
+	"time"
+
 	"github.com/goharbor/harbor/src/common/dao"
 	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/common/utils/log"
 )
 
+// The interval of syncing the buffered log to DB, so the log of the running job can be followed
+const dbLogSyncInterval = 5 * time.Second
+
 // DBLogger is an implementation of logger.Interface.
 // It outputs logs to PGSql.
 type DBLogger struct {
 	backendLogger *log.Logger
-	bw            *bufio.Writer
-	buffer        *bytes.Buffer
-	key           string
+	// Only the log not synced to DB yet is kept in the buffer
+	buffer    *syncBuffer
+	key       string
+	syncLock  *sync.Mutex
+	done      chan struct{}
+	closeOnce *sync.Once
 }
 
 // NewDBLogger crates a new DB logger
 // nil might be returned
-func NewDBLogger(key string, level string, depth int) (*DBLogger, error) {
-	buffer := bytes.NewBuffer(make([]byte, 0))
-	bw := bufio.NewWriter(buffer)
+// If the formatter is nil, the logs are formatted as plain text
+func NewDBLogger(key string, level string, depth int, formatter log.Formatter) (*DBLogger, error) {
+	buffer := &syncBuffer{}
 	logLevel := parseLevel(level)
 
-	backendLogger := log.New(bw, log.NewTextFormatter(), logLevel, depth)
+	backendLogger := log.New(buffer, formatterOrDefault(formatter), logLevel, depth)
 
-	return &DBLogger{
+	dbl := &DBLogger{
 		backendLogger: backendLogger,
-		bw:            bw,
 		buffer:        buffer,
 		key:           key,
-	}, nil
+		syncLock:      new(sync.Mutex),
+		done:          make(chan struct{}),
+		closeOnce:     new(sync.Once),
+	}
+
+	// Reset the log in DB at the beginning as the log is appended by the syncing
+	if _, err := dao.CreateOrUpdateJobLog(&models.JobLog{UUID: key}); err != nil {
+		log.Errorf("reset job log %s in DB error: %s", key, err)
+	}
+
+	go dbl.loopSync()
+
+	return dbl, nil
 }
 
 // Close the opened io stream and flush data into DB
 // Implements logger.Closer interface
 func (dbl *DBLogger) Close() error {
-	err := dbl.bw.Flush()
-	if err != nil {
-		return err
+	dbl.closeOnce.Do(func() {
+		close(dbl.done)
+	})
+
+	return dbl.sync()
+}
+
+// loopSync syncs the buffered log to DB periodically until the logger is closed
+func (dbl *DBLogger) loopSync() {
+	ticker := time.NewTicker(dbLogSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-dbl.done:
+			return
+		case <-ticker.C:
+			if err := dbl.sync(); err != nil {
+				log.Errorf("sync job log %s to DB error: %s", dbl.key, err)
+			}
+		}
 	}
+}
+
+// sync appends the log buffered since the last syncing to DB
+func (dbl *DBLogger) sync() error {
+	dbl.syncLock.Lock()
+	defer dbl.syncLock.Unlock()
 
-	jobLog := models.JobLog{
-		UUID:    dbl.key,
-		Content: dbl.buffer.String(),
+	content := dbl.buffer.String()
+	if len(content) == 0 {
+		return nil
 	}
 
-	_, err = dao.CreateOrUpdateJobLog(&jobLog)
-	if err != nil {
+	if err := dao.AppendJobLog(dbl.key, content); err != nil {
 		return err
 	}
+
+	// The log written during the syncing is kept for the next syncing
+	dbl.buffer.Discard(len(content))
+
 	return nil
 }
 
+// syncBuffer is a buffer safe for writing and reading at the same time
+type syncBuffer struct {
+	lock   sync.Mutex
+	buffer bytes.Buffer
+}
+
+// Write implements io.Writer
+func (sb *syncBuffer) Write(p []byte) (int, error) {
+	sb.lock.Lock()
+	defer sb.lock.Unlock()
+
+	return sb.buffer.Write(p)
+}
+
+// String returns the buffered content
+func (sb *syncBuffer) String() string {
+	sb.lock.Lock()
+	defer sb.lock.Unlock()
+
+	return sb.buffer.String()
+}
+
+// Discard drops the first n bytes of the buffered content
+func (sb *syncBuffer) Discard(n int) {
+	sb.lock.Lock()
+	defer sb.lock.Unlock()
+
+	sb.buffer.Next(n)
+}
+
 // Debug ...
 func (dbl *DBLogger) Debug(v ...interface{}) {
 	dbl.backendLogger.Debug(v...)
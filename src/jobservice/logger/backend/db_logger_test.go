@@ -37,7 +37,7 @@ func TestMain(m *testing.M) {
 // Test DB logger
 func TestDBLogger(t *testing.T) {
 	uuid := "uuid_for_unit_test"
-	l, err := NewDBLogger(uuid, "DEBUG", 4)
+	l, err := NewDBLogger(uuid, "DEBUG", 4, nil)
 	require.Nil(t, err)
 
 	l.Debug("JobLog Debug: TestDBLogger")
@@ -15,13 +15,14 @@ type FileLogger struct {
 
 // NewFileLogger crates a new file logger
 // nil might be returned
-func NewFileLogger(level string, logPath string, depth int) (*FileLogger, error) {
+// If the formatter is nil, the logs are formatted as plain text
+func NewFileLogger(level string, logPath string, depth int, formatter log.Formatter) (*FileLogger, error) {
 	f, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY, 0600)
 	if err != nil {
 		return nil, err
 	}
 	logLevel := parseLevel(level)
-	backendLogger := log.New(f, log.NewTextFormatter(), logLevel, depth)
+	backendLogger := log.New(f, formatterOrDefault(formatter), logLevel, depth)
 
 	return &FileLogger{
 		backendLogger: backendLogger,
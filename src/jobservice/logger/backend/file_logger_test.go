@@ -1,21 +1,25 @@
 package backend
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"os"
 	"path"
 	"testing"
+
+	"github.com/goharbor/harbor/src/common/utils/log"
 )
 
 // Test file logger creation with non existing file path
 func TestFileLoggerCreation(t *testing.T) {
-	if _, err := NewFileLogger("DEBUG", "/non-existing/a.log", 4); err == nil {
+	if _, err := NewFileLogger("DEBUG", "/non-existing/a.log", 4, nil); err == nil {
 		t.Fatalf("expect non nil error but got nil when creating file logger with non existing path")
 	}
 }
 
 // Test file logger
 func TestFileLogger(t *testing.T) {
-	l, err := NewFileLogger("DEBUG", path.Join(os.TempDir(), "TestFileLogger.log"), 4)
+	l, err := NewFileLogger("DEBUG", path.Join(os.TempDir(), "TestFileLogger.log"), 4, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -28,3 +32,34 @@ func TestFileLogger(t *testing.T) {
 	l.Warningf("%s", "TestFileLogger")
 	l.Errorf("%s", "TestFileLogger")
 }
+
+// Test file logger with JSON format
+func TestFileLoggerWithJSONFormat(t *testing.T) {
+	logPath := path.Join(os.TempDir(), "TestFileLoggerWithJSONFormat.log")
+	l, err := NewFileLogger("DEBUG", logPath, 4, log.NewJSONFormatter(map[string]string{"job_id": "fake_job_ID"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.Remove(logPath)
+	}()
+
+	l.Errorf("%s", "TestFileLoggerWithJSONFormat")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &log.JSONRecord{}
+	if err := json.Unmarshal(data, r); err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Level != "ERROR" || r.Msg != "TestFileLoggerWithJSONFormat" || r.Fields["job_id"] != "fake_job_ID" {
+		t.Errorf("unexpected JSON log line: %s", data)
+	}
+}
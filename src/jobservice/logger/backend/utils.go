@@ -26,3 +26,12 @@ func parseLevel(lvl string) log.Level {
 
 	return level
 }
+
+// formatterOrDefault returns the text formatter if the given formatter is nil
+func formatterOrDefault(formatter log.Formatter) log.Formatter {
+	if formatter == nil {
+		return log.NewTextFormatter()
+	}
+
+	return formatter
+}
@@ -15,6 +15,7 @@ import (
 const (
 	systemKeyServiceLogger = "system.jobServiceLogger"
 	systemKeyLogDataGetter = "system.logDataGetter"
+	systemKeySweeper       = "system.sweeper"
 )
 
 var singletons sync.Map
@@ -233,6 +234,8 @@ func Init(ctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("start logger sweeper error: %s", err)
 		}
+		// Avoid data race issue
+		singletons.Store(systemKeySweeper, swp)
 	}
 
 	return nil
@@ -37,11 +37,11 @@ func TestMain(m *testing.M) {
 func TestEntry(t *testing.T) {
 	var loggers = make([]Interface, 0)
 	uuid := "uuid_for_unit_test"
-	dbl, err := backend.NewDBLogger(uuid, "DEBUG", 4)
+	dbl, err := backend.NewDBLogger(uuid, "DEBUG", 4, nil)
 	require.Nil(t, err)
 	loggers = append(loggers, dbl)
 
-	fl, err := backend.NewFileLogger("DEBUG", path.Join(os.TempDir(), "TestFileLogger.log"), 4)
+	fl, err := backend.NewFileLogger("DEBUG", path.Join(os.TempDir(), "TestFileLogger.log"), 4, nil)
 	require.Nil(t, err)
 	loggers = append(loggers, fl)
 
@@ -2,19 +2,28 @@ package logger
 
 import (
 	"errors"
+	"fmt"
 	"path"
 
+	"github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/goharbor/harbor/src/jobservice/logger/backend"
 )
 
+const (
+	// FormatText formats the log as plain text lines, it's the default format
+	FormatText = "text"
+	// FormatJSON formats the log as JSON lines
+	FormatJSON = "json"
+)
+
 // Factory creates a new logger based on the settings.
 type Factory func(options ...OptionItem) (Interface, error)
 
 // FileFactory is factory of file logger
 func FileFactory(options ...OptionItem) (Interface, error) {
 	var (
-		level, baseDir, fileName string
-		depth                    int
+		level, baseDir, fileName, format, jobID string
+		depth                                   int
 	)
 	for _, op := range options {
 		switch op.Field() {
@@ -26,6 +35,10 @@ func FileFactory(options ...OptionItem) (Interface, error) {
 			fileName = op.String()
 		case "depth":
 			depth = op.Int()
+		case "format":
+			format = op.String()
+		case "job_id":
+			jobID = op.String()
 		default:
 
 		}
@@ -39,7 +52,12 @@ func FileFactory(options ...OptionItem) (Interface, error) {
 		return nil, errors.New("missing file name option of the file logger")
 	}
 
-	return backend.NewFileLogger(level, path.Join(baseDir, fileName), depth)
+	formatter, err := getFormatter(format, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return backend.NewFileLogger(level, path.Join(baseDir, fileName), depth, formatter)
 }
 
 // StdFactory is factory of std output logger.
@@ -66,8 +84,8 @@ func StdFactory(options ...OptionItem) (Interface, error) {
 // DBFactory is factory of file logger
 func DBFactory(options ...OptionItem) (Interface, error) {
 	var (
-		level, key string
-		depth      int
+		level, key, format string
+		depth              int
 	)
 	for _, op := range options {
 		switch op.Field() {
@@ -77,6 +95,8 @@ func DBFactory(options ...OptionItem) (Interface, error) {
 			key = op.String()
 		case "depth":
 			depth = op.Int()
+		case "format":
+			format = op.String()
 		default:
 		}
 	}
@@ -85,5 +105,28 @@ func DBFactory(options ...OptionItem) (Interface, error) {
 		return nil, errors.New("missing key option of the db logger")
 	}
 
-	return backend.NewDBLogger(key, level, depth)
+	// The key of the DB logger is the job ID
+	formatter, err := getFormatter(format, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return backend.NewDBLogger(key, level, depth, formatter)
+}
+
+// getFormatter returns the log formatter of the specified format.
+// The job ID is attached to every log line in the JSON format if it's not empty.
+func getFormatter(format string, jobID string) (log.Formatter, error) {
+	switch format {
+	case "", FormatText:
+		return log.NewTextFormatter(), nil
+	case FormatJSON:
+		var fields map[string]string
+		if len(jobID) > 0 {
+			fields = map[string]string{"job_id": jobID}
+		}
+		return log.NewJSONFormatter(fields), nil
+	default:
+		return nil, fmt.Errorf("unknown log format: %s", format)
+	}
 }
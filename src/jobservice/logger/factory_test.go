@@ -72,3 +72,24 @@ func TestDBFactoryErr1(t *testing.T) {
 	_, err := DBFactory(ois...)
 	require.NotNil(t, err)
 }
+
+// TestFileFactoryWithFormat
+func TestFileFactoryWithFormat(t *testing.T) {
+	ois := make([]OptionItem, 0)
+	ois = append(ois, OptionItem{"level", "DEBUG"})
+	ois = append(ois, OptionItem{"base_dir", "/tmp"})
+	ois = append(ois, OptionItem{"filename", "test.out"})
+	ois = append(ois, OptionItem{"format", FormatJSON})
+	ois = append(ois, OptionItem{"job_id", "fake_job_ID"})
+
+	ff, err := FileFactory(ois...)
+	require.Nil(t, err)
+
+	if closer, ok := ff.(Closer); ok {
+		_ = closer.Close()
+	}
+
+	ois[3] = OptionItem{"format", "xml"}
+	_, err = FileFactory(ois...)
+	require.NotNil(t, err)
+}
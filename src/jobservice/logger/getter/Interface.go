@@ -9,4 +9,23 @@ type Interface interface {
 	// If succeed, log data bytes will be returned
 	// otherwise, a non nil error is returned
 	Retrieve(logID string) ([]byte, error)
+
+	// Retrieve the log data of the specified log entry after the offset.
+	// It's used to follow the log of the running job.
+	//
+	// logID string : the id of the log entry. e.g: file name a.log for file log
+	// offset int64 : the number of bytes already retrieved
+	//
+	// If succeed, log data bytes after the offset will be returned
+	// otherwise, a non nil error is returned
+	RetrieveFrom(logID string, offset int64) ([]byte, error)
+
+	// Retrieve the log data of the specified log entry which matches the filter.
+	//
+	// logID string  : the id of the log entry. e.g: file name a.log for file log
+	// filter *Filter: the conditions of filtering the log lines
+	//
+	// If succeed, the matched log data bytes will be returned
+	// otherwise, a non nil error is returned
+	RetrieveWithFilter(logID string, filter *Filter) ([]byte, error)
 }
@@ -2,7 +2,11 @@ package getter
 
 import (
 	"errors"
+	"strings"
+
+	"github.com/astaxie/beego/orm"
 	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/jobservice/errs"
 )
 
 // DBGetter is responsible for retrieving DB log data
@@ -27,3 +31,44 @@ func (dbg *DBGetter) Retrieve(logID string) ([]byte, error) {
 
 	return []byte(jobLog.Content), nil
 }
+
+// RetrieveFrom implements @Interface.RetrieveFrom
+func (dbg *DBGetter) RetrieveFrom(logID string, offset int64) ([]byte, error) {
+	if len(logID) == 0 {
+		return nil, errors.New("empty log identify")
+	}
+
+	jobLog, err := dao.GetJobLog(logID)
+	if err != nil {
+		if err == orm.ErrNoRows {
+			// The log is not synced to DB yet
+			return nil, errs.NoObjectFoundError(logID)
+		}
+
+		return nil, err
+	}
+
+	if offset >= int64(len(jobLog.Content)) {
+		return []byte{}, nil
+	}
+
+	return []byte(jobLog.Content[offset:]), nil
+}
+
+// RetrieveWithFilter implements @Interface.RetrieveWithFilter
+func (dbg *DBGetter) RetrieveWithFilter(logID string, filter *Filter) ([]byte, error) {
+	if len(logID) == 0 {
+		return nil, errors.New("empty log identify")
+	}
+
+	jobLog, err := dao.GetJobLog(logID)
+	if err != nil {
+		if err == orm.ErrNoRows {
+			return nil, errs.NoObjectFoundError(logID)
+		}
+
+		return nil, err
+	}
+
+	return filter.Apply(strings.NewReader(jobLog.Content))
+}
@@ -3,6 +3,7 @@ package getter
 import (
 	"github.com/goharbor/harbor/src/common/dao"
 	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/jobservice/errs"
 	"github.com/goharbor/harbor/src/jobservice/logger/backend"
 	"github.com/goharbor/harbor/src/jobservice/logger/sweeper"
 	"github.com/stretchr/testify/require"
@@ -35,7 +36,7 @@ func TestMain(m *testing.M) {
 // TestDBGetter
 func TestDBGetter(t *testing.T) {
 	uuid := "uuid_for_unit_test_getter"
-	l, err := backend.NewDBLogger(uuid, "DEBUG", 4)
+	l, err := backend.NewDBLogger(uuid, "DEBUG", 4, nil)
 	require.Nil(t, err)
 
 	l.Debug("JobLog Debug: TestDBLoggerGetter")
@@ -47,6 +48,14 @@ func TestDBGetter(t *testing.T) {
 	require.Nil(t, err)
 	log.Infof("get logger %s", ll)
 
+	tail, err := dbGetter.RetrieveFrom(uuid, 5)
+	require.Nil(t, err)
+	require.Equal(t, string(ll[5:]), string(tail))
+
+	tail, err = dbGetter.RetrieveFrom(uuid, int64(len(ll)))
+	require.Nil(t, err)
+	require.Equal(t, 0, len(tail))
+
 	err = sweeper.PrepareDBSweep()
 	require.NoError(t, err)
 	dbSweeper := sweeper.NewDBSweeper(-1)
@@ -58,7 +67,7 @@ func TestDBGetter(t *testing.T) {
 // TestDBGetterError
 func TestDBGetterError(t *testing.T) {
 	uuid := "uuid_for_unit_test_getter_error"
-	l, err := backend.NewDBLogger(uuid, "DEBUG", 4)
+	l, err := backend.NewDBLogger(uuid, "DEBUG", 4, nil)
 	require.Nil(t, err)
 
 	l.Debug("JobLog Debug: TestDBLoggerGetter")
@@ -70,6 +79,8 @@ func TestDBGetterError(t *testing.T) {
 	require.NotNil(t, err)
 	_, err = dbGetter.Retrieve("not_exist_uuid")
 	require.NotNil(t, err)
+	_, err = dbGetter.RetrieveWithFilter("not_exist_uuid", &Filter{Level: "ERROR"})
+	require.True(t, errs.IsObjectNotFoundError(err))
 
 	err = sweeper.PrepareDBSweep()
 	require.NoError(t, err)
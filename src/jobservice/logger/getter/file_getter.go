@@ -4,7 +4,9 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"os"
 	"path"
 
 	"github.com/goharbor/harbor/src/jobservice/errs"
@@ -24,19 +26,69 @@ func NewFileGetter(baseDir string) *FileGetter {
 
 // Retrieve implements @Interface.Retrieve
 func (fg *FileGetter) Retrieve(logID string) ([]byte, error) {
+	fPath, err := fg.logPath(logID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ioutil.ReadFile(fPath)
+}
+
+// RetrieveFrom implements @Interface.RetrieveFrom
+func (fg *FileGetter) RetrieveFrom(logID string, offset int64) ([]byte, error) {
+	fPath, err := fg.logPath(logID)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(fPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	return ioutil.ReadAll(f)
+}
+
+// RetrieveWithFilter implements @Interface.RetrieveWithFilter
+func (fg *FileGetter) RetrieveWithFilter(logID string, filter *Filter) ([]byte, error) {
+	fPath, err := fg.logPath(logID)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(fPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return filter.Apply(f)
+}
+
+// logPath returns the path of the existing log file
+func (fg *FileGetter) logPath(logID string) (string, error) {
 	if len(logID) != 24 {
-		return nil, errors.New("invalid length of log identify")
+		return "", errors.New("invalid length of log identify")
 	}
 
 	if _, err := hex.DecodeString(logID); err != nil {
-		return nil, errors.New("invalid log identify")
+		return "", errors.New("invalid log identify")
 	}
 
 	fPath := path.Join(fg.baseDir, fmt.Sprintf("%s.log", logID))
 
 	if !utils.FileExists(fPath) {
-		return nil, errs.NoObjectFoundError(logID)
+		return "", errs.NoObjectFoundError(logID)
 	}
 
-	return ioutil.ReadFile(fPath)
+	return fPath, nil
 }
@@ -44,4 +44,13 @@ func TestLogDataGetter(t *testing.T) {
 	if len(data) != 5 {
 		t.Errorf("expect reading 5 bytes but got %d bytes", len(data))
 	}
+
+	data, err = fg.RetrieveFrom(newLogFileID, 3)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if string(data) != "lo" {
+		t.Errorf("expect reading 'lo' after offset 3 but got '%s'", data)
+	}
 }
@@ -0,0 +1,122 @@
+package getter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/goharbor/harbor/src/common/utils/log"
+)
+
+// The max size of one log line to scan
+const maxLogLineSize = 1024 * 1024
+
+// Level of the text log line, e.g: "2019-10-10T10:10:10Z [ERROR] ..."
+var textLevelPattern = regexp.MustCompile(`^\S+ \[([A-Z]+)\] `)
+
+// Rank of the log levels
+var levelRanks = map[string]int{
+	"DEBUG":   0,
+	"INFO":    1,
+	"WARNING": 2,
+	"ERROR":   3,
+	"FATAL":   4,
+}
+
+// Filter keeps the conditions of filtering the log lines.
+// Both the plain text and JSON log lines are supported.
+type Filter struct {
+	// The minimum level of the log lines, e.g: "WARNING" matches the WARNING, ERROR and FATAL lines
+	Level string
+	// The substring contained by the log lines
+	Keyword string
+}
+
+// Validate the filter
+func (f *Filter) Validate() error {
+	if f == nil {
+		return nil
+	}
+
+	if len(f.Level) > 0 {
+		if _, ok := levelRanks[strings.ToUpper(f.Level)]; !ok {
+			return fmt.Errorf("invalid log level: %s", f.Level)
+		}
+	}
+
+	return nil
+}
+
+// Apply the filter to the log data read from the reader.
+// A log entry may have multiple lines, e.g: the stack of a panic, the lines following the
+// leading line of the entry are matched or not matched together with the leading line.
+func (f *Filter) Apply(r io.Reader) ([]byte, error) {
+	minRank := -1
+	if f != nil && len(f.Level) > 0 {
+		minRank = levelRanks[strings.ToUpper(f.Level)]
+	}
+
+	var (
+		matched bytes.Buffer
+		entry   bytes.Buffer
+		rank    = -1
+	)
+	flush := func() {
+		if entry.Len() > 0 && rank >= minRank && f.contains(entry.Bytes()) {
+			_, _ = matched.Write(entry.Bytes())
+		}
+		entry.Reset()
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if lvl, ok := lineLevel(line); ok {
+			// A new entry
+			flush()
+			rank = levelRanks[lvl]
+		}
+
+		_, _ = entry.Write(line)
+		_ = entry.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	return matched.Bytes(), nil
+}
+
+// contains checks if the log entry contains the keyword
+func (f *Filter) contains(entry []byte) bool {
+	if f == nil || len(f.Keyword) == 0 {
+		return true
+	}
+
+	return bytes.Contains(entry, []byte(f.Keyword))
+}
+
+// lineLevel returns the level of the leading line of a log entry
+func lineLevel(line []byte) (string, bool) {
+	if len(line) > 0 && line[0] == '{' {
+		r := &log.JSONRecord{}
+		if err := json.Unmarshal(line, r); err == nil {
+			_, ok := levelRanks[r.Level]
+			return r.Level, ok
+		}
+	}
+
+	if m := textLevelPattern.FindSubmatch(line); m != nil {
+		lvl := string(m[1])
+		_, ok := levelRanks[lvl]
+		return lvl, ok
+	}
+
+	return "", false
+}
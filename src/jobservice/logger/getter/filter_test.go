@@ -0,0 +1,44 @@
+package getter
+
+import (
+	"strings"
+	"testing"
+)
+
+const fakeLogData = `2019-10-10T10:10:10Z [INFO] [/jobservice/job.go:10]: copying blob sha256:aaa
+2019-10-10T10:10:11Z [ERROR] [/jobservice/job.go:20]: failed to copy blob sha256:bbb
+stack line 1
+2019-10-10T10:10:12Z [DEBUG] [/jobservice/job.go:30]: debug sha256:bbb
+{"time":"2019-10-10T10:10:13Z","level":"WARNING","msg":"retry blob sha256:bbb","fields":{"job_id":"fake"}}
+{"time":"2019-10-10T10:10:14Z","level":"INFO","msg":"blob sha256:ccc copied","fields":{"job_id":"fake"}}
+`
+
+// Test filtering the log lines
+func TestFilter(t *testing.T) {
+	cases := []struct {
+		filter *Filter
+		lines  int
+	}{
+		{nil, 6},
+		{&Filter{}, 6},
+		{&Filter{Level: "warning"}, 3},
+		{&Filter{Keyword: "sha256:bbb"}, 4},
+		{&Filter{Level: "INFO", Keyword: "sha256:bbb"}, 3},
+		{&Filter{Level: "ERROR", Keyword: "sha256:ccc"}, 0},
+	}
+
+	for _, c := range cases {
+		data, err := c.filter.Apply(strings.NewReader(fakeLogData))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if lines := strings.Count(string(data), "\n"); lines != c.lines {
+			t.Errorf("expect %d lines matched with filter %+v but got %d", c.lines, c.filter, lines)
+		}
+	}
+
+	if err := (&Filter{Level: "bad"}).Validate(); err == nil {
+		t.Error("expect non nil error for invalid level but got nil")
+	}
+}
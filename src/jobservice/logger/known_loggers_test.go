@@ -50,14 +50,14 @@ func TestKnownLoggers(t *testing.T) {
 // Test GetLoggerName
 func TestGetLoggerName(t *testing.T) {
 	uuid := "uuid_for_unit_test"
-	l, err := backend.NewDBLogger(uuid, "DEBUG", 4)
+	l, err := backend.NewDBLogger(uuid, "DEBUG", 4, nil)
 	require.Nil(t, err)
 	require.Equal(t, NameDB, GetLoggerName(l))
 
 	stdLog := backend.NewStdOutputLogger("DEBUG", backend.StdErr, 4)
 	require.Equal(t, NameStdOutput, GetLoggerName(stdLog))
 
-	fileLog, err := backend.NewFileLogger("DEBUG", path.Join(os.TempDir(), "TestFileLogger.log"), 4)
+	fileLog, err := backend.NewFileLogger("DEBUG", path.Join(os.TempDir(), "TestFileLogger.log"), 4, nil)
 	require.Nil(t, err)
 	require.Equal(t, NameFile, GetLoggerName(fileLog))
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/goharbor/harbor/src/jobservice/logger/getter"
+	"github.com/goharbor/harbor/src/jobservice/logger/sweeper"
 )
 
 // Retrieve is wrapper func for getter.Retrieve
@@ -15,3 +16,39 @@ func Retrieve(logID string) ([]byte, error) {
 
 	return val.(getter.Interface).Retrieve(logID)
 }
+
+// RetrieveFrom is wrapper func for getter.RetrieveFrom
+func RetrieveFrom(logID string, offset int64) ([]byte, error) {
+	val, ok := singletons.Load(systemKeyLogDataGetter)
+	if !ok {
+		return nil, errors.New("no log data getter is configured")
+	}
+
+	return val.(getter.Interface).RetrieveFrom(logID, offset)
+}
+
+// RetrieveWithFilter is wrapper func for getter.RetrieveWithFilter
+func RetrieveWithFilter(logID string, filter *getter.Filter) ([]byte, error) {
+	val, ok := singletons.Load(systemKeyLogDataGetter)
+	if !ok {
+		return nil, errors.New("no log data getter is configured")
+	}
+
+	return val.(getter.Interface).RetrieveWithFilter(logID, filter)
+}
+
+// SweepJobs is wrapper func for sweeper.JobSweeper.SweepJobs.
+// Nothing is swept if no sweeper is configured.
+func SweepJobs(jobIDs ...string) (int, error) {
+	val, ok := singletons.Load(systemKeySweeper)
+	if !ok {
+		return 0, nil
+	}
+
+	js, ok := val.(sweeper.JobSweeper)
+	if !ok {
+		return 0, nil
+	}
+
+	return js.SweepJobs(jobIDs...)
+}
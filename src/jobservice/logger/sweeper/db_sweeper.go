@@ -37,6 +37,17 @@ func (dbs *DBSweeper) Sweep() (int, error) {
 	return int(count), nil
 }
 
+// SweepJobs sweeps the DB logs of the specified jobs.
+// It's called in the API requests, the DB initialization is waited in the bootstrap.
+func (dbs *DBSweeper) SweepJobs(jobIDs ...string) (int, error) {
+	count, err := dao.DeleteJobLogs(jobIDs...)
+	if err != nil {
+		return 0, fmt.Errorf("sweep logs of %d jobs in DB failed with error: %s", len(jobIDs), err)
+	}
+
+	return int(count), nil
+}
+
 // Duration for sweeping
 func (dbs *DBSweeper) Duration() int {
 	return dbs.duration
@@ -34,7 +34,7 @@ func TestMain(m *testing.M) {
 // TestDBGetter
 func TestDBGetter(t *testing.T) {
 	uuid := "uuid_for_unit_test_sweeper"
-	l, err := backend.NewDBLogger(uuid, "DEBUG", 4)
+	l, err := backend.NewDBLogger(uuid, "DEBUG", 4, nil)
 	require.Nil(t, err)
 
 	l.Debug("JobLog Debug: TestDBLoggerSweeper")
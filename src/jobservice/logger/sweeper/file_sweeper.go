@@ -64,6 +64,32 @@ func (fs *FileSweeper) Sweep() (int, error) {
 	return cleared, err
 }
 
+// SweepJobs sweeps the log files of the specified jobs
+func (fs *FileSweeper) SweepJobs(jobIDs ...string) (int, error) {
+	cleared := 0
+
+	// Record all errors
+	errs := make([]string, 0)
+	for _, jobID := range jobIDs {
+		logFilePath := path.Join(fs.workDir, fmt.Sprintf("%s.log", path.Base(jobID)))
+		if err := os.Remove(logFilePath); err != nil {
+			if !os.IsNotExist(err) {
+				errs = append(errs, fmt.Sprintf("remove log file '%s' error: %s", logFilePath, err))
+			}
+			continue // go on for next one
+		}
+
+		cleared++
+	}
+
+	var err error
+	if len(errs) > 0 {
+		err = fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+
+	return cleared, err
+}
+
 // Duration for sweeping
 func (fs *FileSweeper) Duration() int {
 	return fs.duration
@@ -43,3 +43,31 @@ func TestFileSweeper(t *testing.T) {
 		t.Errorf("expect count 1 but got %d", count)
 	}
 }
+
+// Test sweeping the log files of the specified jobs
+func TestFileSweeperSweepJobs(t *testing.T) {
+	workDir := path.Join(os.TempDir(), "job_logs_by_id")
+	if err := os.Mkdir(workDir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(workDir); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	logFile := path.Join(workDir, "TestFileSweeperSweepJobs.log")
+	if err := ioutil.WriteFile(logFile, []byte("hello"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	fs := NewFileSweeper(workDir, 5)
+	count, err := fs.SweepJobs("TestFileSweeperSweepJobs", "non_existing_job")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Errorf("expect count 1 but got %d", count)
+	}
+}
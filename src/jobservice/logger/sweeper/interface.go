@@ -11,3 +11,12 @@ type Interface interface {
 	// Return the sweeping duration with day unit.
 	Duration() int
 }
+
+// JobSweeper defines the operation of sweeping the log entries of the specified jobs on demand
+type JobSweeper interface {
+	// Sweep the log entries of the specified jobs
+	//
+	// If failed, an non-nil error will return
+	// If succeeded, count of sweepped log entries is returned
+	SweepJobs(jobIDs ...string) (int, error)
+}
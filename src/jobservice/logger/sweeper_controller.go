@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/goharbor/harbor/src/jobservice/logger/sweeper"
@@ -53,6 +54,27 @@ func (c *SweeperController) Sweep() (int, error) {
 	return 0, nil
 }
 
+// SweepJobs sweeps the log entries of the specified jobs with the sweepers which support it
+func (c *SweeperController) SweepJobs(jobIDs ...string) (int, error) {
+	count := 0
+	errs := make([]string, 0)
+	for _, s := range c.sweepers {
+		if js, ok := s.(sweeper.JobSweeper); ok {
+			cleared, err := js.SweepJobs(jobIDs...)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %s", reflect.TypeOf(s).String(), err))
+			}
+			count += cleared
+		}
+	}
+
+	if len(errs) > 0 {
+		return count, fmt.Errorf("sweep logs of jobs error: %s", strings.Join(errs, "; "))
+	}
+
+	return count, nil
+}
+
 // Duration = -1 for controller
 func (c *SweeperController) Duration() int {
 	return -1
@@ -86,8 +86,22 @@ type Manager interface {
 	// Returns:
 	//   Non nil error if any issues meet
 	SaveJob(job *job.Stats) error
+
+	// Purge the stats of the finished jobs matched with the query.
+	// The periodic jobs are not purged as they're kept with the periodic policies.
+	//
+	// Arguments:
+	//   q *query.Parameter: query parameters, the pagination is ignored
+	//
+	// Returns:
+	//   The IDs of the purged jobs
+	//   Non nil error if any issues meet
+	PurgeJobs(q *query.Parameter) ([]string, error)
 }
 
+// The batch size of scanning the job stats when purging jobs
+const purgeScanBatchSize = 500
+
 // basicManager is the default implementation of @manager,
 // based on redis.
 type basicManager struct {
@@ -126,46 +140,25 @@ func (bm *basicManager) GetJobs(q *query.Parameter) ([]*job.Stats, int64, error)
 		}
 	}
 
-	pattern := rds.KeyJobStats(bm.namespace, "*")
-	args := []interface{}{cursor, "MATCH", pattern, "COUNT", count}
-
 	conn := bm.pool.Get()
 	defer func() {
 		_ = conn.Close()
 	}()
 
-	values, err := redis.Values(conn.Do("SCAN", args...))
-	if err != nil {
-		return nil, 0, err
-	}
-	if len(values) != 2 {
-		return nil, 0, errors.New("malform scan results")
-	}
-
-	nextCur, err := strconv.ParseUint(string(values[0].([]byte)), 10, 8)
+	jobs, nextCur, err := bm.scanJobs(conn, cursor, count)
 	if err != nil {
 		return nil, 0, err
 	}
-	list := values[1].([]interface{})
 
+	// Filter the jobs of this batch
 	results := make([]*job.Stats, 0)
-	for _, v := range list {
-		if bytes, ok := v.([]byte); ok {
-			statsKey := string(bytes)
-			if i := strings.LastIndex(statsKey, ":"); i != -1 {
-				jID := statsKey[i+1:]
-				t := job.NewBasicTrackerWithID(bm.ctx, jID, bm.namespace, bm.pool, nil)
-				if err := t.Load(); err != nil {
-					logger.Errorf("retrieve stats data of job %s error: %s", jID, err)
-					continue
-				}
-
-				results = append(results, t.Job())
-			}
+	for _, j := range jobs {
+		if matchJob(j, q) {
+			results = append(results, j)
 		}
 	}
 
-	return results, int64(nextCur), nil
+	return results, nextCur, nil
 }
 
 // GetPeriodicExecution is implementation of Manager.GetPeriodicExecution
@@ -281,6 +274,10 @@ func (bm *basicManager) GetScheduledJobs(q *query.Parameter) ([]*job.Stats, int6
 			continue
 		}
 
+		if !matchJob(t.Job(), q) {
+			continue
+		}
+
 		res = append(res, t.Job())
 	}
 
@@ -311,6 +308,150 @@ func (bm *basicManager) SaveJob(j *job.Stats) error {
 	return t.Save()
 }
 
+// PurgeJobs is implementation of Manager.PurgeJobs
+func (bm *basicManager) PurgeJobs(q *query.Parameter) ([]string, error) {
+	conn := bm.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	purged := make([]string, 0)
+	// Deleting keys during the scan is safe, the keys existing all the time are still returned
+	cursor := int64(0)
+	for {
+		jobs, nextCur, err := bm.scanJobs(conn, cursor, purgeScanBatchSize)
+		if err != nil {
+			return purged, err
+		}
+
+		for _, j := range jobs {
+			// Only the finished non periodic jobs can be purged
+			if j.Info.JobKind == job.KindPeriodic ||
+				job.RunningStatus.Compare(job.Status(j.Info.Status)) >= 0 ||
+				!matchJob(j, q) {
+				continue
+			}
+
+			if err := bm.purgeJob(conn, j); err != nil {
+				return purged, err
+			}
+			purged = append(purged, j.Info.JobID)
+		}
+
+		if nextCur == 0 {
+			return purged, nil
+		}
+		cursor = nextCur
+	}
+}
+
+// scanJobs scans one batch of the job stats with the cursor.
+// The job stats and the next cursor are returned.
+func (bm *basicManager) scanJobs(conn redis.Conn, cursor int64, count uint) ([]*job.Stats, int64, error) {
+	pattern := rds.KeyJobStats(bm.namespace, "*")
+	args := []interface{}{cursor, "MATCH", pattern, "COUNT", count}
+
+	values, err := redis.Values(conn.Do("SCAN", args...))
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(values) != 2 {
+		return nil, 0, errors.New("malform scan results")
+	}
+
+	nextCur, err := strconv.ParseInt(string(values[0].([]byte)), 10, 64)
+	if err != nil {
+		return nil, 0, err
+	}
+	list := values[1].([]interface{})
+
+	results := make([]*job.Stats, 0)
+	for _, v := range list {
+		if bytes, ok := v.([]byte); ok {
+			statsKey := string(bytes)
+			if i := strings.LastIndex(statsKey, ":"); i != -1 {
+				jID := statsKey[i+1:]
+				t := job.NewBasicTrackerWithID(bm.ctx, jID, bm.namespace, bm.pool, nil)
+				if err := t.Load(); err != nil {
+					logger.Errorf("retrieve stats data of job %s error: %s", jID, err)
+					continue
+				}
+
+				results = append(results, t.Job())
+			}
+		}
+	}
+
+	return results, nextCur, nil
+}
+
+// purgeJob deletes the stats of the job and the references to it
+func (bm *basicManager) purgeJob(conn redis.Conn, j *job.Stats) error {
+	jobID := j.Info.JobID
+
+	if err := conn.Send("MULTI"); err != nil {
+		return err
+	}
+
+	keys := []interface{}{
+		rds.KeyJobStats(bm.namespace, jobID),
+		rds.KeyJobUpstreams(bm.namespace, jobID),
+		rds.KeyJobDependents(bm.namespace, jobID),
+	}
+	if err := conn.Send("DEL", keys...); err != nil {
+		return err
+	}
+
+	if !utils.IsEmptyStr(j.Info.UpstreamJobID) {
+		if err := conn.Send("ZREM", rds.KeyUpstreamJobAndExecutions(bm.namespace, j.Info.UpstreamJobID), jobID); err != nil {
+			return err
+		}
+	}
+
+	_, err := conn.Do("EXEC")
+
+	return err
+}
+
+// matchJob checks if the job matches the filters in the query
+func matchJob(j *job.Stats, q *query.Parameter) bool {
+	if q == nil || q.Extras == nil {
+		return true
+	}
+
+	if v, ok := q.Extras.Get(query.ExtraParamKeyKind); ok {
+		if kind, yes := v.(string); yes && kind != j.Info.JobKind {
+			return false
+		}
+	}
+
+	if v, ok := q.Extras.Get(query.ExtraParamKeyJobName); ok {
+		if name, yes := v.(string); yes && name != j.Info.JobName {
+			return false
+		}
+	}
+
+	if v, ok := q.Extras.Get(query.ExtraParamKeyJobStatus); ok {
+		if status, yes := v.(string); yes && status != j.Info.Status {
+			return false
+		}
+	}
+
+	if v, ok := q.Extras.Get(query.ExtraParamKeyStartTime); ok {
+		if start, yes := v.(int64); yes && j.Info.EnqueueTime < start {
+			return false
+		}
+	}
+
+	if v, ok := q.Extras.Get(query.ExtraParamKeyEndTime); ok {
+		if end, yes := v.(int64); yes && j.Info.EnqueueTime > end {
+			return false
+		}
+	}
+
+	return true
+}
+
 // queryExecutions queries periodic executions by status
 func queryExecutions(conn redis.Conn, dataKey string, q *query.Parameter) ([]string, int64, error) {
 	total, err := redis.Int64(conn.Do("ZCOUNT", dataKey, 0, "+inf"))
@@ -182,3 +182,51 @@ func (suite *BasicManagerTestSuite) TestSaveJob() {
 	err := suite.manager.SaveJob(newJob)
 	require.NoError(suite.T(), err)
 }
+
+// TestGetJobsWithFilters tests get jobs with filters
+func (suite *BasicManagerTestSuite) TestGetJobsWithFilters() {
+	extras := make(query.ExtraParameters)
+	extras.Set(query.ExtraParamKeyJobStatus, job.PendingStatus.String())
+	extras.Set(query.ExtraParamKeyKind, job.KindScheduled)
+
+	jobs, _, err := suite.manager.GetJobs(&query.Parameter{
+		PageSize:   25,
+		PageNumber: 1,
+		Extras:     extras,
+	})
+	require.NoError(suite.T(), err)
+	for _, j := range jobs {
+		assert.Equal(suite.T(), job.PendingStatus.String(), j.Info.Status)
+		assert.Equal(suite.T(), job.KindScheduled, j.Info.JobKind)
+	}
+}
+
+// TestPurgeJobs tests purging jobs
+func (suite *BasicManagerTestSuite) TestPurgeJobs() {
+	finished := &job.Stats{
+		Info: &job.StatsInfo{
+			JobID:       "1003",
+			JobKind:     job.KindGeneric,
+			JobName:     job.SampleJob,
+			Status:      job.SuccessStatus.String(),
+			EnqueueTime: time.Now().Add(-time.Hour).Unix(),
+		},
+	}
+	err := suite.manager.SaveJob(finished)
+	require.NoError(suite.T(), err)
+
+	extras := make(query.ExtraParameters)
+	extras.Set(query.ExtraParamKeyJobName, job.SampleJob)
+	extras.Set(query.ExtraParamKeyEndTime, time.Now().Unix())
+
+	purged, err := suite.manager.PurgeJobs(&query.Parameter{Extras: extras})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"1003"}, purged)
+
+	_, err = suite.manager.GetJob("1003")
+	assert.Error(suite.T(), err)
+
+	// The pending job is not purged
+	_, err = suite.manager.GetJob("1001")
+	assert.NoError(suite.T(), err)
+}
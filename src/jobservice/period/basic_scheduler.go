@@ -147,25 +147,11 @@ func (bs *basicScheduler) UnSchedule(policyID string) error {
 	}()
 
 	// Get the un-scheduling policy object
-	bytes, err := redis.Values(conn.Do("ZRANGEBYSCORE", rds.KeyPeriodicPolicy(bs.namespace), numericID, numericID))
+	p, err := bs.getPolicy(conn, policyID, numericID)
 	if err != nil {
 		return err
 	}
 
-	p := &Policy{}
-	if len(bytes) > 0 {
-		if rawPolicy, ok := bytes[0].([]byte); ok {
-			if err := p.DeSerialize(rawPolicy); err != nil {
-				return err
-			}
-		}
-	}
-
-	if utils.IsEmptyStr(p.ID) {
-		// Deserialize failed
-		return errors.Errorf("no valid periodic job policy found: %s:%d", policyID, numericID)
-	}
-
 	notification := &message{
 		Event: changeEventUnSchedule,
 		Data:  p,
@@ -240,6 +226,150 @@ func (bs *basicScheduler) UnSchedule(policyID string) error {
 	return err
 }
 
+// Pause is implementation of the same method in period.Interface
+func (bs *basicScheduler) Pause(policyID string) error {
+	return bs.setPaused(policyID, true)
+}
+
+// Resume is implementation of the same method in period.Interface
+func (bs *basicScheduler) Resume(policyID string) error {
+	return bs.setPaused(policyID, false)
+}
+
+// setPaused switches the paused flag of the policy and notifies the policy stores of all the nodes
+func (bs *basicScheduler) setPaused(policyID string, paused bool) error {
+	if utils.IsEmptyStr(policyID) {
+		return errors.New("bad periodic job ID: nil")
+	}
+
+	tracker, err := bs.ctl.Track(policyID)
+	if err != nil {
+		return err
+	}
+
+	numericID, err := tracker.NumericID()
+	if err != nil {
+		return err
+	}
+
+	conn := bs.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	p, err := bs.getPolicy(conn, policyID, numericID)
+	if err != nil {
+		return err
+	}
+
+	if p.Paused == paused {
+		// Already done
+		return nil
+	}
+
+	p.Paused = paused
+	if !paused {
+		p.ResumedAt = time.Now().Unix()
+	}
+
+	rawJSON, err := p.Serialize()
+	if err != nil {
+		return err
+	}
+
+	event := changeEventResume
+	if paused {
+		event = changeEventPause
+	}
+
+	msgJSON, err := json.Marshal(&message{
+		Event: event,
+		Data:  p,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Replace the policy with the same score and publish notification via redis transaction
+	err = conn.Send("MULTI")
+	err = conn.Send("ZREMRANGEBYSCORE", rds.KeyPeriodicPolicy(bs.namespace), numericID, numericID)
+	err = conn.Send("ZADD", rds.KeyPeriodicPolicy(bs.namespace), numericID, rawJSON)
+	err = conn.Send("PUBLISH", rds.KeyPeriodicNotification(bs.namespace), msgJSON)
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Do("EXEC"); err != nil {
+		return err
+	}
+
+	if err := tracker.Update("paused", paused); err != nil {
+		// Only logged as the flag in the stats is only for showing
+		logger.Errorf("Update paused flag of periodic job %s error: %s", policyID, err)
+	}
+
+	if !paused {
+		// Do not wait for the next round of enqueuing
+		bs.enqueuer.scheduleNextJobs(p, conn)
+		return nil
+	}
+
+	// Clear the executions which are scheduled but not running yet.
+	// This is a try best action, its failure will not cause the pause action failed.
+	eKey := rds.KeyUpstreamJobAndExecutions(bs.namespace, policyID)
+	eIDs, err := getPeriodicExecutions(conn, eKey)
+	if err != nil {
+		logger.Errorf("Get executions for periodic job %s error: %s", policyID, err)
+		return nil
+	}
+
+	for _, eID := range eIDs {
+		eTracker, err := bs.ctl.Track(eID)
+		if err != nil {
+			logger.Errorf("Track execution %s error: %s", eID, err)
+			continue
+		}
+
+		e := eTracker.Job()
+		if job.ScheduledStatus != job.Status(e.Info.Status) {
+			continue
+		}
+
+		if err := bs.client.DeleteScheduledJob(e.Info.RunAt, policyID); err != nil {
+			logger.Errorf("Delete scheduled job %s error: %s", eID, err)
+		}
+
+		if err := eTracker.Stop(); err != nil {
+			logger.Errorf("Stop execution %s error: %s", eID, err)
+		}
+	}
+
+	return nil
+}
+
+// getPolicy gets the policy with the numeric ID from the backend
+func (bs *basicScheduler) getPolicy(conn redis.Conn, policyID string, numericID int64) (*Policy, error) {
+	bytes, err := redis.Values(conn.Do("ZRANGEBYSCORE", rds.KeyPeriodicPolicy(bs.namespace), numericID, numericID))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Policy{}
+	if len(bytes) > 0 {
+		if rawPolicy, ok := bytes[0].([]byte); ok {
+			if err := p.DeSerialize(rawPolicy); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if utils.IsEmptyStr(p.ID) {
+		// Deserialize failed
+		return nil, errors.Errorf("no valid periodic job policy found: %s:%d", policyID, numericID)
+	}
+
+	return p, nil
+}
+
 // Clear all the dirty jobs
 // A scheduled job will be marked as dirty job only if the enqueued timestamp has expired a horizon.
 // This is a try best action
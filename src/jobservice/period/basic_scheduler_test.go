@@ -130,6 +130,16 @@ func (suite *BasicSchedulerTestSuite) TestScheduler() {
 	_, err = suite.lcmCtl.New(jobStats)
 	require.NoError(suite.T(), err, "lcm new: nil error expected but got %s", err)
 
+	err = suite.scheduler.Pause(p.ID)
+	require.NoError(suite.T(), err, "pause: nil error expected but got %s", err)
+
+	t, err := suite.lcmCtl.Track(p.ID)
+	require.NoError(suite.T(), err, "track: nil error expected but got %s", err)
+	assert.True(suite.T(), t.Job().Info.Paused, "expected periodic job paused")
+
+	err = suite.scheduler.Resume(p.ID)
+	require.NoError(suite.T(), err, "resume: nil error expected but got %s", err)
+
 	err = suite.scheduler.UnSchedule(p.ID)
 	require.NoError(suite.T(), err, "unschedule: nil error expected but got %s", err)
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"strconv"
 	"time"
 
 	"github.com/gocraft/work"
@@ -35,6 +36,11 @@ const (
 	enqueuerHorizon = 4 * time.Minute
 	neverExecuted   = 365 * 24 * time.Hour
 
+	// Only the executions missed within the duration are caught up
+	maxCatchUpDuration = 7 * 24 * time.Hour
+	// The max number of the missed executions caught up at one time
+	maxCatchUpExecutions = 100
+
 	// PeriodicExecutionMark marks the scheduled job to a periodic execution
 	PeriodicExecutionMark = "_job_kind_periodic_"
 )
@@ -165,8 +171,10 @@ func (e *enqueuer) enqueue() {
 
 // scheduleNextJobs schedules job for next time slots based on the policy
 func (e *enqueuer) scheduleNextJobs(p *Policy, conn redis.Conn) {
-	nowTime := time.Unix(time.Now().Unix(), 0)
-	horizon := nowTime.Add(enqueuerHorizon)
+	if p.Paused {
+		logger.Debugf("Periodic job policy %s:%s is paused, skip scheduling", p.JobName, p.ID)
+		return
+	}
 
 	schedule, err := cron.Parse(p.CronSpec)
 	if err != nil {
@@ -174,71 +182,167 @@ func (e *enqueuer) scheduleNextJobs(p *Policy, conn redis.Conn) {
 		// Just in cases, if error occurred, ignore it
 		e.lastEnqueueErr = err
 		logger.Errorf("Invalid corn spec in periodic policy %s %s: %s", p.JobName, p.ID, err)
-	} else {
-		for t := schedule.Next(nowTime); t.Before(horizon); t = schedule.Next(t) {
-			epoch := t.Unix()
-
-			// Clone parameters
-			// Add extra argument for job running too.
-			// Notes: Only for system using
-			wJobParams := cloneParameters(p.JobParameters, epoch)
-
-			// Create an execution (job) based on the periodic job template (policy)
-			j := &work.Job{
-				Name: p.JobName,
-				ID:   p.ID, // Use the ID of policy to avoid scheduling duplicated periodic job executions.
-
-				// This is technically wrong, but this lets the bytes be identical for the same periodic job instance.
-				// If we don't do this, we'd need to use a different approach -- probably giving each periodic job its own
-				// history of the past 100 periodic jobs, and only scheduling a job if it's not in the history.
-				EnqueuedAt: epoch,
-				// Pass parameters to scheduled job here
-				Args: wJobParams,
-			}
-
-			rawJSON, err := utils.SerializeJob(j)
-			if err != nil {
-				e.lastEnqueueErr = err
-				// Actually this error should not happen if the object struct is well defined
-				logger.Errorf("Serialize job object for periodic job %s error: %s", p.ID, err)
-				break
-			}
-
-			// Persistent execution first.
-			// Please pay attention that the job has not been really scheduled yet.
-			// If job data is failed to persistent, then job schedule should be abandoned.
-			execution := e.createExecution(p, epoch)
-			eTracker, err := e.ctl.New(execution)
-			if err != nil {
-				e.lastEnqueueErr = err
-				logger.Errorf("Save stats data of job execution '%s' error: %s", execution.Info.JobID, err)
-				break
-			}
-
-			// Put job to the scheduled job queue
-			_, err = conn.Do("ZADD", rds.RedisKeyScheduled(e.namespace), epoch, rawJSON)
-			if err != nil {
-				e.lastEnqueueErr = err
-				logger.Errorf("Put the execution of the periodic job '%s' to the scheduled job queue error: %s", p.ID, err)
-
-				// Mark job status to be error
-				// If this happened, the job stats is definitely becoming dirty data at job service side.
-				// For the consumer side, the retrying of web hook may fix the problem.
-				if err := eTracker.Fail(); err != nil {
-					e.lastEnqueueErr = err
-					logger.Errorf("Mark execution '%s' to failure status error: %s", execution.Info.JobID, err)
-				}
-
-				break // Probably redis connection is broken
-			}
-
-			logger.Debugf("Scheduled execution for periodic job %s:%s at %d", j.Name, p.ID, epoch)
+		return
+	}
+
+	loc, err := p.Location()
+	if err != nil {
+		e.lastEnqueueErr = err
+		logger.Errorf("Invalid time zone in periodic policy %s %s: %s", p.JobName, p.ID, err)
+		return
+	}
+
+	// The cron spec is evaluated in the location of the time passed to the schedule
+	nowTime := time.Unix(time.Now().Unix(), 0).In(loc)
+	horizon := nowTime.Add(enqueuerHorizon)
+
+	// The missed executions run right now
+	for _, epoch := range e.missedRuns(p, schedule, nowTime, conn) {
+		if err := e.scheduleExecution(p, epoch, nowTime.Unix(), conn); err != nil {
+			return
+		}
+
+		logger.Infof("Catch up missed execution of periodic job %s:%s at %d", p.JobName, p.ID, epoch)
+	}
+
+	for t := schedule.Next(nowTime); t.Before(horizon); t = schedule.Next(t) {
+		if err := e.scheduleExecution(p, t.Unix(), t.Unix(), conn); err != nil {
+			return
+		}
+	}
+}
+
+// missedRuns returns the run times of the executions missed while the jobservice is down
+// based on the catch-up policy of the periodic job
+func (e *enqueuer) missedRuns(p *Policy, schedule cron.Schedule, nowTime time.Time, conn redis.Conn) []int64 {
+	if p.CatchUp != job.CatchUpOnce && p.CatchUp != job.CatchUpAll {
+		return nil
+	}
+
+	// The missed ones are the ones after the latest execution picked up by the workers
+	key := rds.KeyUpstreamJobAndExecutions(e.namespace, p.ID)
+	values, err := redis.Strings(conn.Do("ZREVRANGEBYSCORE", key, nowTime.Unix(), "-inf", "WITHSCORES", "LIMIT", 0, maxCatchUpExecutions))
+	if err != nil {
+		logger.Errorf("Get the executions of periodic job %s error: %s", p.ID, err)
+		return nil
+	}
+	if len(values) == 0 {
+		// Never scheduled
+		return nil
+	}
+
+	var since int64
+	for i := 0; i+1 < len(values); i += 2 {
+		runAt, err := strconv.ParseInt(values[i+1], 10, 64)
+		if err != nil {
+			logger.Errorf("Bad run time of the execution %s: %s", values[i], values[i+1])
+			return nil
+		}
+
+		// The execution is still scheduled if the jobservice is down when it should run.
+		// The claimed one is postponed by the worker as its queue is busy, it's not missed.
+		since = runAt - 1
+		if t, err := e.ctl.Track(values[i]); err != nil ||
+			job.Status(t.Job().Info.Status) != job.ScheduledStatus ||
+			t.Job().Info.ClaimedAt > 0 {
+			since = runAt
+			break
+		}
+	}
+
+	if p.ResumedAt > since {
+		since = p.ResumedAt
+	}
+
+	// Avoid scheduling a flood of executions
+	if earliest := nowTime.Add(-maxCatchUpDuration).Unix(); since < earliest {
+		since = earliest
+	}
+
+	// The executions within the horizon are still in the scheduled job queue
+	// and run by the workers soon even if the jobservice has been down.
+	// The older ones are removed as dirty jobs when the jobservice is started.
+	deadline := nowTime.Add(-enqueuerHorizon)
+
+	runAts := make([]int64, 0)
+	for t := schedule.Next(time.Unix(since, 0).In(nowTime.Location())); !t.After(deadline); t = schedule.Next(t) {
+		runAts = append(runAts, t.Unix())
+		if len(runAts) > maxCatchUpExecutions {
+			runAts = runAts[1:]
 		}
 	}
+
+	if p.CatchUp == job.CatchUpOnce && len(runAts) > 1 {
+		// Only the latest one
+		runAts = runAts[len(runAts)-1:]
+	}
+
+	return runAts
+}
+
+// scheduleExecution schedules the execution of the periodic job identified by the epoch to run at the specified time
+func (e *enqueuer) scheduleExecution(p *Policy, epoch int64, runAt int64, conn redis.Conn) error {
+	// Clone parameters
+	// Add extra argument for job running too.
+	// Notes: Only for system using
+	wJobParams := CloneParameters(p.JobParameters, epoch)
+
+	// Create an execution (job) based on the periodic job template (policy)
+	j := &work.Job{
+		Name: p.JobName,
+		ID:   p.ID, // Use the ID of policy to avoid scheduling duplicated periodic job executions.
+
+		// This is technically wrong, but this lets the bytes be identical for the same periodic job instance.
+		// If we don't do this, we'd need to use a different approach -- probably giving each periodic job its own
+		// history of the past 100 periodic jobs, and only scheduling a job if it's not in the history.
+		EnqueuedAt: epoch,
+		// Pass parameters to scheduled job here
+		Args: wJobParams,
+	}
+
+	rawJSON, err := utils.SerializeJob(j)
+	if err != nil {
+		e.lastEnqueueErr = err
+		// Actually this error should not happen if the object struct is well defined
+		logger.Errorf("Serialize job object for periodic job %s error: %s", p.ID, err)
+		return err
+	}
+
+	// Persistent execution first.
+	// Please pay attention that the job has not been really scheduled yet.
+	// If job data is failed to persistent, then job schedule should be abandoned.
+	execution := CreateExecution(p, epoch)
+	eTracker, err := e.ctl.New(execution)
+	if err != nil {
+		e.lastEnqueueErr = err
+		logger.Errorf("Save stats data of job execution '%s' error: %s", execution.Info.JobID, err)
+		return err
+	}
+
+	// Put job to the scheduled job queue
+	_, err = conn.Do("ZADD", rds.RedisKeyScheduled(e.namespace), runAt, rawJSON)
+	if err != nil {
+		e.lastEnqueueErr = err
+		logger.Errorf("Put the execution of the periodic job '%s' to the scheduled job queue error: %s", p.ID, err)
+
+		// Mark job status to be error
+		// If this happened, the job stats is definitely becoming dirty data at job service side.
+		// For the consumer side, the retrying of web hook may fix the problem.
+		if err := eTracker.Fail(); err != nil {
+			e.lastEnqueueErr = err
+			logger.Errorf("Mark execution '%s' to failure status error: %s", execution.Info.JobID, err)
+		}
+
+		return err // Probably redis connection is broken
+	}
+
+	logger.Debugf("Scheduled execution for periodic job %s:%s at %d", j.Name, p.ID, runAt)
+
+	return nil
 }
 
-// createExecution creates execution object
-func (e *enqueuer) createExecution(p *Policy, runAt int64) *job.Stats {
+// CreateExecution creates the execution object of the periodic job policy running at the specified time
+func CreateExecution(p *Policy, runAt int64) *job.Stats {
 	eID := fmt.Sprintf("%s@%d", p.ID, runAt)
 
 	return &job.Stats{
@@ -308,7 +412,8 @@ func (e *enqueuer) shouldEnqueue() bool {
 	return false
 }
 
-func cloneParameters(params job.Parameters, epoch int64) job.Parameters {
+// CloneParameters clones the parameters of the periodic job policy and marks them with the execution epoch
+func CloneParameters(params job.Parameters, epoch int64) job.Parameters {
 	p := make(job.Parameters)
 
 	// Clone parameters to a new param map
@@ -29,6 +29,7 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/lcm"
 	"github.com/goharbor/harbor/src/jobservice/tests"
 	"github.com/gomodule/redigo/redis"
+	"github.com/robfig/cron"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -122,6 +123,69 @@ func (suite *EnqueuerTestSuite) TestEnqueuer() {
 	require.Nil(suite.T(), err, "enqueuer start: nil error expected but got %s", err)
 }
 
+// TestMissedRuns tests catching up the missed executions
+func (suite *EnqueuerTestSuite) TestMissedRuns() {
+	schedule, err := cron.Parse("0 * * * * *")
+	require.NoError(suite.T(), err, "parse cron: nil error expected but got %s", err)
+
+	p := &Policy{
+		ID:       "fake_policy_catch_up",
+		JobName:  job.SampleJob,
+		CronSpec: "0 * * * * *",
+		CatchUp:  job.CatchUpSkip,
+	}
+
+	conn := suite.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	nowTime := time.Unix(time.Now().Unix(), 0)
+	assert.Equal(suite.T(), 0, len(suite.enqueuer.missedRuns(p, schedule, nowTime, conn)), "expected no missed runs when never scheduled")
+
+	// The last execution is done 10 minutes ago
+	execution := CreateExecution(p, schedule.Next(nowTime.Add(-11*time.Minute)).Unix())
+	execution.Info.Status = job.SuccessStatus.String()
+	_, err = suite.enqueuer.ctl.New(execution)
+	require.NoError(suite.T(), err, "new execution: nil error expected but got %s", err)
+
+	assert.Equal(suite.T(), 0, len(suite.enqueuer.missedRuns(p, schedule, nowTime, conn)), "expected no missed runs for 'skip'")
+
+	p.CatchUp = job.CatchUpAll
+	runAts := suite.enqueuer.missedRuns(p, schedule, nowTime, conn)
+	require.Condition(suite.T(), func() bool {
+		return len(runAts) >= 5
+	}, "expected at least 5 missed runs for 'all' but got %d", len(runAts))
+	for _, runAt := range runAts {
+		assert.Condition(suite.T(), func() bool {
+			return runAt > execution.Info.RunAt && runAt <= nowTime.Add(-enqueuerHorizon).Unix()
+		}, "missed run %d is out of range", runAt)
+	}
+
+	p.CatchUp = job.CatchUpOnce
+	once := suite.enqueuer.missedRuns(p, schedule, nowTime, conn)
+	require.Equal(suite.T(), 1, len(once), "expected 1 missed run for 'once' but got %d", len(once))
+	assert.Equal(suite.T(), runAts[len(runAts)-1], once[0], "expected the latest missed run for 'once'")
+
+	// The scheduled execution postponed by the worker is claimed, so it's not missed
+	postponed := CreateExecution(p, schedule.Next(nowTime.Add(-7*time.Minute)).Unix())
+	t, err := suite.enqueuer.ctl.New(postponed)
+	require.NoError(suite.T(), err, "new execution: nil error expected but got %s", err)
+	err = t.Update("claimed_at", nowTime.Unix())
+	require.NoError(suite.T(), err, "claim execution: nil error expected but got %s", err)
+
+	p.CatchUp = job.CatchUpAll
+	for _, runAt := range suite.enqueuer.missedRuns(p, schedule, nowTime, conn) {
+		assert.Condition(suite.T(), func() bool {
+			return runAt > postponed.Info.RunAt
+		}, "missed run %d is not after the claimed execution", runAt)
+	}
+
+	// The ones missed before resuming are not caught up
+	p.ResumedAt = nowTime.Unix()
+	assert.Equal(suite.T(), 0, len(suite.enqueuer.missedRuns(p, schedule, nowTime, conn)), "expected no missed runs after resuming")
+}
+
 func (suite *EnqueuerTestSuite) prepare() {
 	now := time.Now()
 	minute := now.Minute()
@@ -24,6 +24,7 @@ import (
 
 	"github.com/goharbor/harbor/src/jobservice/common/rds"
 	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/logger"
 	"github.com/gomodule/redigo/redis"
 	"github.com/robfig/cron"
@@ -35,6 +36,10 @@ const (
 	changeEventSchedule = "Schedule"
 	// changeEventUnSchedule : UnSchedule periodic job policy event
 	changeEventUnSchedule = "UnSchedule"
+	// changeEventPause : Pause periodic job policy event
+	changeEventPause = "Pause"
+	// changeEventResume : Resume periodic job policy event
+	changeEventResume = "Resume"
 )
 
 // Policy ...
@@ -46,6 +51,14 @@ type Policy struct {
 	CronSpec      string                 `json:"cron_spec"`
 	JobParameters map[string]interface{} `json:"job_params,omitempty"`
 	WebHookURL    string                 `json:"web_hook_url,omitempty"`
+	// The time zone to evaluate the cron spec in, local time zone is used if it's empty
+	TimeZone string `json:"time_zone,omitempty"`
+	// The catch-up policy of the executions missed while the jobservice is down
+	CatchUp string `json:"catch_up,omitempty"`
+	// No executions are scheduled for the paused policy
+	Paused bool `json:"paused,omitempty"`
+	// The executions missed before the policy is resumed are not caught up
+	ResumedAt int64 `json:"resumed_at,omitempty"`
 }
 
 // Serialize the policy to raw data.
@@ -78,9 +91,26 @@ func (p *Policy) Validate() error {
 		return err
 	}
 
+	if _, err := p.Location(); err != nil {
+		return err
+	}
+
+	if !job.IsValidCatchUp(p.CatchUp) {
+		return fmt.Errorf("bad catch-up policy: %s", p.CatchUp)
+	}
+
 	return nil
 }
 
+// Location returns the location of the time zone which the cron spec is evaluated in
+func (p *Policy) Location() (*time.Location, error) {
+	if utils.IsEmptyStr(p.TimeZone) {
+		return time.Local, nil
+	}
+
+	return time.LoadLocation(p.TimeZone)
+}
+
 // policyStore is in-memory cache for the periodic job policies.
 type policyStore struct {
 	// k-v pair and key is the policy ID
@@ -239,6 +269,10 @@ func (ps *policyStore) sync(m *message) error {
 		if removed == nil {
 			return fmt.Errorf("failed to sync unscheduled policy %s", m.Data.ID)
 		}
+	case changeEventPause, changeEventResume:
+		if err := ps.update(m.Data); err != nil {
+			return fmt.Errorf("failed to sync %s policy %s: %s", strings.ToLower(m.Event), m.Data.ID, err)
+		}
 	default:
 		return fmt.Errorf("message %s is not supported", m.Event)
 	}
@@ -305,6 +339,21 @@ func (ps *policyStore) add(item *Policy) error {
 	return nil
 }
 
+// Update the existing policy with the new one
+func (ps *policyStore) update(item *Policy) error {
+	if item == nil {
+		return errors.New("nil policy to update")
+	}
+
+	if _, ok := ps.hash.Load(item.ID); !ok {
+		return fmt.Errorf("policy %s is not found", item.ID)
+	}
+
+	ps.hash.Store(item.ID, item)
+
+	return nil
+}
+
 // Iterate all the policies in the store
 func (ps *policyStore) Iterate(f func(id string, p *Policy) bool) {
 	ps.hash.Range(func(k, v interface{}) bool {
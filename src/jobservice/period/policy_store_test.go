@@ -132,6 +132,34 @@ func (suite *PolicyStoreTestSuite) TestLoad() {
 		return true
 	})
 	assert.Equal(suite.T(), 1, count, "expected 1 policies but got %d", count)
+
+	paused := *p
+	paused.Paused = true
+	err = suite.store.sync(&message{
+		Event: changeEventPause,
+		Data:  &paused,
+	})
+	assert.Nil(suite.T(), err, "sync pause: nil error expected but got %s", err)
+	suite.store.Iterate(func(id string, p *Policy) bool {
+		assert.True(suite.T(), p.Paused, "expected policy %s paused", id)
+		return true
+	})
+
+	err = suite.store.sync(&message{
+		Event: changeEventResume,
+		Data:  p,
+	})
+	assert.Nil(suite.T(), err, "sync resume: nil error expected but got %s", err)
+	suite.store.Iterate(func(id string, p *Policy) bool {
+		assert.False(suite.T(), p.Paused, "expected policy %s resumed", id)
+		return true
+	})
+
+	err = suite.store.sync(&message{
+		Event: changeEventPause,
+		Data:  p1,
+	})
+	assert.NotNil(suite.T(), err, "sync pause of removed policy: non nil error expected but got nil")
 }
 
 // TestPolicy tests policy itself
@@ -44,4 +44,22 @@ type Scheduler interface {
 	// Return:
 	//  error if failed to unschedule
 	UnSchedule(policyID string) error
+
+	// Pause the specified cron job policy.
+	// No executions are scheduled for the paused policy and
+	// the executions already scheduled but not running yet are stopped.
+	//
+	// policyID string: The ID of cron job policy.
+	//
+	// Return:
+	//  error if failed to pause
+	Pause(policyID string) error
+
+	// Resume the specified paused cron job policy.
+	//
+	// policyID string: The ID of cron job policy.
+	//
+	// Return:
+	//  error if failed to resume
+	Resume(policyID string) error
 }
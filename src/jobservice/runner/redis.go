@@ -127,7 +127,11 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 		// Switch job status based on the returned error.
 		// The err happened here should not override the job run error, just log it.
 		if err != nil {
-			if er := tracker.Fail(); er != nil {
+			markFailed := tracker.Fail
+			if rj.willRetry(runningJob, j) {
+				markFailed = tracker.FailForRetry
+			}
+			if er := markFailed(); er != nil {
 				logger.Errorf("Mark job status to failure error: %s", err)
 			}
 
@@ -144,6 +148,12 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 				markStopped = bp(true)
 				return
 			}
+
+			if latest == job.ErrorStatus {
+				// The job has been reaped as it ran out of the time limit
+				err = errors.Errorf("job %s:%s is done after it's marked as failed", j.Name, j.ID)
+				return
+			}
 		}
 
 		// Mark job status to success.
@@ -187,6 +197,20 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 	if err = tracker.Run(); err != nil {
 		return
 	}
+	// Set the time limit of the running job, so it's reaped even if it never reports
+	maxRuntime := runningJob.MaxRuntime()
+	if maxRuntime <= 0 {
+		maxRuntime = job.DefaultMaxRuntime
+	}
+	if er := tracker.Update("timeout_at", time.Now().Add(maxRuntime).Unix()); er != nil {
+		// Just log it
+		logger.Errorf("Set time limit of job %s:%s error: %s", j.Name, j.ID, er)
+	}
+	// Enroll the job to the running jobs, the heartbeat is refreshed when the job checks in or reports progress
+	if er := tracker.Heartbeat(); er != nil {
+		// Just log it
+		logger.Errorf("Heartbeat of job %s:%s error: %s", j.Name, j.ID, er)
+	}
 	// Run the job
 	err = runningJob.Run(execContext, j.Args)
 	// Handle retry
@@ -202,6 +226,19 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 	return
 }
 
+// willRetry checks whether the failed job is going to be retried by the worker,
+// the worker counts the failure and retries the job if it doesn't reach the max fails
+func (rj *RedisJob) willRetry(j job.Interface, wj *work.Job) bool {
+	if j == nil {
+		j = Wrap(rj.job)
+	}
+	if !j.ShouldRetry() {
+		return false
+	}
+
+	return wj.Fails+1 < int64(j.MaxFails())
+}
+
 func (rj *RedisJob) retry(j job.Interface, wj *work.Job) {
 	if !j.ShouldRetry() {
 		// Cancel retry immediately
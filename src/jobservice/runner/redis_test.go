@@ -140,6 +140,13 @@ func (suite *RedisRunnerTestSuite) TestJobWrapper() {
 	redisJob := NewRedisJob((*fakeParentJob)(nil), suite.envContext, suite.lcmCtl)
 	err := redisJob.Run(j)
 	require.NoError(suite.T(), err, "redis job: nil error expected but got %s", err)
+
+	// The job without its own max runtime is limited by the default one
+	t, err := suite.lcmCtl.Track("FAKE-j")
+	require.NoError(suite.T(), err)
+	assert.Condition(suite.T(), func() bool {
+		return t.Job().Info.TimeoutAt > time.Now().Add(job.DefaultMaxRuntime-time.Minute).Unix()
+	}, "expect the default time limit is applied")
 }
 
 // TestJobWrapperInvalidTracker tests job runner with invalid job ID
@@ -199,6 +206,10 @@ func (j *fakeParentJob) ShouldRetry() bool {
 	return false
 }
 
+func (j *fakeParentJob) MaxRuntime() time.Duration {
+	return 0
+}
+
 func (j *fakeParentJob) Validate(params job.Parameters) error {
 	return nil
 }
@@ -220,6 +231,10 @@ func (j *fakePanicJob) ShouldRetry() bool {
 	return false
 }
 
+func (j *fakePanicJob) MaxRuntime() time.Duration {
+	return 0
+}
+
 func (j *fakePanicJob) Validate(params job.Parameters) error {
 	return nil
 }
@@ -16,6 +16,7 @@ package runtime
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"os"
 	"os/signal"
@@ -33,6 +34,7 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/env"
 	"github.com/goharbor/harbor/src/jobservice/hook"
 	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/external"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/gc"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/notification"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/replication"
@@ -40,10 +42,12 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/job/impl/scan"
 	"github.com/goharbor/harbor/src/jobservice/lcm"
 	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/jobservice/logger/sweeper"
 	"github.com/goharbor/harbor/src/jobservice/mgt"
 	"github.com/goharbor/harbor/src/jobservice/migration"
 	"github.com/goharbor/harbor/src/jobservice/worker"
 	"github.com/goharbor/harbor/src/jobservice/worker/cworker"
+	"github.com/goharbor/harbor/src/jobservice/worker/pgworker"
 	"github.com/goharbor/harbor/src/pkg/retention"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 )
@@ -53,6 +57,8 @@ const (
 	healthCheckPeriod     = time.Minute
 	dialReadTimeout       = healthCheckPeriod + 10*time.Second
 	dialWriteTimeout      = 10 * time.Second
+	// Connections reserved for the periodic scheduler and the API requests
+	dbReservedConns = 5
 )
 
 // JobService ...
@@ -90,75 +96,94 @@ func (bs *Bootstrap) LoadAndRun(ctx context.Context, cancel context.CancelFunc)
 	// Alliance to config
 	cfg := config.DefaultConfig
 
+	// The DB logs are swept when purging jobs via API, make sure the DB is initialized before serving
+	for _, lc := range cfg.JobLoggerConfigs {
+		if lc.Name == logger.NameDB {
+			sweeper.WaitingDBInit()
+			break
+		}
+	}
+
 	var (
 		backendWorker worker.Interface
 		manager       mgt.Manager
 	)
-	if cfg.PoolConfig.Backend == config.JobServicePoolBackendRedis {
-		// Number of workers
-		workerNum := cfg.PoolConfig.WorkerCount
-		// Add {} to namespace to void slot issue
-		namespace := fmt.Sprintf("{%s}", cfg.PoolConfig.RedisPoolCfg.Namespace)
-		// Get redis connection pool
-		redisPool := bs.getRedisPool(cfg.PoolConfig.RedisPoolCfg)
-
-		// Do data migration if necessary
-		rdbMigrator := migration.New(redisPool, namespace)
-		rdbMigrator.Register(migration.PolicyMigratorFactory)
-		if err := rdbMigrator.Migrate(); err != nil {
-			// Just logged, should not block the starting process
-			logger.Error(err)
-		}
+	if cfg.PoolConfig.Backend != config.JobServicePoolBackendRedis &&
+		cfg.PoolConfig.Backend != config.JobServicePoolBackendPostgreSQL {
+		return errors.Errorf("worker backend '%s' is not supported", cfg.PoolConfig.Backend)
+	}
 
-		// Create stats manager
-		manager = mgt.NewManager(ctx, namespace, redisPool)
-		// Create hook agent, it's a singleton object
-		hookAgent := hook.NewAgent(rootContext, namespace, redisPool)
-		hookCallback := func(URL string, change *job.StatusChange) error {
-			msg := fmt.Sprintf("status change: job=%s, status=%s", change.JobID, change.Status)
-			if !utils.IsEmptyStr(change.CheckIn) {
-				msg = fmt.Sprintf("%s, check_in=%s", msg, change.CheckIn)
-			}
+	// Number of workers
+	workerNum := cfg.PoolConfig.WorkerCount
+	// Add {} to namespace to void slot issue
+	namespace := fmt.Sprintf("{%s}", cfg.PoolConfig.RedisPoolCfg.Namespace)
+	// Get redis connection pool, it keeps the job stats for all the backends
+	redisPool := bs.getRedisPool(cfg.PoolConfig.RedisPoolCfg)
+
+	// Do data migration if necessary
+	rdbMigrator := migration.New(redisPool, namespace)
+	rdbMigrator.Register(migration.PolicyMigratorFactory)
+	if err := rdbMigrator.Migrate(); err != nil {
+		// Just logged, should not block the starting process
+		logger.Error(err)
+	}
 
-			evt := &hook.Event{
-				URL:       URL,
-				Timestamp: time.Now().Unix(),
-				Data:      change,
-				Message:   msg,
-			}
+	// Create stats manager
+	manager = mgt.NewManager(ctx, namespace, redisPool)
+	// Create hook agent, it's a singleton object
+	hookAgent := hook.NewAgent(rootContext, namespace, redisPool)
+	hookCallback := func(URL string, change *job.StatusChange) error {
+		msg := fmt.Sprintf("status change: job=%s, status=%s", change.JobID, change.Status)
+		if !utils.IsEmptyStr(change.CheckIn) {
+			msg = fmt.Sprintf("%s, check_in=%s", msg, change.CheckIn)
+		}
 
-			return hookAgent.Trigger(evt)
+		evt := &hook.Event{
+			URL:       URL,
+			Timestamp: time.Now().Unix(),
+			Data:      change,
+			Message:   msg,
 		}
 
-		// Create job life cycle management controller
-		lcmCtl := lcm.NewController(rootContext, namespace, redisPool, hookCallback)
+		return hookAgent.Trigger(evt)
+	}
+
+	// Create job life cycle management controller
+	lcmCtl := lcm.NewController(rootContext, namespace, redisPool, hookCallback)
 
-		// Start the backend worker
+	// Start the backend worker
+	if cfg.PoolConfig.Backend == config.JobServicePoolBackendPostgreSQL {
+		backendWorker, err = bs.loadAndRunPostgreSQLWorkerPool(
+			rootContext,
+			workerNum,
+			cfg.PoolConfig.PostgreSQLPoolCfg,
+			lcmCtl,
+		)
+	} else {
 		backendWorker, err = bs.loadAndRunRedisWorkerPool(
 			rootContext,
 			namespace,
 			workerNum,
+			cfg.PoolConfig.Queues,
 			redisPool,
 			lcmCtl,
 		)
-		if err != nil {
-			return errors.Errorf("load and run worker error: %s", err)
-		}
+	}
+	if err != nil {
+		return errors.Errorf("load and run worker error: %s", err)
+	}
 
-		// Run daemon process of life cycle controller
-		// Ignore returned error
-		if err = lcmCtl.Serve(); err != nil {
-			return errors.Errorf("start life cycle controller error: %s", err)
-		}
+	// Run daemon process of life cycle controller
+	// Ignore returned error
+	if err = lcmCtl.Serve(); err != nil {
+		return errors.Errorf("start life cycle controller error: %s", err)
+	}
 
-		// Start agent
-		// Non blocking call
-		hookAgent.Attach(lcmCtl)
-		if err = hookAgent.Serve(); err != nil {
-			return errors.Errorf("start hook agent error: %s", err)
-		}
-	} else {
-		return errors.Errorf("worker backend '%s' is not supported", cfg.PoolConfig.Backend)
+	// Start agent
+	// Non blocking call
+	hookAgent.Attach(lcmCtl)
+	if err = hookAgent.Serve(); err != nil {
+		return errors.Errorf("start hook agent error: %s", err)
 	}
 
 	// Initialize controller
@@ -232,25 +257,13 @@ func (bs *Bootstrap) loadAndRunRedisWorkerPool(
 	ctx *env.Context,
 	ns string,
 	workers uint,
+	queues []*config.QueueConfig,
 	redisPool *redis.Pool,
 	lcmCtl lcm.Controller,
 ) (worker.Interface, error) {
-	redisWorker := cworker.NewWorker(ctx, ns, workers, redisPool, lcmCtl)
+	redisWorker := cworker.NewWorker(ctx, ns, workers, queues, redisPool, lcmCtl)
 	// Register jobs here
-	if err := redisWorker.RegisterJobs(
-		map[string]interface{}{
-			// Only for debugging and testing purpose
-			job.SampleJob: (*sample.Job)(nil),
-			// Functional jobs
-			job.ImageScanJob:           (*scan.ClairJob)(nil),
-			job.ImageScanAllJob:        (*scan.All)(nil),
-			job.ImageGC:                (*gc.GarbageCollector)(nil),
-			job.Replication:            (*replication.Replication)(nil),
-			job.ReplicationScheduler:   (*replication.Scheduler)(nil),
-			job.Retention:              (*retention.Job)(nil),
-			scheduler.JobNameScheduler: (*scheduler.PeriodicJob)(nil),
-			job.WebhookJob:             (*notification.WebhookJob)(nil),
-		}); err != nil {
+	if err := redisWorker.RegisterJobs(knownJobs()); err != nil {
 		// exit
 		return nil, err
 	}
@@ -262,6 +275,58 @@ func (bs *Bootstrap) loadAndRunRedisWorkerPool(
 	return redisWorker, nil
 }
 
+// Load and run the worker backed by the postgresql database
+func (bs *Bootstrap) loadAndRunPostgreSQLWorkerPool(
+	ctx *env.Context,
+	workers uint,
+	pgConfig *config.PostgreSQLPoolConfig,
+	lcmCtl lcm.Controller,
+) (worker.Interface, error) {
+	db, err := sql.Open("postgres", pgConfig.DataSourceName())
+	if err != nil {
+		return nil, err
+	}
+
+	// Reserve a connection for each worker to claim, renew and acknowledge its job
+	maxOpenConns := pgConfig.MaxOpenConns
+	if maxOpenConns <= 0 {
+		maxOpenConns = int(workers) + dbReservedConns
+	}
+	db.SetMaxOpenConns(maxOpenConns)
+
+	pgWorker := pgworker.NewWorker(ctx, workers, db, lcmCtl)
+	// Register jobs here
+	if err := pgWorker.RegisterJobs(knownJobs()); err != nil {
+		// exit
+		return nil, err
+	}
+
+	if err := pgWorker.Start(); err != nil {
+		return nil, err
+	}
+
+	return pgWorker, nil
+}
+
+// knownJobs returns the jobs registered to the worker
+func knownJobs() map[string]interface{} {
+	return map[string]interface{}{
+		// Only for debugging and testing purpose
+		job.SampleJob: (*sample.Job)(nil),
+		// Functional jobs
+		job.ImageScanJob:           (*scan.Job)(nil),
+		job.ImageScanAllJob:        (*scan.All)(nil),
+		job.ImageRescanJob:         (*scan.Rescan)(nil),
+		job.ImageGC:                (*gc.GarbageCollector)(nil),
+		job.Replication:            (*replication.Replication)(nil),
+		job.ReplicationScheduler:   (*replication.Scheduler)(nil),
+		job.Retention:              (*retention.Job)(nil),
+		scheduler.JobNameScheduler: (*scheduler.PeriodicJob)(nil),
+		job.WebhookJob:             (*notification.WebhookJob)(nil),
+		job.ExternalJob:            (*external.Job)(nil),
+	}
+}
+
 // Get a redis connection pool
 func (bs *Bootstrap) getRedisPool(redisPoolConfig *config.RedisPoolConfig) *redis.Pool {
 	return &redis.Pool{
@@ -16,9 +16,11 @@
 package tests
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
@@ -31,6 +33,11 @@ const (
 	dialWriteTimeout      = 10 * time.Second
 	testingRedisHost      = "REDIS_HOST"
 	testingNamespace      = "testing_job_service_v2"
+	testingPGHost         = "POSTGRESQL_HOST"
+	testingPGPort         = "POSTGRESQL_PORT"
+	testingPGUser         = "POSTGRESQL_USR"
+	testingPGPassword     = "POSTGRESQL_PWD"
+	testingPGDatabase     = "POSTGRESQL_DATABASE"
 )
 
 // GiveMeRedisPool ...
@@ -54,6 +61,25 @@ func GiveMeRedisPool() *redis.Pool {
 	return redisPool
 }
 
+// GiveMePostgreSQLDB returns the database handle of the postgresql for testing,
+// the lib/pq driver should be registered by the caller
+func GiveMePostgreSQLDB() (*sql.DB, error) {
+	port, err := strconv.Atoi(os.Getenv(testingPGPort))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", testingPGPort, err)
+	}
+
+	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+		os.Getenv(testingPGHost),
+		port,
+		os.Getenv(testingPGUser),
+		os.Getenv(testingPGPassword),
+		os.Getenv(testingPGDatabase),
+	)
+
+	return sql.Open("postgres", dsn)
+}
+
 // GiveMeTestNamespace ...
 func GiveMeTestNamespace() string {
 	return testingNamespace
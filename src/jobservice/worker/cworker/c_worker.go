@@ -16,12 +16,16 @@ package cworker
 
 import (
 	"fmt"
+	"os"
 	"reflect"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/gocraft/work"
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
 	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/config"
 	"github.com/goharbor/harbor/src/jobservice/env"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/lcm"
@@ -42,6 +46,8 @@ const (
 	workerPoolStatusDead         = "Dead"
 	pingRedisMaxTimes            = 10
 	defaultWorkerCount      uint = 10
+	// The delay to put the job back when its queue is running the max number of jobs
+	queueBusyPostponeSeconds = 10
 )
 
 // basicWorker is the worker implementation based on gocraft/work powered by redis.
@@ -58,6 +64,10 @@ type basicWorker struct {
 	// key is name of known job
 	// value is the type of known job
 	knownJobs *sync.Map
+
+	// key is name of the job, the job not in any named queue is put to the default queue
+	queues       map[string]*jobQueue
+	defaultQueue *jobQueue
 }
 
 // workerContext ...
@@ -76,7 +86,14 @@ func (rpc *workerContext) logJob(job *work.Job, next work.NextMiddlewareFunc) er
 }
 
 // NewWorker is constructor of worker
-func NewWorker(ctx *env.Context, namespace string, workerCount uint, redisPool *redis.Pool, ctl lcm.Controller) worker.Interface {
+func NewWorker(
+	ctx *env.Context,
+	namespace string,
+	workerCount uint,
+	queues []*config.QueueConfig,
+	redisPool *redis.Pool,
+	ctl lcm.Controller,
+) worker.Interface {
 	wc := defaultWorkerCount
 	if workerCount > 0 {
 		wc = workerCount
@@ -92,6 +109,12 @@ func NewWorker(ctx *env.Context, namespace string, workerCount uint, redisPool *
 		ctl:       ctl,
 		context:   ctx,
 		knownJobs: new(sync.Map),
+		queues:    newJobQueues(queues),
+		defaultQueue: &jobQueue{
+			name:     config.DefaultQueueName,
+			priority: defaultQueuePriority,
+			jobNames: make([]string, 0),
+		},
 	}
 }
 
@@ -115,6 +138,9 @@ func (w *basicWorker) Start() error {
 		return errors.New("missing job life cycle controller")
 	}
 
+	// Launch the waiting jobs once their upstream jobs are done
+	w.ctl.SetLauncher(w.launch)
+
 	// Test the redis connection
 	if err := w.ping(); err != nil {
 		return err
@@ -202,6 +228,31 @@ func (w *basicWorker) Enqueue(jobName string, params job.Parameters, isUnique bo
 	return generateResult(j, job.KindGeneric, isUnique, params, webHook), nil
 }
 
+// EnqueueAfter job
+func (w *basicWorker) EnqueueAfter(jobName string, params job.Parameters, upstreamJobIDs []string, webHook string) (*job.Stats, error) {
+	j := &work.Job{
+		Name:       jobName,
+		ID:         utils.MakeIdentifier(),
+		EnqueuedAt: time.Now().Unix(),
+		Args:       params,
+	}
+
+	res := generateResult(j, job.KindGeneric, false, params, webHook)
+	res.Info.DependsOn = upstreamJobIDs
+
+	// Save the stats of the waiting job before tracking the upstream jobs
+	// as it may be launched immediately if the upstream jobs are already succeeded
+	if _, err := w.ctl.New(res); err != nil {
+		return nil, err
+	}
+
+	if err := w.ctl.Depend(j.ID, upstreamJobIDs); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // Schedule job
 func (w *basicWorker) Schedule(jobName string, params job.Parameters, runAfterSeconds uint64, isUnique bool, webHook string) (*job.Stats, error) {
 	var (
@@ -237,13 +288,15 @@ func (w *basicWorker) Schedule(jobName string, params job.Parameters, runAfterSe
 }
 
 // PeriodicallyEnqueue job
-func (w *basicWorker) PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, isUnique bool, webHook string) (*job.Stats, error) {
+func (w *basicWorker) PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, timeZone string, catchUp string, isUnique bool, webHook string) (*job.Stats, error) {
 	p := &period.Policy{
 		ID:            utils.MakeIdentifier(),
 		JobName:       jobName,
 		CronSpec:      cronSetting,
 		JobParameters: params,
 		WebHookURL:    webHook,
+		TimeZone:      timeZone,
+		CatchUp:       catchUp,
 	}
 
 	id, err := w.scheduler.Schedule(p)
@@ -258,6 +311,8 @@ func (w *basicWorker) PeriodicallyEnqueue(jobName string, params job.Parameters,
 			Status:      job.ScheduledStatus.String(),
 			JobKind:     job.KindPeriodic,
 			CronSpec:    cronSetting,
+			TimeZone:    timeZone,
+			CatchUp:     catchUp,
 			WebHookURL:  webHook,
 			NumericPID:  id,
 			EnqueueTime: time.Now().Unix(),
@@ -278,7 +333,10 @@ func (w *basicWorker) Stats() (*worker.Stats, error) {
 		return nil, err
 	}
 
-	// Find the heartbeat of this worker via pid
+	// Find the heartbeat of this worker via host and pid,
+	// the queue stats are local so they're only attached to the pool of this worker
+	host, _ := os.Hostname()
+	pid := os.Getpid()
 	stats := make([]*worker.StatsData, 0)
 	for _, hb := range hbs {
 		if hb.HeartbeatAt == 0 {
@@ -297,6 +355,9 @@ func (w *basicWorker) Stats() (*worker.Stats, error) {
 			Concurrency:  hb.Concurrency,
 			Status:       wPoolStatus,
 		}
+		if hb.Host == host && hb.Pid == pid {
+			stat.Queues = w.queueStats()
+		}
 		stats = append(stats, stat)
 	}
 
@@ -344,6 +405,24 @@ func (w *basicWorker) StopJob(jobID string) error {
 	}
 }
 
+// PauseJob pauses the periodic job
+func (w *basicWorker) PauseJob(jobID string) error {
+	if utils.IsEmptyStr(jobID) {
+		return errors.New("empty job ID to pause")
+	}
+
+	return w.scheduler.Pause(jobID)
+}
+
+// ResumeJob resumes the paused periodic job
+func (w *basicWorker) ResumeJob(jobID string) error {
+	if utils.IsEmptyStr(jobID) {
+		return errors.New("empty job ID to resume")
+	}
+
+	return w.scheduler.Resume(jobID)
+}
+
 // RetryJob retry the job
 func (w *basicWorker) RetryJob(jobID string) error {
 	return errors.New("not implemented")
@@ -364,6 +443,12 @@ func (w *basicWorker) ValidateJobParameters(jobType interface{}, params job.Para
 	return theJ.Validate(params)
 }
 
+// ValidateCatchUp ...
+// All the catch-up policies are supported by the periodic enqueuer.
+func (w *basicWorker) ValidateCatchUp(catchUp string) error {
+	return nil
+}
+
 // RegisterJob is used to register the job to the worker.
 // j is the type of job
 func (w *basicWorker) registerJob(name string, j interface{}) (err error) {
@@ -397,6 +482,13 @@ func (w *basicWorker) registerJob(name string, j interface{}) (err error) {
 		return
 	}
 
+	// Find the queue of the job
+	q, ok := w.queues[name]
+	if !ok {
+		q = w.defaultQueue
+		q.jobNames = append(q.jobNames, name)
+	}
+
 	// Wrap job
 	redisJob := runner.NewRedisJob(j, w.context, w.ctl)
 	// Get more info from j
@@ -405,11 +497,18 @@ func (w *basicWorker) registerJob(name string, j interface{}) (err error) {
 	w.pool.JobWithOptions(
 		name,
 		work.JobOptions{
+			Priority: q.priority,
 			MaxFails: theJ.MaxFails(),
 			SkipDead: true,
 		},
 		// Use generic handler to handle as we do not accept context with this way.
 		func(job *work.Job) error {
+			// Do not occupy the worker if the queue is running the max number of jobs
+			if !q.acquire() {
+				return w.postpone(job, q.name)
+			}
+			defer q.release()
+
 			return redisJob.Run(job)
 		},
 	)
@@ -421,6 +520,87 @@ func (w *basicWorker) registerJob(name string, j interface{}) (err error) {
 	return nil
 }
 
+// postpone puts the job back to the scheduled queue to run it later
+func (w *basicWorker) postpone(j *work.Job, queueName string) error {
+	rawJSON, err := utils.SerializeJob(j)
+	if err != nil {
+		return err
+	}
+
+	conn := w.redisPool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// Mark the periodic execution as claimed before putting it back,
+	// otherwise it looks like a missed one to the catch-up of the periodic enqueuer
+	if epoch, ok := j.Args[period.PeriodicExecutionMark]; ok {
+		eID := fmt.Sprintf("%s@%v", j.ID, epoch)
+		t, err := w.ctl.Track(eID)
+		if err != nil {
+			return err
+		}
+		if err := t.Update("claimed_at", time.Now().Unix()); err != nil {
+			return err
+		}
+	}
+
+	runAt := time.Now().Unix() + queueBusyPostponeSeconds
+	if _, err := conn.Do("ZADD", rds.RedisKeyScheduled(w.namespace), runAt, rawJSON); err != nil {
+		return err
+	}
+
+	logger.Debugf("Job %s:%s is postponed to %d as queue %s is busy", j.Name, j.ID, runAt, queueName)
+
+	return nil
+}
+
+// queueStats returns the stats of the named queues and the default queue
+func (w *basicWorker) queueStats() []*worker.QueueStats {
+	stats := make([]*worker.QueueStats, 0)
+	visited := make(map[string]bool)
+
+	for _, q := range w.queues {
+		if visited[q.name] {
+			continue
+		}
+		visited[q.name] = true
+
+		stats = append(stats, q.stats())
+	}
+
+	// Keep the output stable
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Name < stats[j].Name
+	})
+
+	return append(stats, w.defaultQueue.stats())
+}
+
+// launch puts the waiting job to the queue with its existing ID
+func (w *basicWorker) launch(stats *job.Stats) error {
+	j := &work.Job{
+		Name:       stats.Info.JobName,
+		ID:         stats.Info.JobID,
+		EnqueuedAt: time.Now().Unix(),
+		Args:       stats.Info.Parameters,
+	}
+
+	rawJSON, err := utils.SerializeJob(j)
+	if err != nil {
+		return err
+	}
+
+	conn := w.redisPool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_, err = conn.Do("LPUSH", rds.RedisKeyJobs(w.namespace, j.Name), rawJSON)
+
+	return err
+}
+
 // Ping the redis server
 func (w *basicWorker) ping() error {
 	conn := w.redisPool.Get()
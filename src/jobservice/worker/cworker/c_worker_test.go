@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/config"
 	"github.com/goharbor/harbor/src/jobservice/env"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/lcm"
@@ -71,7 +72,15 @@ func (suite *CWorkerTestSuite) SetupSuite() {
 		func(hookURL string, change *job.StatusChange) error { return nil },
 	)
 
-	suite.cWorker = NewWorker(envCtx, suite.namespace, 5, suite.pool, suite.lcmCtl)
+	queues := []*config.QueueConfig{
+		{
+			Name:           "long_run",
+			Priority:       10,
+			MaxConcurrency: 1,
+			Jobs:           []string{"fake_long_run_job"},
+		},
+	}
+	suite.cWorker = NewWorker(envCtx, suite.namespace, 5, queues, suite.pool, suite.lcmCtl)
 	err := suite.cWorker.RegisterJobs(map[string]interface{}{
 		"fake_job":          (*fakeJob)(nil),
 		"fake_long_run_job": (*fakeLongRunJob)(nil),
@@ -163,6 +172,8 @@ func (suite *CWorkerTestSuite) TestEnqueuePeriodicJob() {
 		"fake_job",
 		params,
 		fmt.Sprintf("10 %d * * * *", m+2),
+		"",
+		"",
 		false,
 		"http://fake-hook.com:8080",
 	)
@@ -175,6 +186,26 @@ func (suite *CWorkerTestSuite) TestWorkerStats() {
 	stats, err := suite.cWorker.Stats()
 	require.NoError(suite.T(), err, "worker stats: nil error expected but got %s", err)
 	assert.Equal(suite.T(), 1, len(stats.Pools), "expected 1 pool but got 0")
+
+	queues := stats.Pools[0].Queues
+	require.Equal(suite.T(), 2, len(queues), "expected 2 queues but got %d", len(queues))
+	assert.Equal(suite.T(), "long_run", queues[0].Name)
+	assert.Equal(suite.T(), uint(1), queues[0].MaxConcurrency)
+	assert.Equal(suite.T(), config.DefaultQueueName, queues[1].Name)
+	assert.Equal(suite.T(), []string{"fake_job"}, queues[1].JobNames)
+}
+
+// TestJobQueue tests the concurrency limit of job queue
+func (suite *CWorkerTestSuite) TestJobQueue() {
+	q := &jobQueue{
+		name:           "limited",
+		maxConcurrency: 1,
+	}
+
+	assert.True(suite.T(), q.acquire(), "expect running slot acquired")
+	assert.False(suite.T(), q.acquire(), "expect no running slot left")
+	q.release()
+	assert.True(suite.T(), q.acquire(), "expect running slot acquired after releasing")
 }
 
 // TestStopJob test stop job
@@ -229,6 +260,10 @@ func (j *fakeJob) ShouldRetry() bool {
 	return false
 }
 
+func (j *fakeJob) MaxRuntime() time.Duration {
+	return 0
+}
+
 func (j *fakeJob) Validate(params job.Parameters) error {
 	if p, ok := params["name"]; ok {
 		if p == "testing:v1" || p == "testing:v2" {
@@ -256,6 +291,10 @@ func (j *fakeLongRunJob) ShouldRetry() bool {
 	return false
 }
 
+func (j *fakeLongRunJob) MaxRuntime() time.Duration {
+	return 0
+}
+
 func (j *fakeLongRunJob) Validate(params job.Parameters) error {
 	if p, ok := params["name"]; ok {
 		if p == "testing:v1" || p == "testing:v2" {
@@ -0,0 +1,96 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cworker
+
+import (
+	"sync/atomic"
+
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/worker"
+)
+
+const (
+	// The default priority of the job queue
+	defaultQueuePriority uint = 1
+)
+
+// jobQueue is the named queue which the jobs are put to.
+// The jobs of the queue share the priority and the concurrency limit.
+type jobQueue struct {
+	name           string
+	priority       uint
+	maxConcurrency uint
+	jobNames       []string
+	// count of the running jobs of the queue on this node
+	running int32
+}
+
+// newJobQueues creates the job queues from the configurations,
+// the returned map is keyed by the job name
+func newJobQueues(queueConfigs []*config.QueueConfig) map[string]*jobQueue {
+	queues := make(map[string]*jobQueue)
+
+	for _, qc := range queueConfigs {
+		q := &jobQueue{
+			name:           qc.Name,
+			priority:       qc.Priority,
+			maxConcurrency: qc.MaxConcurrency,
+			jobNames:       qc.Jobs,
+		}
+		if q.priority == 0 {
+			q.priority = defaultQueuePriority
+		}
+
+		for _, jobName := range qc.Jobs {
+			queues[jobName] = q
+		}
+	}
+
+	return queues
+}
+
+// acquire takes a running slot of the queue,
+// false is returned if the queue is running the max number of jobs
+func (q *jobQueue) acquire() bool {
+	if q.maxConcurrency == 0 {
+		return true
+	}
+
+	if atomic.AddInt32(&q.running, 1) > int32(q.maxConcurrency) {
+		atomic.AddInt32(&q.running, -1)
+		return false
+	}
+
+	return true
+}
+
+// release gives back the running slot taken by acquire
+func (q *jobQueue) release() {
+	if q.maxConcurrency == 0 {
+		return
+	}
+
+	atomic.AddInt32(&q.running, -1)
+}
+
+// stats returns the settings of the queue
+func (q *jobQueue) stats() *worker.QueueStats {
+	return &worker.QueueStats{
+		Name:           q.name,
+		Priority:       q.priority,
+		MaxConcurrency: q.maxConcurrency,
+		JobNames:       q.jobNames,
+	}
+}
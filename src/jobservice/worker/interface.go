@@ -44,6 +44,18 @@ type Interface interface {
 	//  error      : if failed to enqueue
 	Enqueue(jobName string, params job.Parameters, isUnique bool, webHook string) (*job.Stats, error)
 
+	// Enqueue the job after all the upstream jobs succeed.
+	//
+	// jobName string           : the name of enqueuing job
+	// params job.Parameters    : parameters of enqueuing job
+	// upstreamJobIDs []string  : IDs of the jobs which the enqueuing job depends on
+	// webHook string           : the server URL to receive hook events
+	//
+	// Returns:
+	//  *job.Stats : the stats of the waiting job if succeed
+	//  error      : if failed to enqueue
+	EnqueueAfter(jobName string, params job.Parameters, upstreamJobIDs []string, webHook string) (*job.Stats, error)
+
 	// Schedule job to run after the specified interval (seconds).
 	//
 	// jobName string         : the name of enqueuing job
@@ -62,13 +74,15 @@ type Interface interface {
 	// jobName string        : the name of enqueuing job
 	// params job.Parameters : parameters of enqueuing job
 	// cronSetting string    : the periodic duration with cron style like '0 * * * * *'
+	// timeZone string       : the time zone to evaluate the cron setting in, local time zone if it's empty
+	// catchUp string        : the catch-up policy of the executions missed while the jobservice is down
 	// isUnique bool         : specify if duplicated job will be discarded
 	// webHook string        : the server URL to receive hook events
 	//
 	// Returns:
 	//  models.JobStats: the stats of enqueuing job if succeed
 	//  error          : if failed to enqueue
-	PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, isUnique bool, webHook string) (*job.Stats, error)
+	PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, timeZone string, catchUp string, isUnique bool, webHook string) (*job.Stats, error)
 
 	// Return the status info of the worker.
 	//
@@ -96,6 +110,14 @@ type Interface interface {
 
 	ValidateJobParameters(jobType interface{}, params job.Parameters) error
 
+	// Check if the catch-up policy of the periodic job is supported by the worker
+	//
+	// catchUp string : the catch-up policy of the periodic job
+	//
+	// Return:
+	//  error if the catch-up policy is not supported
+	ValidateCatchUp(catchUp string) error
+
 	// Stop the job
 	//
 	// jobID string : ID of the enqueued job
@@ -104,6 +126,22 @@ type Interface interface {
 	//  error           : error returned if meet any problems
 	StopJob(jobID string) error
 
+	// Pause the periodic job
+	//
+	// jobID string : ID of the periodic job
+	//
+	// Return:
+	//  error           : error returned if meet any problems
+	PauseJob(jobID string) error
+
+	// Resume the paused periodic job
+	//
+	// jobID string : ID of the periodic job
+	//
+	// Return:
+	//  error           : error returned if meet any problems
+	ResumeJob(jobID string) error
+
 	// Retry the job
 	//
 	// jobID string : ID of the enqueued job
@@ -27,4 +27,14 @@ type StatsData struct {
 	JobNames     []string `json:"job_names"`
 	Concurrency  uint     `json:"concurrency"`
 	Status       string   `json:"status"`
+	// The named queues of the jobs served by the worker pool
+	Queues []*QueueStats `json:"queues,omitempty"`
+}
+
+// QueueStats represents the settings of the named job queue.
+type QueueStats struct {
+	Name           string   `json:"name"`
+	Priority       uint     `json:"priority"`
+	MaxConcurrency uint     `json:"max_concurrency"`
+	JobNames       []string `json:"job_names"`
 }
@@ -0,0 +1,633 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgworker
+
+import (
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gocraft/work"
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/env"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/lcm"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/jobservice/period"
+	"github.com/goharbor/harbor/src/jobservice/runner"
+	"github.com/goharbor/harbor/src/jobservice/worker"
+	// register the postgresql driver
+	_ "github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+const (
+	workerPoolStatusHealthy      = "Healthy"
+	pingDBMaxTimes               = 10
+	defaultWorkerCount      uint = 10
+	// Waiting interval when no job is ready in the queue
+	fetchInterval = 2 * time.Second
+	// The claimed job is visible to the workers again if its claim is not renewed in this duration
+	claimLease = 5 * time.Minute
+	// The interval of renewing the claims of the running jobs
+	claimRenewInterval = time.Minute
+)
+
+// pgWorker is the worker implementation based on the job queue persisted in postgresql.
+type pgWorker struct {
+	db          *sql.DB
+	queue       *queue
+	context     *env.Context
+	scheduler   period.Scheduler
+	ctl         lcm.Controller
+	workerCount uint
+	poolID      string
+	startedAt   int64
+
+	// key is name of known job
+	// value is the type of known job
+	knownJobs *sync.Map
+	// key is name of known job
+	// value is the *jobHandler of known job
+	handlers *sync.Map
+}
+
+// jobHandler handles the queued jobs with the same name
+type jobHandler struct {
+	runner   *runner.RedisJob
+	maxFails uint
+}
+
+// NewWorker is constructor of worker
+func NewWorker(ctx *env.Context, workerCount uint, db *sql.DB, ctl lcm.Controller) worker.Interface {
+	wc := defaultWorkerCount
+	if workerCount > 0 {
+		wc = workerCount
+	}
+
+	q := &queue{db: db}
+
+	return &pgWorker{
+		db:          db,
+		queue:       q,
+		context:     ctx,
+		scheduler:   newScheduler(ctx.SystemContext, db, q, ctl),
+		ctl:         ctl,
+		workerCount: wc,
+		poolID:      utils.MakeIdentifier(),
+		knownJobs:   new(sync.Map),
+		handlers:    new(sync.Map),
+	}
+}
+
+// Start to serve
+// Unblock action
+func (w *pgWorker) Start() error {
+	if w.db == nil {
+		return errors.New("missing postgresql database")
+	}
+
+	if w.context == nil || w.context.SystemContext == nil {
+		// report and exit
+		return errors.New("missing context")
+	}
+
+	if w.ctl == nil {
+		return errors.New("missing job life cycle controller")
+	}
+
+	// Launch the waiting jobs once their upstream jobs are done
+	w.ctl.SetLauncher(w.launch)
+
+	// Test the database connection
+	if err := w.ping(); err != nil {
+		return err
+	}
+
+	// Start the periodic scheduler
+	w.context.WG.Add(1)
+	go func() {
+		defer func() {
+			w.context.WG.Done()
+		}()
+		// Blocking call
+		if err := w.scheduler.Start(); err != nil {
+			w.context.ErrorChan <- err
+		}
+	}()
+
+	// Keep the claims of the running jobs
+	w.context.WG.Add(1)
+	go w.renewClaims()
+
+	// Start the workers fetching jobs from the queue
+	for i := uint(0); i < w.workerCount; i++ {
+		w.context.WG.Add(1)
+		go w.loop()
+	}
+
+	w.startedAt = time.Now().Unix()
+	logger.Infof("PostgreSQL worker is started")
+
+	return nil
+}
+
+// RegisterJobs is used to register multiple jobs to worker.
+func (w *pgWorker) RegisterJobs(jobs map[string]interface{}) error {
+	if jobs == nil || len(jobs) == 0 {
+		// Do nothing
+		return nil
+	}
+
+	for name, j := range jobs {
+		if err := w.registerJob(name, j); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Enqueue job
+func (w *pgWorker) Enqueue(jobName string, params job.Parameters, isUnique bool, webHook string) (*job.Stats, error) {
+	j, err := w.push(jobName, params, 0, isUnique)
+	if err != nil {
+		return nil, err
+	}
+
+	return generateResult(j, job.KindGeneric, isUnique, params, webHook), nil
+}
+
+// EnqueueAfter job
+func (w *pgWorker) EnqueueAfter(jobName string, params job.Parameters, upstreamJobIDs []string, webHook string) (*job.Stats, error) {
+	j := &work.Job{
+		Name:       jobName,
+		ID:         utils.MakeIdentifier(),
+		EnqueuedAt: time.Now().Unix(),
+		Args:       params,
+	}
+
+	res := generateResult(j, job.KindGeneric, false, params, webHook)
+	res.Info.DependsOn = upstreamJobIDs
+
+	// Save the stats of the waiting job before tracking the upstream jobs
+	// as it may be launched immediately if the upstream jobs are already succeeded
+	if _, err := w.ctl.New(res); err != nil {
+		return nil, err
+	}
+
+	if err := w.ctl.Depend(j.ID, upstreamJobIDs); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Schedule job
+func (w *pgWorker) Schedule(jobName string, params job.Parameters, runAfterSeconds uint64, isUnique bool, webHook string) (*job.Stats, error) {
+	j, err := w.push(jobName, params, runAfterSeconds, isUnique)
+	if err != nil {
+		return nil, err
+	}
+
+	res := generateResult(j, job.KindScheduled, isUnique, params, webHook)
+	res.Info.RunAt = j.EnqueuedAt + int64(runAfterSeconds)
+	res.Info.Status = job.ScheduledStatus.String()
+
+	return res, nil
+}
+
+// PeriodicallyEnqueue job
+func (w *pgWorker) PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, timeZone string, catchUp string, isUnique bool, webHook string) (*job.Stats, error) {
+	if err := w.ValidateCatchUp(catchUp); err != nil {
+		return nil, err
+	}
+
+	p := &period.Policy{
+		ID:            utils.MakeIdentifier(),
+		JobName:       jobName,
+		CronSpec:      cronSetting,
+		JobParameters: params,
+		WebHookURL:    webHook,
+		TimeZone:      timeZone,
+		CatchUp:       catchUp,
+	}
+
+	id, err := w.scheduler.Schedule(p)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &job.Stats{
+		Info: &job.StatsInfo{
+			JobID:       p.ID,
+			JobName:     jobName,
+			Status:      job.ScheduledStatus.String(),
+			JobKind:     job.KindPeriodic,
+			CronSpec:    cronSetting,
+			TimeZone:    timeZone,
+			CatchUp:     catchUp,
+			WebHookURL:  webHook,
+			NumericPID:  id,
+			EnqueueTime: time.Now().Unix(),
+			UpdateTime:  time.Now().Unix(),
+			RefLink:     fmt.Sprintf("/api/v1/jobs/%s", p.ID),
+			Parameters:  params,
+		},
+	}
+
+	return res, nil
+}
+
+// Stats of worker
+// Only the worker pool of this node is reported as the pools of other nodes are not tracked
+func (w *pgWorker) Stats() (*worker.Stats, error) {
+	if w.startedAt == 0 {
+		return nil, errors.New("failed to get stats of worker pools: worker is not started")
+	}
+
+	jobNames := w.jobNames()
+	sort.Strings(jobNames)
+
+	return &worker.Stats{
+		Pools: []*worker.StatsData{
+			{
+				WorkerPoolID: w.poolID,
+				StartedAt:    w.startedAt,
+				HeartbeatAt:  time.Now().Unix(),
+				JobNames:     jobNames,
+				Concurrency:  w.workerCount,
+				Status:       workerPoolStatusHealthy,
+			},
+		},
+	}, nil
+}
+
+// StopJob will stop the job
+func (w *pgWorker) StopJob(jobID string) error {
+	if utils.IsEmptyStr(jobID) {
+		return errors.New("empty job ID to stop")
+	}
+
+	t, err := w.ctl.Track(jobID)
+	if err != nil {
+		return err
+	}
+
+	if job.RunningStatus.Compare(job.Status(t.Job().Info.Status)) < 0 {
+		// Job has been in the final states
+		return errors.Errorf("mismatch job status for stopping job: %s, job status %s is behind %s", jobID, t.Job().Info.Status, job.RunningStatus)
+	}
+
+	switch t.Job().Info.JobKind {
+	case job.KindGeneric:
+		return t.Stop()
+	case job.KindScheduled:
+		// we need to delete the scheduled job in the queue if it is not running yet
+		// otherwise, stop it.
+		// The ID of the queued execution of periodic job is the ID of the policy.
+		qID := jobID
+		if !utils.IsEmptyStr(t.Job().Info.UpstreamJobID) {
+			qID = t.Job().Info.UpstreamJobID
+		}
+		if removed, err := w.queue.remove(qID, t.Job().Info.RunAt); err != nil || len(removed) == 0 {
+			// Job is already running?
+			logger.Errorf("scheduled job %s (run at = %d) is not found in the queue to stop, is it already running?", jobID, t.Job().Info.RunAt)
+		}
+		// Anyway, mark jon stopped
+		return t.Stop()
+	case job.KindPeriodic:
+		return w.scheduler.UnSchedule(jobID)
+	default:
+		return errors.Errorf("job kind %s is not supported", t.Job().Info.JobKind)
+	}
+}
+
+// PauseJob pauses the periodic job
+func (w *pgWorker) PauseJob(jobID string) error {
+	if utils.IsEmptyStr(jobID) {
+		return errors.New("empty job ID to pause")
+	}
+
+	return w.scheduler.Pause(jobID)
+}
+
+// ResumeJob resumes the paused periodic job
+func (w *pgWorker) ResumeJob(jobID string) error {
+	if utils.IsEmptyStr(jobID) {
+		return errors.New("empty job ID to resume")
+	}
+
+	return w.scheduler.Resume(jobID)
+}
+
+// RetryJob puts the failed job back to the queue to run it again
+func (w *pgWorker) RetryJob(jobID string) error {
+	if utils.IsEmptyStr(jobID) {
+		return errors.New("empty job ID to retry")
+	}
+
+	t, err := w.ctl.Track(jobID)
+	if err != nil {
+		return err
+	}
+
+	if job.Status(t.Job().Info.Status) != job.ErrorStatus {
+		return errors.Errorf("mismatch job status for retrying job: %s, job status %s is not %s", jobID, t.Job().Info.Status, job.ErrorStatus)
+	}
+
+	// The periodic executions are retried by the next scheduling
+	if t.Job().Info.JobKind == job.KindPeriodic {
+		return errors.Errorf("periodic job %s can not be retried", jobID)
+	}
+
+	// Reset the status to pending before queueing, otherwise the runner treats it as a failed one
+	if err := t.Reset(); err != nil {
+		return err
+	}
+
+	return w.launch(t.Job())
+}
+
+// IsKnownJob ...
+func (w *pgWorker) IsKnownJob(name string) (interface{}, bool) {
+	return w.knownJobs.Load(name)
+}
+
+// ValidateJobParameters ...
+func (w *pgWorker) ValidateJobParameters(jobType interface{}, params job.Parameters) error {
+	if jobType == nil {
+		return errors.New("nil job type")
+	}
+
+	theJ := runner.Wrap(jobType)
+	return theJ.Validate(params)
+}
+
+// ValidateCatchUp ...
+// The missed executions are not caught up by the PostgreSQL scheduler, only skipping them is supported.
+func (w *pgWorker) ValidateCatchUp(catchUp string) error {
+	if catchUp == job.CatchUpOnce || catchUp == job.CatchUpAll {
+		return errors.Errorf("catch-up policy %s is not supported by the PostgreSQL backend, only '%s' is supported", catchUp, job.CatchUpSkip)
+	}
+
+	return nil
+}
+
+// push puts the job to the queue
+func (w *pgWorker) push(jobName string, params job.Parameters, runAfterSeconds uint64, isUnique bool) (*work.Job, error) {
+	j := &work.Job{
+		Name:       jobName,
+		ID:         utils.MakeIdentifier(),
+		EnqueuedAt: time.Now().Unix(),
+		Args:       params,
+	}
+
+	// As the job is declared to be unique,
+	// check the uniqueness of the job,
+	// Here we only need to make sure only 1 job with the same type and parameters in the queue
+	// For the uniqueness of executing, it can be checked in the running stage
+	var key string
+	if isUnique {
+		var err error
+		if key, err = uniqueKey(jobName, params); err != nil {
+			return nil, err
+		}
+	}
+
+	ok, err := w.queue.push(w.db, j, j.EnqueuedAt+int64(runAfterSeconds), key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("job '%s' can not be enqueued, please check the job metatdata", jobName)
+	}
+
+	return j, nil
+}
+
+// launch puts the waiting job to the queue with its existing ID
+func (w *pgWorker) launch(stats *job.Stats) error {
+	j := &work.Job{
+		Name:       stats.Info.JobName,
+		ID:         stats.Info.JobID,
+		EnqueuedAt: time.Now().Unix(),
+		Args:       stats.Info.Parameters,
+	}
+
+	_, err := w.queue.push(w.db, j, j.EnqueuedAt, "")
+
+	return err
+}
+
+// loop fetches and handles the jobs until the system context is done
+func (w *pgWorker) loop() {
+	defer w.context.WG.Done()
+
+	for {
+		select {
+		case <-w.context.SystemContext.Done():
+			return
+		default:
+		}
+
+		qj, err := w.queue.fetch(time.Now().Unix(), w.jobNames(), w.poolID)
+		if err != nil {
+			logger.Errorf("Fetch job from queue error: %s", err)
+		}
+
+		if qj == nil {
+			// wait for a while or be terminated
+			select {
+			case <-time.After(fetchInterval):
+			case <-w.context.SystemContext.Done():
+				return
+			}
+
+			continue
+		}
+
+		w.process(qj)
+	}
+}
+
+// process runs the queued job and updates the queue based on the result
+func (w *pgWorker) process(qj *queuedJob) {
+	j := qj.job
+
+	jobCopy := *j
+	// as the args may contain sensitive information, ignore them when logging the detail
+	jobCopy.Args = nil
+	jobInfo, _ := utils.SerializeJob(&jobCopy)
+	logger.Infof("Job incoming: %s", jobInfo)
+
+	v, ok := w.handlers.Load(j.Name)
+	if !ok {
+		// Should not happen as only the known jobs are fetched
+		logger.Errorf("No handler found for job %s:%s", j.Name, j.ID)
+		if er := w.queue.release(qj); er != nil {
+			logger.Errorf("Release job %s:%s error: %s", j.Name, j.ID, er)
+		}
+		return
+	}
+	h := v.(*jobHandler)
+
+	err := h.runner.Run(j)
+	if err == nil {
+		if er := w.queue.ack(qj); er != nil {
+			logger.Errorf("Remove job %s:%s from queue error: %s", j.Name, j.ID, er)
+		}
+		return
+	}
+
+	now := time.Now().Unix()
+	j.Fails++
+	j.LastErr = err.Error()
+	j.FailedAt = now
+
+	if j.Fails < int64(h.maxFails) {
+		if er := w.queue.retry(qj, now+backoff(j.Fails)); er != nil {
+			logger.Errorf("Put job %s:%s back to queue for retrying error: %s", j.Name, j.ID, er)
+		}
+		return
+	}
+
+	// Dead job is discarded
+	if er := w.queue.ack(qj); er != nil {
+		logger.Errorf("Remove dead job %s:%s from queue error: %s", j.Name, j.ID, er)
+	}
+}
+
+// renewClaims renews the claims of the jobs running in this pool until the system context is done
+func (w *pgWorker) renewClaims() {
+	defer w.context.WG.Done()
+
+	ticker := time.NewTicker(claimRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.queue.renew(w.poolID, time.Now().Unix()); err != nil {
+				logger.Errorf("Renew claims of running jobs error: %s", err)
+			}
+		case <-w.context.SystemContext.Done():
+			return
+		}
+	}
+}
+
+// RegisterJob is used to register the job to the worker.
+// j is the type of job
+func (w *pgWorker) registerJob(name string, j interface{}) (err error) {
+	if utils.IsEmptyStr(name) || j == nil {
+		return errors.New("job can not be registered with empty name or nil interface")
+	}
+
+	// j must be job.Interface
+	if _, ok := j.(job.Interface); !ok {
+		return errors.Errorf("job must implement the job.Interface: %s", reflect.TypeOf(j).String())
+	}
+
+	// 1:1 constraint
+	if jInList, ok := w.knownJobs.Load(name); ok {
+		return fmt.Errorf("job name %s has been already registered with %s", name, reflect.TypeOf(jInList).String())
+	}
+
+	// Same job implementation can be only registered with one name
+	w.knownJobs.Range(func(jName interface{}, jInList interface{}) bool {
+		jobImpl := reflect.TypeOf(j).String()
+		if reflect.TypeOf(jInList).String() == jobImpl {
+			err = errors.Errorf("job %s has been already registered with name %s", jobImpl, jName)
+			return false
+		}
+
+		return true
+	})
+
+	// Something happened in the range
+	if err != nil {
+		return
+	}
+
+	w.handlers.Store(name, &jobHandler{
+		runner:   runner.NewRedisJob(j, w.context, w.ctl),
+		maxFails: runner.Wrap(j).MaxFails(),
+	})
+	// Keep the name of registered jobs as known jobs for future validation
+	w.knownJobs.Store(name, j)
+
+	logger.Infof("Register job %s with name %s", reflect.TypeOf(j).String(), name)
+
+	return nil
+}
+
+func (w *pgWorker) jobNames() []string {
+	names := make([]string, 0)
+	w.knownJobs.Range(func(name interface{}, _ interface{}) bool {
+		names = append(names, name.(string))
+		return true
+	})
+
+	return names
+}
+
+// Ping the database
+func (w *pgWorker) ping() error {
+	var err error
+	for count := 1; count <= pingDBMaxTimes; count++ {
+		if err = w.db.Ping(); err == nil {
+			return nil
+		}
+
+		time.Sleep(time.Duration(count+4) * time.Second)
+	}
+
+	return fmt.Errorf("connect to postgresql server timeout: %s", err.Error())
+}
+
+// backoff returns the seconds to wait before retrying the job, it's same with the one of gocraft/work
+func backoff(fails int64) int64 {
+	return fails*fails*fails*fails + 15 + int64(rand.Intn(30))*(fails+1)
+}
+
+// generate the job stats data
+func generateResult(
+	j *work.Job,
+	jobKind string,
+	isUnique bool,
+	jobParameters job.Parameters,
+	webHook string,
+) *job.Stats {
+	return &job.Stats{
+		Info: &job.StatsInfo{
+			JobID:       j.ID,
+			JobName:     j.Name,
+			JobKind:     jobKind,
+			IsUnique:    isUnique,
+			Status:      job.PendingStatus.String(),
+			EnqueueTime: j.EnqueuedAt,
+			UpdateTime:  time.Now().Unix(),
+			RefLink:     fmt.Sprintf("/api/v1/jobs/%s", j.ID),
+			Parameters:  jobParameters,
+			WebHookURL:  webHook,
+		},
+	}
+}
@@ -0,0 +1,372 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgworker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gocraft/work"
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/env"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/lcm"
+	"github.com/goharbor/harbor/src/jobservice/tests"
+	"github.com/goharbor/harbor/src/jobservice/worker"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// PGWorkerTestSuite tests functions of postgresql worker
+type PGWorkerTestSuite struct {
+	suite.Suite
+
+	pgWorker worker.Interface
+	lcmCtl   lcm.Controller
+
+	namespace string
+	pool      *redis.Pool
+	db        *sql.DB
+
+	cancel  context.CancelFunc
+	context *env.Context
+}
+
+// SetupSuite prepares test suite
+func (suite *PGWorkerTestSuite) SetupSuite() {
+	suite.namespace = tests.GiveMeTestNamespace()
+	suite.pool = tests.GiveMeRedisPool()
+
+	db, err := tests.GiveMePostgreSQLDB()
+	require.NoError(suite.T(), err, "open postgresql: nil error expected but got %s", err)
+	suite.db = db
+
+	// Append node ID
+	vCtx := context.WithValue(context.Background(), utils.NodeID, utils.GenerateNodeID())
+	// Create the root context
+	ctx, cancel := context.WithCancel(vCtx)
+	suite.cancel = cancel
+
+	envCtx := &env.Context{
+		SystemContext: ctx,
+		WG:            new(sync.WaitGroup),
+		ErrorChan:     make(chan error, 1),
+	}
+	suite.context = envCtx
+
+	suite.lcmCtl = lcm.NewController(
+		envCtx,
+		suite.namespace,
+		suite.pool,
+		func(hookURL string, change *job.StatusChange) error { return nil },
+	)
+
+	suite.pgWorker = NewWorker(envCtx, 5, suite.db, suite.lcmCtl)
+	err = suite.pgWorker.RegisterJobs(map[string]interface{}{
+		"fake_job":          (*fakeJob)(nil),
+		"fake_long_run_job": (*fakeLongRunJob)(nil),
+	})
+	require.NoError(suite.T(), err, "register jobs: nil error expected but got %s", err)
+
+	err = suite.pgWorker.Start()
+	require.NoError(suite.T(), err, "start postgresql worker: nil error expected but got %s", err)
+}
+
+// TearDownSuite clears the test suite
+func (suite *PGWorkerTestSuite) TearDownSuite() {
+	suite.cancel()
+
+	suite.context.WG.Wait()
+
+	conn := suite.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_ = tests.ClearAll(suite.namespace, conn)
+
+	_, _ = suite.db.Exec(`DELETE FROM job_queue`)
+	_, _ = suite.db.Exec(`DELETE FROM job_periodic_policy`)
+	_ = suite.db.Close()
+}
+
+// TestPGWorkerTestSuite is entry fo go test
+func TestPGWorkerTestSuite(t *testing.T) {
+	suite.Run(t, new(PGWorkerTestSuite))
+}
+
+// TestRegisterJobs ...
+func (suite *PGWorkerTestSuite) TestRegisterJobs() {
+	_, ok := suite.pgWorker.IsKnownJob("fake_job")
+	assert.EqualValues(suite.T(), true, ok, "expected known job but registering 'fake_job' appears to have failed")
+
+	params := make(map[string]interface{})
+	params["name"] = "testing:v1"
+	err := suite.pgWorker.ValidateJobParameters((*fakeJob)(nil), params)
+	assert.NoError(suite.T(), err, "validate parameters: nil error expected but got %s", err)
+}
+
+// TestEnqueueJob tests enqueue job and waits for it to be done
+func (suite *PGWorkerTestSuite) TestEnqueueJob() {
+	params := make(job.Parameters)
+	params["name"] = "testing:v1"
+
+	stats, err := suite.pgWorker.Enqueue("fake_job", params, false, "")
+	require.NoError(suite.T(), err, "enqueue job: nil error expected but got %s", err)
+	t, err := suite.lcmCtl.New(stats)
+	require.NoError(suite.T(), err, "lcm: nil error expected but got %s", err)
+
+	suite.waitForStatus(t, job.SuccessStatus)
+
+	var count int
+	err = suite.db.QueryRow(`SELECT COUNT(*) FROM job_queue WHERE job_id = $1`, stats.Info.JobID).Scan(&count)
+	require.NoError(suite.T(), err, "count queued jobs: nil error expected but got %s", err)
+	assert.Equal(suite.T(), 0, count, "expected job removed from queue but it's still there")
+}
+
+// TestEnqueueUniqueJob tests enqueue unique job
+func (suite *PGWorkerTestSuite) TestEnqueueUniqueJob() {
+	params := make(job.Parameters)
+	params["name"] = "testing:v2"
+
+	// Schedule it later to keep it in the queue
+	stats, err := suite.pgWorker.Schedule("fake_job", params, 120, true, "http://fake-hook.com:8080")
+	require.NoError(suite.T(), err, "schedule unique job: nil error expected but got %s", err)
+	_, err = suite.lcmCtl.New(stats)
+	require.NoError(suite.T(), err, "lcm: nil error expected but got %s", err)
+
+	_, err = suite.pgWorker.Enqueue("fake_job", params, true, "http://fake-hook.com:8080")
+	assert.Error(suite.T(), err, "enqueue duplicated unique job: non nil error expected but got nil")
+
+	err = suite.pgWorker.StopJob(stats.Info.JobID)
+	assert.NoError(suite.T(), err, "stop job: nil error expected but got %s", err)
+}
+
+// TestScheduleJob tests schedule job
+func (suite *PGWorkerTestSuite) TestScheduleJob() {
+	params := make(job.Parameters)
+	params["name"] = "testing:v1"
+
+	runAt := time.Now().Unix() + 1
+	stats, err := suite.pgWorker.Schedule("fake_job", params, 1, false, "")
+	require.NoError(suite.T(), err, "schedule job: nil error expected but got %s", err)
+	require.Condition(suite.T(), func() bool {
+		return runAt <= stats.Info.RunAt
+	}, "expect returned 'RunAt' should be >= '%d' but seems not", runAt)
+	t, err := suite.lcmCtl.New(stats)
+	require.NoError(suite.T(), err, "lcm: nil error expected but got %s", err)
+
+	suite.waitForStatus(t, job.SuccessStatus)
+}
+
+// TestEnqueuePeriodicJob tests periodic job
+func (suite *PGWorkerTestSuite) TestEnqueuePeriodicJob() {
+	params := make(job.Parameters)
+	params["name"] = "testing:v1"
+
+	m := time.Now().Minute()
+	if m+2 >= 60 {
+		m = m - 2
+	}
+	stats, err := suite.pgWorker.PeriodicallyEnqueue(
+		"fake_job",
+		params,
+		fmt.Sprintf("10 %d * * * *", m+2),
+		"",
+		"",
+		false,
+		"http://fake-hook.com:8080",
+	)
+	require.NoError(suite.T(), err, "periodic job: nil error expected but got %s", err)
+	_, err = suite.lcmCtl.New(stats)
+	require.NoError(suite.T(), err, "lcm: nil error expected but got %s", err)
+
+	var count int
+	err = suite.db.QueryRow(`SELECT COUNT(*) FROM job_periodic_policy WHERE policy_id = $1`, stats.Info.JobID).Scan(&count)
+	require.NoError(suite.T(), err, "count policies: nil error expected but got %s", err)
+	assert.Equal(suite.T(), 1, count, "expected 1 periodic policy but got %d", count)
+
+	err = suite.pgWorker.StopJob(stats.Info.JobID)
+	require.NoError(suite.T(), err, "stop periodic job: nil error expected but got %s", err)
+
+	err = suite.db.QueryRow(`SELECT COUNT(*) FROM job_queue WHERE job_id = $1`, stats.Info.JobID).Scan(&count)
+	require.NoError(suite.T(), err, "count queued executions: nil error expected but got %s", err)
+	assert.Equal(suite.T(), 0, count, "expected no queued executions but got %d", count)
+
+	// The missed executions are not caught up
+	assert.NoError(suite.T(), suite.pgWorker.ValidateCatchUp(job.CatchUpSkip))
+	assert.Error(suite.T(), suite.pgWorker.ValidateCatchUp(job.CatchUpOnce))
+	_, err = suite.pgWorker.PeriodicallyEnqueue("fake_job", params, "10 * * * * *", "", job.CatchUpAll, false, "")
+	assert.Error(suite.T(), err, "periodic job with catch-up: non nil error expected but got nil")
+}
+
+// TestRetryJob tests retrying the failed job
+func (suite *PGWorkerTestSuite) TestRetryJob() {
+	params := make(job.Parameters)
+	params["name"] = "testing:v1"
+
+	stats := generateResult(&work.Job{
+		Name:       "fake_job",
+		ID:         utils.MakeIdentifier(),
+		EnqueuedAt: time.Now().Unix(),
+		Args:       params,
+	}, job.KindGeneric, false, params, "")
+	t, err := suite.lcmCtl.New(stats)
+	require.NoError(suite.T(), err, "lcm: nil error expected but got %s", err)
+
+	err = suite.pgWorker.RetryJob(stats.Info.JobID)
+	assert.Error(suite.T(), err, "retry pending job: non nil error expected but got nil")
+
+	err = t.Fail()
+	require.NoError(suite.T(), err, "fail job: nil error expected but got %s", err)
+
+	err = suite.pgWorker.RetryJob(stats.Info.JobID)
+	require.NoError(suite.T(), err, "retry failed job: nil error expected but got %s", err)
+
+	suite.waitForStatus(t, job.SuccessStatus)
+}
+
+// TestWorkerStats tests worker stats
+func (suite *PGWorkerTestSuite) TestWorkerStats() {
+	stats, err := suite.pgWorker.Stats()
+	require.NoError(suite.T(), err, "worker stats: nil error expected but got %s", err)
+	assert.Equal(suite.T(), 1, len(stats.Pools), "expected 1 pool but got 0")
+}
+
+// TestStopJob test stop job
+func (suite *PGWorkerTestSuite) TestStopJob() {
+	// Stop generic job
+	params := make(map[string]interface{})
+	params["name"] = "testing:v1"
+
+	genericJob, err := suite.pgWorker.Enqueue("fake_long_run_job", params, false, "")
+	require.NoError(suite.T(), err, "enqueue job: nil error expected but got %s", err)
+	t, err := suite.lcmCtl.New(genericJob)
+	require.NoError(suite.T(), err, "new job stats: nil error expected but got %s", err)
+
+	suite.waitForStatus(t, job.RunningStatus)
+
+	// The running job is claimed by the pool without keeping a transaction open
+	var claimedBy string
+	err = suite.db.QueryRow(`SELECT claimed_by FROM job_queue WHERE job_id = $1`, genericJob.Info.JobID).Scan(&claimedBy)
+	require.NoError(suite.T(), err, "get claimer of running job: nil error expected but got %s", err)
+	assert.Equal(suite.T(), suite.pgWorker.(*pgWorker).poolID, claimedBy, "expected running job claimed by the pool")
+
+	err = suite.pgWorker.StopJob(genericJob.Info.JobID)
+	require.NoError(suite.T(), err, "stop job: nil error expected but got %s", err)
+
+	// Stop scheduled job
+	scheduledJob, err := suite.pgWorker.Schedule("fake_long_run_job", params, 120, false, "")
+	require.NoError(suite.T(), err, "schedule job: nil error expected but got %s", err)
+	_, err = suite.lcmCtl.New(scheduledJob)
+	require.NoError(suite.T(), err, "new job stats: nil error expected but got %s", err)
+
+	err = suite.pgWorker.StopJob(scheduledJob.Info.JobID)
+	require.NoError(suite.T(), err, "stop job: nil error expected but got %s", err)
+}
+
+func (suite *PGWorkerTestSuite) waitForStatus(t job.Tracker, status job.Status) {
+	tk := time.NewTicker(500 * time.Millisecond)
+	defer tk.Stop()
+
+	timeout := time.After(30 * time.Second)
+	for {
+		select {
+		case <-tk.C:
+			latest, err := t.Status()
+			require.NoError(suite.T(), err, "get latest status: nil error expected but got %s", err)
+			if latest.Compare(status) == 0 {
+				return
+			}
+		case <-timeout:
+			require.NoError(suite.T(), fmt.Errorf("check %s status time out", status))
+			return
+		}
+	}
+}
+
+type fakeJob struct{}
+
+func (j *fakeJob) MaxFails() uint {
+	return 3
+}
+
+func (j *fakeJob) ShouldRetry() bool {
+	return false
+}
+
+func (j *fakeJob) MaxRuntime() time.Duration {
+	return 0
+}
+
+func (j *fakeJob) Validate(params job.Parameters) error {
+	if p, ok := params["name"]; ok {
+		if p == "testing:v1" || p == "testing:v2" {
+			return nil
+		}
+	}
+
+	return errors.New("validate: testing error")
+}
+
+func (j *fakeJob) Run(ctx job.Context, params job.Parameters) error {
+	ctx.OPCommand()
+	_ = ctx.Checkin("done")
+
+	return nil
+}
+
+type fakeLongRunJob struct{}
+
+func (j *fakeLongRunJob) MaxFails() uint {
+	return 3
+}
+
+func (j *fakeLongRunJob) ShouldRetry() bool {
+	return false
+}
+
+func (j *fakeLongRunJob) MaxRuntime() time.Duration {
+	return 0
+}
+
+func (j *fakeLongRunJob) Validate(params job.Parameters) error {
+	if p, ok := params["name"]; ok {
+		if p == "testing:v1" || p == "testing:v2" {
+			return nil
+		}
+	}
+
+	return errors.New("validate: testing error")
+}
+
+func (j *fakeLongRunJob) Run(ctx job.Context, params job.Parameters) error {
+	time.Sleep(3 * time.Second)
+
+	if _, stopped := ctx.OPCommand(); stopped {
+		return nil
+	}
+
+	_ = ctx.Checkin("done")
+
+	return nil
+}
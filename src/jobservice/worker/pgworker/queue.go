@@ -0,0 +1,210 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgworker
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gocraft/work"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// execer is implemented by both *sql.DB and *sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// queue is the job queue persisted in the postgresql database.
+// Jobs are claimed with a short 'FOR UPDATE SKIP LOCKED' statement which marks the claimer and
+// the claiming time, so no transaction is kept open while the job is running. The claimer renews
+// the claims of its running jobs periodically, the job becomes visible again if the claim is not
+// renewed in the lease time as the worker died before finishing it.
+type queue struct {
+	db *sql.DB
+}
+
+// queuedJob is the job claimed from the queue
+type queuedJob struct {
+	id  int64
+	job *work.Job
+}
+
+// claimExpiredBefore returns the time before which the claims are treated as expired
+func claimExpiredBefore(now int64) int64 {
+	return now - int64(claimLease/time.Second)
+}
+
+// uniqueKey returns the key to identify the jobs with the same name and parameters
+func uniqueKey(jobName string, args map[string]interface{}) (string, error) {
+	// The keys of map are sorted by json marshaling
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(append([]byte(jobName+":"), data...))), nil
+}
+
+// push puts the job to the queue which is going to run at the specified time.
+// False is returned if the job is discarded as the same job is already in the queue.
+func (q *queue) push(e execer, j *work.Job, runAt int64, unique string) (bool, error) {
+	args, err := json.Marshal(j.Args)
+	if err != nil {
+		return false, err
+	}
+
+	var key interface{}
+	if len(unique) > 0 {
+		key = unique
+	}
+
+	query := `INSERT INTO job_queue (job_id, job_name, args, unique_key, run_at, enqueued_at)
+		VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING`
+	res, err := e.Exec(query, j.ID, j.Name, string(args), key, runAt, j.EnqueuedAt)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+// fetch claims and returns the earliest job which is ready to run before the specified time,
+// nil returned if no such job found
+func (q *queue) fetch(now int64, jobNames []string, claimer string) (*queuedJob, error) {
+	var (
+		qj      = &queuedJob{job: &work.Job{}}
+		args    []byte
+		lastErr sql.NullString
+	)
+
+	query := `UPDATE job_queue SET claimed_by = $3, claimed_at = $1 WHERE id = (
+		SELECT id FROM job_queue WHERE run_at <= $1 AND job_name = ANY($2) AND claimed_at < $4
+		ORDER BY run_at, id LIMIT 1 FOR UPDATE SKIP LOCKED
+	) RETURNING id, job_id, job_name, args, enqueued_at, fails, last_err, failed_at`
+	err := q.db.QueryRow(query, now, pq.Array(jobNames), claimer, claimExpiredBefore(now)).Scan(
+		&qj.id,
+		&qj.job.ID,
+		&qj.job.Name,
+		&args,
+		&qj.job.EnqueuedAt,
+		&qj.job.Fails,
+		&lastErr,
+		&qj.job.FailedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	qj.job.LastErr = lastErr.String
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &qj.job.Args); err != nil {
+			// Release the claim to not block the other jobs as the job will be never handled successfully
+			_ = q.release(qj)
+			return nil, errors.Wrapf(err, "bad arguments of queued job %s", qj.job.ID)
+		}
+	}
+
+	return qj, nil
+}
+
+// renew extends the claims of the running jobs of the claimer
+func (q *queue) renew(claimer string, now int64) error {
+	_, err := q.db.Exec(`UPDATE job_queue SET claimed_at = $2 WHERE claimed_by = $1`, claimer, now)
+
+	return err
+}
+
+// ack removes the claimed job from the queue
+func (q *queue) ack(qj *queuedJob) error {
+	_, err := q.db.Exec(`DELETE FROM job_queue WHERE id = $1`, qj.id)
+
+	return err
+}
+
+// release drops the claim of the job to make it visible to the workers again
+func (q *queue) release(qj *queuedJob) error {
+	_, err := q.db.Exec(`UPDATE job_queue SET claimed_by = NULL, claimed_at = 0 WHERE id = $1`, qj.id)
+
+	return err
+}
+
+// retry keeps the failure of the job, releases the claim and makes it run again at the specified time
+func (q *queue) retry(qj *queuedJob, runAt int64) error {
+	query := `UPDATE job_queue SET fails = $2, last_err = $3, failed_at = $4, run_at = $5,
+		claimed_by = NULL, claimed_at = 0 WHERE id = $1`
+	_, err := q.db.Exec(query, qj.id, qj.job.Fails, qj.job.LastErr, qj.job.FailedAt, runAt)
+
+	return err
+}
+
+// remove deletes the jobs which are not running from the queue,
+// the run times of the deleted jobs are returned.
+// If runAt is 0, all the jobs with the ID are deleted.
+func (q *queue) remove(jobID string, runAt int64) ([]int64, error) {
+	query := `DELETE FROM job_queue WHERE id IN (
+		SELECT id FROM job_queue WHERE job_id = $1 AND ($2 = 0 OR run_at = $2) AND claimed_at < $3
+		FOR UPDATE SKIP LOCKED
+	) RETURNING run_at`
+	rows, err := q.db.Query(query, jobID, runAt, claimExpiredBefore(time.Now().Unix()))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	return scanRunAts(rows)
+}
+
+// running returns the run times of the jobs with the ID which are being handled by the workers
+func (q *queue) running(jobID string) ([]int64, error) {
+	query := `SELECT run_at FROM job_queue WHERE job_id = $1 AND claimed_at >= $2`
+	rows, err := q.db.Query(query, jobID, claimExpiredBefore(time.Now().Unix()))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	return scanRunAts(rows)
+}
+
+func scanRunAts(rows *sql.Rows) ([]int64, error) {
+	runAts := make([]int64, 0)
+	for rows.Next() {
+		var runAt int64
+		if err := rows.Scan(&runAt); err != nil {
+			return nil, err
+		}
+		runAts = append(runAts, runAt)
+	}
+
+	return runAts, rows.Err()
+}
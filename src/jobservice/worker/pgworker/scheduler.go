@@ -0,0 +1,443 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgworker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gocraft/work"
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/lcm"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/jobservice/period"
+	"github.com/pkg/errors"
+	"github.com/robfig/cron"
+)
+
+const (
+	enqueuerSleep   = 2 * time.Minute
+	enqueuerHorizon = 4 * time.Minute
+
+	// The key of the advisory lock acquired by the node enqueuing the periodic job executions
+	periodicEnqueueLock = 0x6a6f6273
+)
+
+// scheduler is the periodic scheduler keeping the policies in the job_periodic_policy table.
+// The executions of the policies are put to the job queue ahead of time within a horizon.
+type scheduler struct {
+	context  context.Context
+	db       *sql.DB
+	queue    *queue
+	ctl      lcm.Controller
+	stopChan chan bool
+}
+
+// newScheduler is constructor of scheduler
+func newScheduler(ctx context.Context, db *sql.DB, q *queue, ctl lcm.Controller) period.Scheduler {
+	return &scheduler{
+		context:  ctx,
+		db:       db,
+		queue:    q,
+		ctl:      ctl,
+		stopChan: make(chan bool, 1),
+	}
+}
+
+// Start the periodic scheduling process
+// Blocking call here
+func (s *scheduler) Start() error {
+	defer func() {
+		logger.Info("PostgreSQL scheduler is stopped")
+	}()
+
+	logger.Info("PostgreSQL scheduler is started")
+
+	// Do enqueue immediately when starting
+	s.enqueue()
+
+	timer := time.NewTimer(enqueuerSleep)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.stopChan:
+			return nil
+		case <-s.context.Done():
+			return nil
+		case <-timer.C:
+			s.enqueue()
+			timer.Reset(enqueuerSleep)
+		}
+	}
+}
+
+// Stop the periodic scheduling process
+func (s *scheduler) Stop() error {
+	s.stopChan <- true
+
+	return nil
+}
+
+// Schedule is implementation of the same method in period.Interface
+func (s *scheduler) Schedule(p *period.Policy) (int64, error) {
+	if p == nil {
+		return -1, errors.New("bad policy object: nil")
+	}
+
+	if err := p.Validate(); err != nil {
+		return -1, err
+	}
+
+	params, err := json.Marshal(p.JobParameters)
+	if err != nil {
+		return -1, err
+	}
+
+	var pid int64
+	query := `INSERT INTO job_periodic_policy (policy_id, job_name, cron_spec, time_zone, job_params, web_hook_url, paused)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	if err := s.db.QueryRow(query, p.ID, p.JobName, p.CronSpec, p.TimeZone, string(params), p.WebHookURL, p.Paused).Scan(&pid); err != nil {
+		return -1, err
+	}
+
+	// Do the 1st round of enqueuing
+	if err := s.scheduleNextJobs(p); err != nil {
+		logger.Errorf("Schedule executions of periodic job %s error: %s", p.ID, err)
+	}
+
+	return pid, nil
+}
+
+// UnSchedule is implementation of the same method in period.Interface
+func (s *scheduler) UnSchedule(policyID string) error {
+	if utils.IsEmptyStr(policyID) {
+		return errors.New("bad periodic job ID: nil")
+	}
+
+	tracker, err := s.ctl.Track(policyID)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db.Exec(`DELETE FROM job_periodic_policy WHERE policy_id = $1`, policyID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.Errorf("no valid periodic job policy found: %s", policyID)
+	}
+
+	// Expire periodic job stats
+	if err := tracker.Expire(); err != nil {
+		logger.Error(err)
+	}
+
+	// Switch the job stats to stopped
+	// Should not block the next clear action
+	err = tracker.Stop()
+
+	// Remove the queued executions and stop the running ones.
+	// This is a try best action, its failure will not cause the unschedule action failed.
+	runAts, er := s.queue.remove(policyID, 0)
+	if er != nil {
+		logger.Errorf("Remove queued executions of periodic job %s error: %s", policyID, er)
+	}
+	if running, er := s.queue.running(policyID); er != nil {
+		logger.Errorf("Get running executions of periodic job %s error: %s", policyID, er)
+	} else {
+		runAts = append(runAts, running...)
+	}
+
+	for _, runAt := range runAts {
+		eID := fmt.Sprintf("%s@%d", policyID, runAt)
+		eTracker, er := s.ctl.Track(eID)
+		if er != nil {
+			logger.Errorf("Track execution %s error: %s", eID, er)
+			continue
+		}
+
+		// Only stop the executions not in the final states
+		if job.RunningStatus.Compare(job.Status(eTracker.Job().Info.Status)) >= 0 {
+			if er := eTracker.Stop(); er != nil {
+				logger.Errorf("Stop execution %s error: %s", eID, er)
+			}
+		}
+	}
+
+	return err
+}
+
+// Pause is implementation of the same method in period.Interface
+func (s *scheduler) Pause(policyID string) error {
+	if err := s.setPaused(policyID, true); err != nil {
+		return err
+	}
+
+	// Remove the queued executions and stop them.
+	// This is a try best action, its failure will not cause the pause action failed.
+	runAts, err := s.queue.remove(policyID, 0)
+	if err != nil {
+		logger.Errorf("Remove queued executions of periodic job %s error: %s", policyID, err)
+	}
+
+	for _, runAt := range runAts {
+		eID := fmt.Sprintf("%s@%d", policyID, runAt)
+		eTracker, err := s.ctl.Track(eID)
+		if err != nil {
+			logger.Errorf("Track execution %s error: %s", eID, err)
+			continue
+		}
+
+		if job.ScheduledStatus == job.Status(eTracker.Job().Info.Status) {
+			if err := eTracker.Stop(); err != nil {
+				logger.Errorf("Stop execution %s error: %s", eID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Resume is implementation of the same method in period.Interface
+func (s *scheduler) Resume(policyID string) error {
+	if err := s.setPaused(policyID, false); err != nil {
+		return err
+	}
+
+	p, err := s.getPolicy(policyID)
+	if err != nil {
+		return err
+	}
+
+	// Do not wait for the next round of enqueuing
+	if err := s.scheduleNextJobs(p); err != nil {
+		logger.Errorf("Schedule executions of periodic job %s error: %s", p.ID, err)
+	}
+
+	return nil
+}
+
+// setPaused switches the paused flag of the policy
+func (s *scheduler) setPaused(policyID string, paused bool) error {
+	if utils.IsEmptyStr(policyID) {
+		return errors.New("bad periodic job ID: nil")
+	}
+
+	tracker, err := s.ctl.Track(policyID)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db.Exec(`UPDATE job_periodic_policy SET paused = $2 WHERE policy_id = $1`, policyID, paused)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.Errorf("no valid periodic job policy found: %s", policyID)
+	}
+
+	if err := tracker.Update("paused", paused); err != nil {
+		// Only logged as the flag in the stats is only for showing
+		logger.Errorf("Update paused flag of periodic job %s error: %s", policyID, err)
+	}
+
+	return nil
+}
+
+// enqueue schedules the executions of all the policies,
+// only one node does it at the same time
+func (s *scheduler) enqueue() {
+	tx, err := s.db.Begin()
+	if err != nil {
+		logger.Errorf("Begin transaction for periodic enqueuing error: %s", err)
+		return
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// The lock is released when the transaction ends
+	var locked bool
+	if err := tx.QueryRow(`SELECT pg_try_advisory_xact_lock($1)`, periodicEnqueueLock).Scan(&locked); err != nil {
+		logger.Errorf("Acquire lock for periodic enqueuing error: %s", err)
+		return
+	}
+	if !locked {
+		logger.Debug("Periodic enqueuing is being done by other node")
+		return
+	}
+
+	policies, err := s.listPolicies(tx)
+	if err != nil {
+		logger.Errorf("List periodic job policies error: %s", err)
+		return
+	}
+
+	for _, p := range policies {
+		if err := s.scheduleNextJobs(p); err != nil {
+			logger.Errorf("Schedule executions of periodic job %s error: %s", p.ID, err)
+		}
+	}
+}
+
+// listPolicies lists the policies which are not paused
+func (s *scheduler) listPolicies(tx *sql.Tx) ([]*period.Policy, error) {
+	rows, err := tx.Query(`SELECT ` + policyColumns + ` FROM job_periodic_policy WHERE NOT paused`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	policies := make([]*period.Policy, 0)
+	for rows.Next() {
+		p, err := scanPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		if p != nil {
+			policies = append(policies, p)
+		}
+	}
+
+	return policies, rows.Err()
+}
+
+// getPolicy gets the policy with the ID
+func (s *scheduler) getPolicy(policyID string) (*period.Policy, error) {
+	row := s.db.QueryRow(`SELECT `+policyColumns+` FROM job_periodic_policy WHERE policy_id = $1`, policyID)
+	p, err := scanPolicy(row)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, errors.Errorf("no valid periodic job policy found: %s", policyID)
+	}
+
+	return p, nil
+}
+
+const policyColumns = `policy_id, job_name, cron_spec, time_zone, job_params, web_hook_url, paused`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanPolicy scans the policy from the row with the policyColumns,
+// nil policy is returned if the policy is malformed
+func scanPolicy(row rowScanner) (*period.Policy, error) {
+	var (
+		p          = &period.Policy{}
+		params     []byte
+		webHookURL sql.NullString
+	)
+	if err := row.Scan(&p.ID, &p.JobName, &p.CronSpec, &p.TimeZone, &params, &webHookURL, &p.Paused); err != nil {
+		return nil, err
+	}
+
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p.JobParameters); err != nil {
+			logger.Errorf("Bad parameters of periodic job policy %s: %s", p.ID, err)
+			return nil, nil
+		}
+	}
+	p.WebHookURL = webHookURL.String
+
+	return p, nil
+}
+
+// scheduleNextJobs schedules job for next time slots based on the policy
+func (s *scheduler) scheduleNextJobs(p *period.Policy) error {
+	if p.Paused {
+		return nil
+	}
+
+	schedule, err := cron.Parse(p.CronSpec)
+	if err != nil {
+		return err
+	}
+
+	loc, err := p.Location()
+	if err != nil {
+		return err
+	}
+
+	// The cron spec is evaluated in the location of the time passed to the schedule
+	nowTime := time.Unix(time.Now().Unix(), 0).In(loc)
+	horizon := nowTime.Add(enqueuerHorizon)
+
+	for t := schedule.Next(nowTime); t.Before(horizon); t = schedule.Next(t) {
+		if err := s.scheduleExecution(p, t.Unix()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scheduleExecution puts the execution of the policy at the epoch to the queue
+// the execution stats is saved before the queued job becomes visible to the workers
+func (s *scheduler) scheduleExecution(p *period.Policy, epoch int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	j := &work.Job{
+		Name: p.JobName,
+		// Use the ID of policy to avoid scheduling duplicated periodic job executions.
+		ID:         p.ID,
+		EnqueuedAt: epoch,
+		// Add extra argument for job running too.
+		Args: period.CloneParameters(p.JobParameters, epoch),
+	}
+
+	ok, err := s.queue.push(tx, j, epoch, "")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// Already scheduled
+		return nil
+	}
+
+	execution := period.CreateExecution(p, epoch)
+	if _, err := s.ctl.New(execution); err != nil {
+		return errors.Wrapf(err, "save stats data of job execution '%s' error", execution.Info.JobID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	logger.Debugf("Scheduled execution for periodic job %s:%s at %d", j.Name, p.ID, epoch)
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/models"
+	notifierModel "github.com/goharbor/harbor/src/core/notifier/model"
+	"github.com/goharbor/harbor/src/pkg/notification/model"
+)
+
+// ContentTypeJSON is the default content type of the payload
+const ContentTypeJSON = "application/json"
+
+var eventTitles = map[string]string{
+	model.EventTypePushImage:         "Image pushed",
+	model.EventTypePullImage:         "Image pulled",
+	model.EventTypeDeleteImage:       "Image deleted",
+	model.EventTypeUploadChart:       "Chart uploaded",
+	model.EventTypeDeleteChart:       "Chart deleted",
+	model.EventTypeDownloadChart:     "Chart downloaded",
+	model.EventTypeScanningCompleted: "Image scanning completed",
+	model.EventTypeScanningFailed:    "Image scanning failed",
+	model.EventTypeTestEndpoint:      "Test of the webhook endpoint",
+}
+
+var templateFuncs = template.FuncMap{
+	// json marshals the value, e.g. to quote the string in the JSON payload
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	// time formats the unix timestamp in RFC3339
+	"time": func(t int64) string {
+		return time.Unix(t, 0).UTC().Format(time.RFC3339)
+	},
+}
+
+// SlackMessage is the message of Slack incoming webhook
+type SlackMessage struct {
+	Text string `json:"text"`
+}
+
+// Format formats the payload according to the type of the target,
+// it returns the content type and the body of the request sent to the target
+func Format(target *models.EventTarget, payload *notifierModel.Payload) (string, []byte, error) {
+	switch target.Type {
+	case model.NotifyTypeSlack:
+		data, err := json.Marshal(&SlackMessage{Text: SlackText(payload)})
+		return ContentTypeJSON, data, err
+	case model.NotifyTypeTemplate:
+		tpl, err := parseTemplate(target.PayloadTemplate)
+		if err != nil {
+			return "", nil, err
+		}
+		buf := &bytes.Buffer{}
+		if err := tpl.Execute(buf, payload); err != nil {
+			return "", nil, fmt.Errorf("failed to render the payload template: %v", err)
+		}
+		contentType := target.ContentType
+		if len(contentType) == 0 {
+			contentType = ContentTypeJSON
+		}
+		return contentType, buf.Bytes(), nil
+	default:
+		data, err := json.Marshal(payload)
+		return ContentTypeJSON, data, err
+	}
+}
+
+// ValidateTarget checks the settings of the target specific to its type
+func ValidateTarget(target *models.EventTarget) error {
+	if target.Type != model.NotifyTypeTemplate {
+		return nil
+	}
+	if len(strings.TrimSpace(target.PayloadTemplate)) == 0 {
+		return fmt.Errorf("the payload template is required by the target type %s", target.Type)
+	}
+	_, err := parseTemplate(target.PayloadTemplate)
+	return err
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	tpl, err := template.New("payload").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload template: %v", err)
+	}
+	return tpl, nil
+}
+
+// SlackText returns the human readable text of the payload in the markup of Slack
+func SlackText(payload *notifierModel.Payload) string {
+	title, ok := eventTitles[payload.Type]
+	if !ok {
+		title = payload.Type
+	}
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "*%s*", title)
+	if payload.EventData != nil && payload.EventData.Repository != nil {
+		fmt.Fprintf(b, " in repository `%s`", payload.EventData.Repository.RepoFullName)
+	}
+	if len(payload.Operator) > 0 {
+		fmt.Fprintf(b, " by %s", payload.Operator)
+	}
+	if payload.OccurAt > 0 {
+		fmt.Fprintf(b, " at %s", time.Unix(payload.OccurAt, 0).UTC().Format(time.RFC3339))
+	}
+	if payload.EventData == nil {
+		return b.String()
+	}
+	for _, res := range payload.EventData.Resources {
+		fmt.Fprintf(b, "\n• `%s`", res.Tag)
+		if len(res.ResourceURL) > 0 {
+			fmt.Fprintf(b, " %s", res.ResourceURL)
+		}
+		if o := res.ScanOverview; o != nil {
+			fmt.Fprintf(b, ", scan status: %s", o.Status)
+			if o.Sev > 0 {
+				fmt.Fprintf(b, ", severity: %s", models.Severity(o.Sev))
+			}
+			if o.CompOverview != nil {
+				fmt.Fprintf(b, ", components: %d", o.CompOverview.Total)
+			}
+		}
+	}
+	return b.String()
+}
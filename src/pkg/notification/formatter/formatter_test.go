@@ -0,0 +1,91 @@
+package formatter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/models"
+	notifierModel "github.com/goharbor/harbor/src/core/notifier/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var payload = &notifierModel.Payload{
+	Type:    "scanningCompleted",
+	OccurAt: time.Date(2019, 10, 10, 10, 10, 10, 0, time.UTC).Unix(),
+	EventData: &notifierModel.EventData{
+		Repository: &notifierModel.Repository{
+			Name:         "app",
+			Namespace:    "library",
+			RepoFullName: "library/app",
+			RepoType:     "private",
+		},
+		Resources: []*notifierModel.Resource{
+			{
+				Tag:         "1.0",
+				Digest:      "sha256:0204dc6e09fa57ab99ac40e415eb637d62c8b2571ecbbc9ca0eb5e2ad2b5c56f",
+				ResourceURL: "harbor.example.com/library/app:1.0",
+				ScanOverview: &models.ImgScanOverview{
+					Status:       models.JobFinished,
+					Sev:          int(models.SevHigh),
+					CompOverview: &models.ComponentsOverview{Total: 12},
+				},
+			},
+		},
+	},
+	Operator: "admin",
+}
+
+func TestFormatHTTP(t *testing.T) {
+	contentType, data, err := Format(&models.EventTarget{Type: "http"}, payload)
+	require.Nil(t, err)
+	assert.Equal(t, ContentTypeJSON, contentType)
+	p := &notifierModel.Payload{}
+	require.Nil(t, json.Unmarshal(data, p))
+	assert.Equal(t, payload.Type, p.Type)
+}
+
+func TestFormatSlack(t *testing.T) {
+	contentType, data, err := Format(&models.EventTarget{Type: "slack"}, payload)
+	require.Nil(t, err)
+	assert.Equal(t, ContentTypeJSON, contentType)
+	msg := &SlackMessage{}
+	require.Nil(t, json.Unmarshal(data, msg))
+	assert.Equal(t, "*Image scanning completed* in repository `library/app` by admin at 2019-10-10T10:10:10Z\n"+
+		"• `1.0` harbor.example.com/library/app:1.0, scan status: finished, severity: high, components: 12", msg.Text)
+
+	assert.Equal(t, "*Test of the webhook endpoint*", SlackText(&notifierModel.Payload{Type: "testEndpoint"}))
+}
+
+func TestFormatTemplate(t *testing.T) {
+	target := &models.EventTarget{
+		Type:            "template",
+		PayloadTemplate: `{"msg": {{ json (printf "%s %s" .Type .EventData.Repository.RepoFullName) }}, "at": "{{ time .OccurAt }}"}`,
+	}
+	contentType, data, err := Format(target, payload)
+	require.Nil(t, err)
+	assert.Equal(t, ContentTypeJSON, contentType)
+	assert.Equal(t, `{"msg": "scanningCompleted library/app", "at": "2019-10-10T10:10:10Z"}`, string(data))
+
+	target = &models.EventTarget{
+		Type:            "template",
+		PayloadTemplate: "{{ .Operator }} pushed {{ range .EventData.Resources }}{{ .Tag }}{{ end }}",
+		ContentType:     "text/plain",
+	}
+	contentType, data, err = Format(target, payload)
+	require.Nil(t, err)
+	assert.Equal(t, "text/plain", contentType)
+	assert.Equal(t, "admin pushed 1.0", string(data))
+
+	// the field doesn't exist
+	_, _, err = Format(&models.EventTarget{Type: "template", PayloadTemplate: "{{ .NotExist }}"}, payload)
+	assert.NotNil(t, err)
+}
+
+func TestValidateTarget(t *testing.T) {
+	assert.Nil(t, ValidateTarget(&models.EventTarget{Type: "http"}))
+	assert.Nil(t, ValidateTarget(&models.EventTarget{Type: "template", PayloadTemplate: "{{ .Type }}"}))
+	assert.NotNil(t, ValidateTarget(&models.EventTarget{Type: "template"}))
+	assert.NotNil(t, ValidateTarget(&models.EventTarget{Type: "template", PayloadTemplate: "{{ .Type "}))
+}
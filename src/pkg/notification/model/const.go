@@ -12,5 +12,7 @@ const (
 	EventTypeScanningFailed    = "scanningFailed"
 	EventTypeTestEndpoint      = "testEndpoint"
 
-	NotifyTypeHTTP = "http"
+	NotifyTypeHTTP     = "http"
+	NotifyTypeSlack    = "slack"    // the payload is sent as the message of Slack incoming webhook
+	NotifyTypeTemplate = "template" // the payload is rendered by the Go template defined in the target
 )
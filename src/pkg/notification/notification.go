@@ -45,7 +45,7 @@ func Init() {
 		model.EventTypeScanningCompleted, model.EventTypeScanningFailed,
 	)
 
-	initSupportedNotifyType(model.NotifyTypeHTTP)
+	initSupportedNotifyType(model.NotifyTypeHTTP, model.NotifyTypeSlack, model.NotifyTypeTemplate)
 
 	log.Info("notification initialization completed")
 }
@@ -20,6 +20,6 @@ type Manager interface {
 	Delete(int64) error
 	// Test the specified policy
 	Test(*models.NotificationPolicy) error
-	// GetRelatedPolices get event type related policies in project
-	GetRelatedPolices(int64, string) ([]*models.NotificationPolicy, error)
+	// GetRelatedPolices get event type related policies in project, including the system level ones matching the project
+	GetRelatedPolices(*models.Project, string) ([]*models.NotificationPolicy, error)
 }
@@ -4,14 +4,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/goharbor/harbor/src/common/config/encrypt"
 	"github.com/goharbor/harbor/src/common/dao/notification"
 	commonhttp "github.com/goharbor/harbor/src/common/http"
 	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/common/utils/log"
 	notifierModel "github.com/goharbor/harbor/src/core/notifier/model"
 	"github.com/goharbor/harbor/src/pkg/notification/model"
+	"github.com/goharbor/harbor/src/pkg/notification/signature"
 )
 
 // DefaultManager ...
@@ -29,7 +33,7 @@ func (m *DefaultManager) Create(policy *models.NotificationPolicy) (int64, error
 	policy.CreationTime = t
 	policy.UpdateTime = t
 
-	err := policy.ConvertToDBModel()
+	err := convertToDBModel(policy)
 	if err != nil {
 		return 0, err
 	}
@@ -45,7 +49,7 @@ func (m *DefaultManager) List(projectID int64) ([]*models.NotificationPolicy, er
 	}
 
 	for _, policy := range persisPolicies {
-		err := policy.ConvertFromDBModel()
+		err := convertFromDBModel(policy)
 		if err != nil {
 			return nil, err
 		}
@@ -64,7 +68,7 @@ func (m *DefaultManager) Get(id int64) (*models.NotificationPolicy, error) {
 	if policy == nil {
 		return nil, nil
 	}
-	err = policy.ConvertFromDBModel()
+	err = convertFromDBModel(policy)
 	return policy, err
 }
 
@@ -74,14 +78,14 @@ func (m *DefaultManager) GetByNameAndProjectID(name string, projectID int64) (*m
 	if err != nil {
 		return nil, err
 	}
-	err = policy.ConvertFromDBModel()
+	err = convertFromDBModel(policy)
 	return policy, err
 }
 
 // Update the specified notification policy
 func (m *DefaultManager) Update(policy *models.NotificationPolicy) error {
 	policy.UpdateTime = time.Now()
-	err := policy.ConvertToDBModel()
+	err := convertToDBModel(policy)
 	if err != nil {
 		return err
 	}
@@ -104,8 +108,8 @@ func (m *DefaultManager) Test(policy *models.NotificationPolicy) error {
 
 	for _, target := range policy.Targets {
 		switch target.Type {
-		case "http":
-			return m.policyHTTPTest(target.Address, target.SkipCertVerify, p)
+		case model.NotifyTypeHTTP, model.NotifyTypeSlack, model.NotifyTypeTemplate:
+			return m.policyHTTPTest(target.Address, target.SkipCertVerify, target.Secret, p)
 		default:
 			return fmt.Errorf("invalid policy target type: %s", target.Type)
 		}
@@ -113,13 +117,15 @@ func (m *DefaultManager) Test(policy *models.NotificationPolicy) error {
 	return nil
 }
 
-func (m *DefaultManager) policyHTTPTest(address string, skipCertVerify bool, p []byte) error {
+func (m *DefaultManager) policyHTTPTest(address string, skipCertVerify bool, secret string, p []byte) error {
 	req, err := http.NewRequest(http.MethodPost, address, nil)
 	if err != nil {
 		return err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	// the test request has no body, so the signature is computed over the empty payload
+	signature.SignRequest(req, secret, nil)
 
 	client := http.Client{
 		Transport: commonhttp.GetHTTPTransport(skipCertVerify),
@@ -135,13 +141,29 @@ func (m *DefaultManager) policyHTTPTest(address string, skipCertVerify bool, p [
 	return nil
 }
 
-// GetRelatedPolices get policies including event type in project
-func (m *DefaultManager) GetRelatedPolices(projectID int64, eventType string) ([]*models.NotificationPolicy, error) {
-	policies, err := m.List(projectID)
+// GetRelatedPolices get policies including event type in project, and the enabled system level policies
+// including event type whose project pattern matches the project
+func (m *DefaultManager) GetRelatedPolices(project *models.Project, eventType string) ([]*models.NotificationPolicy, error) {
+	policies, err := m.List(project.ProjectID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get notification policies with projectID %d: %v", projectID, err)
+		return nil, fmt.Errorf("failed to get notification policies with projectID %d: %v", project.ProjectID, err)
 	}
 
+	sysPolicies, err := m.List(models.SystemNotificationPolicyProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system level notification policies: %v", err)
+	}
+	for _, ply := range sysPolicies {
+		if ply.MatchProject(project.Name) {
+			policies = append(policies, ply)
+		}
+	}
+
+	return filterPolicies(policies, eventType), nil
+}
+
+// filterPolicies returns the enabled policies including the event type
+func filterPolicies(policies []*models.NotificationPolicy, eventType string) []*models.NotificationPolicy {
 	var result []*models.NotificationPolicy
 
 	for _, ply := range policies {
@@ -155,5 +177,49 @@ func (m *DefaultManager) GetRelatedPolices(projectID int64, eventType string) ([
 			result = append(result, ply)
 		}
 	}
-	return result, nil
+	return result
+}
+
+// convertToDBModel converts the policy to the DB model with the secrets of the targets encrypted
+func convertToDBModel(policy *models.NotificationPolicy) error {
+	plain := policy.Targets
+	defer func() {
+		policy.Targets = plain
+	}()
+
+	targets := make([]models.EventTarget, len(plain))
+	for i, target := range plain {
+		if len(target.Secret) > 0 {
+			secret, err := encrypt.Instance().Encrypt(target.Secret)
+			if err != nil {
+				return fmt.Errorf("failed to encrypt the secret of notification target %s: %v", target.Address, err)
+			}
+			target.Secret = secret
+		}
+		targets[i] = target
+	}
+	policy.Targets = targets
+
+	return policy.ConvertToDBModel()
+}
+
+// convertFromDBModel converts the policy from the DB model with the secrets of the targets decrypted,
+// the secret stored in plaintext before it's encrypted is kept as it is
+func convertFromDBModel(policy *models.NotificationPolicy) error {
+	if err := policy.ConvertFromDBModel(); err != nil {
+		return err
+	}
+
+	for i := range policy.Targets {
+		target := &policy.Targets[i]
+		if !strings.HasPrefix(target.Secret, utils.EncryptHeaderV1) {
+			continue
+		}
+		secret, err := encrypt.Instance().Decrypt(target.Secret)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt the secret of notification target %s: %v", target.Address, err)
+		}
+		target.Secret = secret
+	}
+	return nil
 }
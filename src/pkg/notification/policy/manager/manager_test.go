@@ -3,6 +3,10 @@ package manager
 import (
 	"reflect"
 	"testing"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewDefaultManger(t *testing.T) {
@@ -20,3 +24,19 @@ func TestNewDefaultManger(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterPolicies(t *testing.T) {
+	policies := []*models.NotificationPolicy{
+		{ID: 1, Enabled: true, EventTypes: []string{"pushImage", "pullImage"}},
+		{ID: 2, Enabled: false, EventTypes: []string{"pushImage"}},
+		{ID: 3, Enabled: true, EventTypes: []string{"deleteImage"}},
+		{ID: 4, Enabled: true, ProjectPattern: "team-*", EventTypes: []string{"pushImage"}},
+	}
+
+	result := filterPolicies(policies, "pushImage")
+	require.Len(t, result, 2)
+	assert.Equal(t, int64(1), result[0].ID)
+	assert.Equal(t, int64(4), result[1].ID)
+
+	assert.Empty(t, filterPolicies(policies, "scanningFailed"))
+}
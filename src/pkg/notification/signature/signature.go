@@ -0,0 +1,68 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// HeaderSignature is the header carrying the HMAC-SHA256 signature of the delivery
+	HeaderSignature = "X-Harbor-Signature"
+	// HeaderTimestamp is the header carrying the unix timestamp in seconds when the delivery is signed
+	HeaderTimestamp = "X-Harbor-Timestamp"
+
+	prefix = "sha256="
+)
+
+// Sign returns the signature of the payload signed at the timestamp, in the format "sha256=<hex digest>".
+// The digest is the HMAC-SHA256 of "<timestamp>.<payload>" keyed by the secret.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return prefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignRequest sets the timestamp and signature headers of the request with the current time,
+// nothing is done if the secret is empty
+func SignRequest(req *http.Request, secret string, payload []byte) {
+	if len(secret) == 0 {
+		return
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(secret, timestamp, payload))
+}
+
+// Verify checks the signature and the timestamp of a delivery, the deliveries whose timestamp
+// differs from now by more than the tolerance are rejected to prevent them from being replayed
+func Verify(secret, timestamp, signature string, payload []byte, tolerance time.Duration) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q: %v", timestamp, err)
+	}
+	if tolerance > 0 {
+		diff := time.Since(time.Unix(ts, 0))
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > tolerance {
+			return fmt.Errorf("timestamp %s is out of the tolerance %v", timestamp, tolerance)
+		}
+	}
+	if !strings.HasPrefix(signature, prefix) {
+		return fmt.Errorf("invalid signature %q", signature)
+	}
+	if !hmac.Equal([]byte(Sign(secret, ts, payload)), []byte(signature)) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package signature
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	// echo -n '1570702210.{"type":"pushImage"}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=1cb5f286fa61226bb4efc1e714192cc2d2a1ba99bf2ca785c9f55cd060ab3676",
+		Sign("secret", 1570702210, []byte(`{"type":"pushImage"}`)))
+}
+
+func TestSignRequest(t *testing.T) {
+	payload := []byte(`{"type":"pushImage"}`)
+
+	req, err := http.NewRequest(http.MethodPost, "http://127.0.0.1", nil)
+	require.Nil(t, err)
+	SignRequest(req, "", payload)
+	assert.Empty(t, req.Header.Get(HeaderSignature))
+	assert.Empty(t, req.Header.Get(HeaderTimestamp))
+
+	SignRequest(req, "secret", payload)
+	assert.Nil(t, Verify("secret", req.Header.Get(HeaderTimestamp), req.Header.Get(HeaderSignature), payload, 5*time.Minute))
+}
+
+func TestVerify(t *testing.T) {
+	payload := []byte(`{"type":"pushImage"}`)
+	now := time.Now().Unix()
+	ts := strconv.FormatInt(now, 10)
+	sig := Sign("secret", now, payload)
+
+	assert.Nil(t, Verify("secret", ts, sig, payload, time.Minute))
+	// wrong secret
+	assert.NotNil(t, Verify("another", ts, sig, payload, time.Minute))
+	// tampered payload
+	assert.NotNil(t, Verify("secret", ts, sig, []byte(`{"type":"deleteImage"}`), time.Minute))
+	// tampered timestamp
+	assert.NotNil(t, Verify("secret", strconv.FormatInt(now+1, 10), sig, payload, time.Minute))
+	// invalid timestamp
+	assert.NotNil(t, Verify("secret", "now", sig, payload, time.Minute))
+	// invalid signature
+	assert.NotNil(t, Verify("secret", ts, "md5=abc", payload, time.Minute))
+
+	// replayed
+	old := now - 3600
+	assert.NotNil(t, Verify("secret", strconv.FormatInt(old, 10), Sign("secret", old, payload), payload, 5*time.Minute))
+	assert.Nil(t, Verify("secret", strconv.FormatInt(old, 10), Sign("secret", old, payload), payload, 0))
+}
@@ -15,6 +15,8 @@
 package dep
 
 import (
+	"context"
+	"io"
 	"testing"
 
 	"github.com/goharbor/harbor/src/chartserver"
@@ -59,12 +61,18 @@ func (f *fakeJobserviceClient) SubmitJob(*jmodels.JobData) (string, error) {
 func (f *fakeJobserviceClient) GetJobLog(uuid string) ([]byte, error) {
 	return nil, nil
 }
+func (f *fakeJobserviceClient) FollowJobLog(ctx context.Context, uuid string, acceptEvents bool) (io.ReadCloser, error) {
+	return nil, nil
+}
 func (f *fakeJobserviceClient) PostAction(uuid, action string) error {
 	return nil
 }
 func (f *fakeJobserviceClient) GetExecutions(uuid string) ([]job.Stats, error) {
 	return nil, nil
 }
+func (f *fakeJobserviceClient) GetJob(uuid string) (*job.Stats, error) {
+	return nil, nil
+}
 
 type clientTestSuite struct {
 	suite.Suite
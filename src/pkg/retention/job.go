@@ -50,6 +50,11 @@ func (pj *Job) ShouldRetry() bool {
 	return true
 }
 
+// MaxRuntime of the job
+func (pj *Job) MaxRuntime() time.Duration {
+	return 0
+}
+
 // Validate the parameters
 func (pj *Job) Validate(params job.Parameters) (err error) {
 	if _, err = getParamRepo(params); err == nil {
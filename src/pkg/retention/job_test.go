@@ -208,6 +208,12 @@ func (c *fakeJobContext) Checkin(status string) error {
 	return nil
 }
 
+func (c *fakeJobContext) SetProgress(phase string, current, total int64) error {
+	fmt.Printf("Progress: %s %d/%d\n", phase, current, total)
+
+	return nil
+}
+
 func (c *fakeJobContext) OPCommand() (job.OPCommand, bool) {
 	return "", false
 }
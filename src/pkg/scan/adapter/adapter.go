@@ -0,0 +1,91 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/models"
+)
+
+const (
+	// AuthNone means no credential is sent to the scanner adapter
+	AuthNone = ""
+	// AuthBasic sends the credential with "Authorization: Basic <credential>"
+	AuthBasic = "Basic"
+	// AuthBearer sends the credential with "Authorization: Bearer <credential>"
+	AuthBearer = "Bearer"
+	// AuthAPIKey sends the credential with "X-ScannerAdapter-API-Key: <credential>"
+	AuthAPIKey = "X-ScannerAdapter-API-Key"
+)
+
+// Adapter defines the protocol between Harbor and a scanner.
+// The scan is asynchronous: the scan request is submitted first and the report is fetched
+// with the returned ID until it's ready.
+type Adapter interface {
+	// GetMetadata returns the metadata of the scanner adapter
+	GetMetadata() (*ScannerAdapterMetadata, error)
+	// SubmitScan submits the request to scan the artifact
+	SubmitScan(req *ScanRequest) (*ScanResponse, error)
+	// GetScanReport returns the report of the scan request, a *ReportNotReadyError
+	// is returned if the scan is still in progress
+	GetScanReport(scanRequestID string) (*VulnerabilityReport, error)
+}
+
+// ReportNotReadyError is returned when the report of the scan request is not ready yet
+type ReportNotReadyError struct {
+	// How long to wait before fetching the report again
+	RetryAfter time.Duration
+}
+
+// Error implements error interface
+func (e *ReportNotReadyError) Error() string {
+	return fmt.Sprintf("report is not ready yet, retry after %s", e.RetryAfter)
+}
+
+// IsReportNotReady checks whether the error means the report is not ready yet
+func IsReportNotReady(err error) (*ReportNotReadyError, bool) {
+	e, ok := err.(*ReportNotReadyError)
+	return e, ok
+}
+
+// ValidateAuth checks if the auth type is supported
+func ValidateAuth(auth string) error {
+	switch auth {
+	case AuthNone, AuthBasic, AuthBearer, AuthAPIKey:
+		return nil
+	default:
+		return fmt.Errorf("unsupported auth type of scanner adapter: %s", auth)
+	}
+}
+
+// ParseSeverity parses the severity in the report to Harbor's Severity type,
+// the value not recognized is parsed as unknown.
+func ParseSeverity(sev string) models.Severity {
+	switch strings.ToLower(sev) {
+	case models.SeverityNone:
+		return models.SevNone
+	case models.SeverityLow:
+		return models.SevLow
+	case models.SeverityMedium:
+		return models.SevMedium
+	case models.SeverityHigh, models.SeverityCritical:
+		return models.SevHigh
+	default:
+		return models.SevUnknown
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/schema2"
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils/clair"
+	"github.com/goharbor/harbor/src/common/utils/log"
+)
+
+// ClairScanner is the scanner info of the built-in Clair
+var ClairScanner = &Scanner{
+	Name:    "Clair",
+	Vendor:  "CoreOS",
+	Version: "2.x",
+}
+
+// clairAdapter scans the artifacts with the Clair deployed with Harbor.
+// Clair indexes the layers synchronously, so the report is ready once the scan request is submitted.
+// The ID of the scan request is the name of the top layer, the report is fetched from Clair with it.
+type clairAdapter struct {
+	client *clair.Client
+}
+
+// NewClairAdapter returns an adapter scanning with the Clair at the endpoint,
+// set the logger as the job's logger if it's used in a job.
+func NewClairAdapter(endpoint string, logger *log.Logger) Adapter {
+	return &clairAdapter{
+		client: clair.NewClient(endpoint, logger),
+	}
+}
+
+// GetMetadata implements Adapter
+func (c *clairAdapter) GetMetadata() (*ScannerAdapterMetadata, error) {
+	return &ScannerAdapterMetadata{
+		Scanner: ClairScanner,
+		Capabilities: []*ScannerCapability{
+			{
+				ConsumesMimeTypes: []string{MimeTypeDockerArtifact},
+				ProducesMimeTypes: []string{MimeTypeNativeReport},
+			},
+		},
+	}, nil
+}
+
+// SubmitScan implements Adapter
+func (c *clairAdapter) SubmitScan(req *ScanRequest) (*ScanResponse, error) {
+	if req == nil || req.Registry == nil || req.Artifact == nil {
+		return nil, fmt.Errorf("invalid scan request: missing registry or artifact")
+	}
+
+	layers, err := prepareClairLayers(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(layers) == 0 {
+		return nil, fmt.Errorf("no layer found in artifact %s@%s", req.Artifact.Repository, req.Artifact.Digest)
+	}
+
+	for _, l := range layers {
+		if err := c.client.ScanLayer(l); err != nil {
+			return nil, fmt.Errorf("failed to scan layer %s: %v", l.Name, err)
+		}
+	}
+
+	return &ScanResponse{ID: layers[len(layers)-1].Name}, nil
+}
+
+// GetScanReport implements Adapter
+func (c *clairAdapter) GetScanReport(scanRequestID string) (*VulnerabilityReport, error) {
+	res, err := c.client.GetResult(scanRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get result from Clair: %v", err)
+	}
+
+	return ReportFromClairResult(res), nil
+}
+
+// ReportFromClairResult transforms the returned value of Clair API to a report
+func ReportFromClairResult(res *models.ClairLayerEnvelope) *VulnerabilityReport {
+	report := &VulnerabilityReport{
+		GeneratedAt:     time.Now().UTC(),
+		Scanner:         ClairScanner,
+		Vulnerabilities: []*VulnerabilityItem{},
+		Packages:        []*Package{},
+	}
+	if res == nil || res.Layer == nil {
+		return report
+	}
+
+	highest := models.SevNone
+	report.Severity = "Negligible"
+	for _, f := range res.Layer.Features {
+		report.Packages = append(report.Packages, &Package{
+			Name:    f.Name,
+			Version: f.Version,
+		})
+		for _, v := range f.Vulnerabilities {
+			item := &VulnerabilityItem{
+				ID:          v.Name,
+				Package:     f.Name,
+				Version:     f.Version,
+				FixVersion:  v.FixedBy,
+				Severity:    v.Severity,
+				Description: v.Description,
+				CVSS:        CVSSFromClairMetadata(v.Metadata),
+			}
+			if len(v.Link) > 0 {
+				item.Links = []string{v.Link}
+			}
+			report.Vulnerabilities = append(report.Vulnerabilities, item)
+
+			if sev := ParseSeverity(v.Severity); sev > highest {
+				highest = sev
+				report.Severity = v.Severity
+			}
+		}
+	}
+
+	return report
+}
+
+// prepareClairLayers pulls the manifest of the artifact and forms the chain of layers to be indexed by Clair
+func prepareClairLayers(req *ScanRequest) ([]models.ClairLayer, error) {
+	registryURL := strings.TrimSuffix(req.Registry.URL, "/")
+	repo := req.Artifact.Repository
+
+	payload, err := pullManifest(registryURL, repo, req.Artifact.Digest, req.Registry.Authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	layers := make([]models.ClairLayer, 0)
+	manifest, _, err := distribution.UnmarshalManifest(schema2.MediaTypeManifest, payload)
+	if err != nil {
+		return layers, err
+	}
+	tokenHeader := map[string]string{"Connection": "close", "Authorization": req.Registry.Authorization}
+	// form the chain by using the digests of all parent layers in the image, such that if another image is built on top of this image the layer name can be re-used.
+	shaChain := ""
+	for _, d := range manifest.References() {
+		if d.MediaType == schema2.MediaTypeImageConfig {
+			continue
+		}
+		shaChain += string(d.Digest) + "-"
+		l := models.ClairLayer{
+			Name:    fmt.Sprintf("%x", sha256.Sum256([]byte(shaChain))),
+			Headers: tokenHeader,
+			Format:  "Docker",
+			Path:    fmt.Sprintf("%s/v2/%s/blobs/%s", registryURL, repo, d.Digest),
+		}
+		if len(layers) > 0 {
+			l.ParentName = layers[len(layers)-1].Name
+		}
+		layers = append(layers, l)
+	}
+	return layers, nil
+}
+
+func pullManifest(registryURL, repo, reference, authorization string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v2/%s/manifests/%s", registryURL, repo, reference), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", schema2.MediaTypeManifest)
+	if len(authorization) > 0 {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	client := &http.Client{
+		Transport: commonhttp.GetHTTPTransport(),
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to pull manifest %s:%s, status code: %d, text: %s", repo, reference, resp.StatusCode, string(data))
+	}
+
+	return data, nil
+}
+
+// CVSSFromClairMetadata extracts the CVSS from the NVD metadata of the vulnerability reported by Clair,
+// the CVSSv3 is preferred if both versions are available. Nil is returned if there is no CVSS in the metadata.
+func CVSSFromClairMetadata(metadata map[string]interface{}) *CVSS {
+	nvd, ok := metadata["NVD"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for _, version := range []string{"CVSSv3", "CVSSv2"} {
+		m, ok := nvd[version].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		score, ok := m["Score"].(float64)
+		if !ok {
+			continue
+		}
+		vector, _ := m["Vectors"].(string)
+		return &CVSS{
+			Score:  score,
+			Vector: vector,
+		}
+	}
+	return nil
+}
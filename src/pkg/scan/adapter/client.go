@@ -0,0 +1,186 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+)
+
+// The default interval of fetching the report if the adapter doesn't set the Retry-After header
+const defaultRetryAfter = 5 * time.Second
+
+// client talks with the scanner adapter with the HTTP API:
+//
+//	GET  /api/v1/metadata
+//	POST /api/v1/scan
+//	GET  /api/v1/scan/{scan_request_id}/report
+type client struct {
+	endpoint   string
+	auth       string
+	credential string
+	client     *http.Client
+}
+
+// NewClient returns an adapter calling the HTTP API of the scanner adapter at the endpoint
+func NewClient(endpoint, auth, credential string, skipCertVerify bool) (Adapter, error) {
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid endpoint of scanner adapter %s: %v", endpoint, err)
+	}
+	if err := ValidateAuth(auth); err != nil {
+		return nil, err
+	}
+
+	return &client{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		auth:       auth,
+		credential: credential,
+		client: &http.Client{
+			Transport: commonhttp.GetHTTPTransport(skipCertVerify),
+			Timeout:   30 * time.Second,
+			// The adapter responds 302 when the report is not ready
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}, nil
+}
+
+// GetMetadata implements Adapter
+func (c *client) GetMetadata() (*ScannerAdapterMetadata, error) {
+	req, err := c.newRequest(http.MethodGet, "/api/v1/metadata", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", MimeTypeAdapterMeta)
+
+	_, data, err := c.send(req, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &ScannerAdapterMetadata{}
+	if err := json.Unmarshal(data, meta); err != nil {
+		return nil, fmt.Errorf("invalid metadata of scanner adapter: %v", err)
+	}
+
+	return meta, nil
+}
+
+// SubmitScan implements Adapter
+func (c *client) SubmitScan(scanReq *ScanRequest) (*ScanResponse, error) {
+	data, err := json.Marshal(scanReq)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(http.MethodPost, "/api/v1/scan", data)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", MimeTypeScanRequest)
+	req.Header.Set("Accept", MimeTypeScanResponse)
+
+	_, data, err = c.send(req, http.StatusAccepted)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ScanResponse{}
+	if err := json.Unmarshal(data, resp); err != nil {
+		return nil, fmt.Errorf("invalid scan response of scanner adapter: %v", err)
+	}
+	if len(resp.ID) == 0 {
+		return nil, fmt.Errorf("missing scan request ID in the scan response of scanner adapter")
+	}
+
+	return resp, nil
+}
+
+// GetScanReport implements Adapter
+func (c *client) GetScanReport(scanRequestID string) (*VulnerabilityReport, error) {
+	req, err := c.newRequest(http.MethodGet, fmt.Sprintf("/api/v1/scan/%s/report", url.PathEscape(scanRequestID)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", MimeTypeNativeReport)
+
+	resp, data, err := c.send(req, http.StatusOK, http.StatusFound)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusFound {
+		retryAfter := defaultRetryAfter
+		if v, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && v > 0 {
+			retryAfter = time.Duration(v) * time.Second
+		}
+		return nil, &ReportNotReadyError{RetryAfter: retryAfter}
+	}
+
+	report := &VulnerabilityReport{}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("invalid report of scanner adapter: %v", err)
+	}
+
+	return report, nil
+}
+
+func (c *client) newRequest(method, path string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequest(method, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.auth {
+	case AuthNone:
+	case AuthAPIKey:
+		req.Header.Set(AuthAPIKey, c.credential)
+	default:
+		req.Header.Set("Authorization", fmt.Sprintf("%s %s", c.auth, c.credential))
+	}
+
+	return req, nil
+}
+
+func (c *client) send(req *http.Request, expectedStatus ...int) (*http.Response, []byte, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, s := range expectedStatus {
+		if resp.StatusCode == s {
+			return resp, data, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("unexpected status code %d of %s %s, text: %s", resp.StatusCode, req.Method, req.URL.Path, string(data))
+}
@@ -0,0 +1,150 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// ClientTestSuite tests the client of the scanner adapter
+type ClientTestSuite struct {
+	suite.Suite
+
+	server *httptest.Server
+	client Adapter
+}
+
+// TestClientTestSuite is entry of go test
+func TestClientTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
+
+// SetupSuite prepares the test suite
+func (suite *ClientTestSuite) SetupSuite() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/metadata", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&ScannerAdapterMetadata{
+			Scanner: &Scanner{Name: "Trivy", Vendor: "Aqua Security", Version: "0.1"},
+			Capabilities: []*ScannerCapability{
+				{
+					ConsumesMimeTypes: []string{MimeTypeDockerArtifact},
+					ProducesMimeTypes: []string{MimeTypeNativeReport},
+				},
+			},
+		})
+	})
+	mux.HandleFunc("/api/v1/scan", func(w http.ResponseWriter, r *http.Request) {
+		req := &ScanRequest{}
+		if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(req) != nil || req.Artifact == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(&ScanResponse{ID: req.Artifact.Digest})
+	})
+	mux.HandleFunc("/api/v1/scan/pending/report", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(http.StatusFound)
+	})
+	mux.HandleFunc("/api/v1/scan/sha256:123/report", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&VulnerabilityReport{
+			Severity: "High",
+			Vulnerabilities: []*VulnerabilityItem{
+				{ID: "CVE-2018-6485", Package: "glibc", Version: "2.24", Severity: "High"},
+			},
+		})
+	})
+
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(AuthAPIKey) != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+
+	c, err := NewClient(suite.server.URL, AuthAPIKey, "key", false)
+	require.NoError(suite.T(), err)
+	suite.client = c
+}
+
+// TearDownSuite clears the test suite
+func (suite *ClientTestSuite) TearDownSuite() {
+	suite.server.Close()
+}
+
+// TestNewClient tests NewClient
+func (suite *ClientTestSuite) TestNewClient() {
+	_, err := NewClient("not-a-url", AuthNone, "", false)
+	assert.Error(suite.T(), err)
+	_, err = NewClient(suite.server.URL, "Digest", "", false)
+	assert.Error(suite.T(), err)
+}
+
+// TestGetMetadata tests GetMetadata
+func (suite *ClientTestSuite) TestGetMetadata() {
+	meta, err := suite.client.GetMetadata()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Trivy", meta.Scanner.Name)
+	assert.NoError(suite.T(), meta.Validate())
+
+	c, err := NewClient(suite.server.URL, AuthNone, "", false)
+	require.NoError(suite.T(), err)
+	_, err = c.GetMetadata()
+	assert.Error(suite.T(), err, "unauthorized: error expected")
+}
+
+// TestScan tests submitting the scan request and fetching the report
+func (suite *ClientTestSuite) TestScan() {
+	resp, err := suite.client.SubmitScan(&ScanRequest{
+		Registry: &Registry{URL: "http://registry:5000", Authorization: "Bearer token"},
+		Artifact: &Artifact{Repository: "library/alpine", Digest: "sha256:123", MimeType: MimeTypeDockerArtifact},
+	})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "sha256:123", resp.ID)
+
+	report, err := suite.client.GetScanReport(resp.ID)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "High", report.Severity)
+	assert.Len(suite.T(), report.Vulnerabilities, 1)
+
+	_, err = suite.client.GetScanReport("pending")
+	require.Error(suite.T(), err)
+	notReady, ok := IsReportNotReady(err)
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), 3*time.Second, notReady.RetryAfter)
+}
+
+// TestValidateMetadata tests validating the metadata
+func (suite *ClientTestSuite) TestValidateMetadata() {
+	meta := &ScannerAdapterMetadata{
+		Scanner: &Scanner{Name: "Anchore"},
+		Capabilities: []*ScannerCapability{
+			{
+				ConsumesMimeTypes: []string{MimeTypeOCIArtifact},
+				ProducesMimeTypes: []string{MimeTypeNativeReport},
+			},
+		},
+	}
+	assert.Error(suite.T(), meta.Validate())
+}
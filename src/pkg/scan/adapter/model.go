@@ -0,0 +1,146 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// MimeTypeDockerArtifact is the mime type of the docker image manifest v2 which can be scanned
+	MimeTypeDockerArtifact = "application/vnd.docker.distribution.manifest.v2+json"
+	// MimeTypeOCIArtifact is the mime type of the OCI image manifest which can be scanned
+	MimeTypeOCIArtifact = "application/vnd.oci.image.manifest.v1+json"
+	// MimeTypeNativeReport is the mime type of the vulnerability report understood by Harbor
+	MimeTypeNativeReport = "application/vnd.scanner.adapter.vuln.report.harbor+json; version=1.0"
+	// MimeTypeScanRequest is the mime type of the scan request
+	MimeTypeScanRequest = "application/vnd.scanner.adapter.scan.request+json; version=1.0"
+	// MimeTypeScanResponse is the mime type of the scan response
+	MimeTypeScanResponse = "application/vnd.scanner.adapter.scan.response+json; version=1.0"
+	// MimeTypeAdapterMeta is the mime type of the scanner adapter metadata
+	MimeTypeAdapterMeta = "application/vnd.scanner.adapter.metadata+json; version=1.0"
+)
+
+// ScannerAdapterMetadata describes the scanner behind the adapter and what it can do
+type ScannerAdapterMetadata struct {
+	Scanner      *Scanner             `json:"scanner"`
+	Capabilities []*ScannerCapability `json:"capabilities"`
+	Properties   map[string]string    `json:"properties,omitempty"`
+}
+
+// Scanner represents the scanner
+type Scanner struct {
+	Name    string `json:"name"`
+	Vendor  string `json:"vendor"`
+	Version string `json:"version"`
+}
+
+// ScannerCapability declares the mime types of the artifacts the scanner consumes
+// and the mime types of the reports it produces
+type ScannerCapability struct {
+	ConsumesMimeTypes []string `json:"consumes_mime_types"`
+	ProducesMimeTypes []string `json:"produces_mime_types"`
+}
+
+// Validate the metadata, the scanner must be able to scan the docker images
+// and produce the report in the native format of Harbor
+func (m *ScannerAdapterMetadata) Validate() error {
+	if m.Scanner == nil || len(m.Scanner.Name) == 0 {
+		return fmt.Errorf("missing scanner name in the metadata")
+	}
+
+	for _, c := range m.Capabilities {
+		if c != nil && contains(c.ConsumesMimeTypes, MimeTypeDockerArtifact) && contains(c.ProducesMimeTypes, MimeTypeNativeReport) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("scanner %s does not support to scan %s with report %s", m.Scanner.Name, MimeTypeDockerArtifact, MimeTypeNativeReport)
+}
+
+// ScanRequest is the request submitted to the scanner to scan an artifact
+type ScanRequest struct {
+	Registry *Registry `json:"registry"`
+	Artifact *Artifact `json:"artifact"`
+}
+
+// Registry is where the scanner pulls the artifact from
+type Registry struct {
+	URL string `json:"url"`
+	// The value of the Authorization header used to pull the artifact, e.g: "Bearer <token>"
+	Authorization string `json:"authorization"`
+}
+
+// Artifact is the artifact to be scanned
+type Artifact struct {
+	Repository string `json:"repository"`
+	Digest     string `json:"digest"`
+	Tag        string `json:"tag,omitempty"`
+	MimeType   string `json:"mime_type"`
+}
+
+// ScanResponse is returned by the scanner when the scan request is accepted
+type ScanResponse struct {
+	// The ID used to fetch the report
+	ID string `json:"id"`
+}
+
+// VulnerabilityReport is the report in the native format of Harbor
+type VulnerabilityReport struct {
+	GeneratedAt     time.Time            `json:"generated_at"`
+	Artifact        *Artifact            `json:"artifact,omitempty"`
+	Scanner         *Scanner             `json:"scanner,omitempty"`
+	Severity        string               `json:"severity"`
+	Vulnerabilities []*VulnerabilityItem `json:"vulnerabilities"`
+	// All the packages found in the artifact, including the ones without vulnerability.
+	// It's optional, the packages with vulnerabilities are counted if it's not provided.
+	Packages []*Package `json:"packages,omitempty"`
+}
+
+// VulnerabilityItem is a vulnerability found in a package
+type VulnerabilityItem struct {
+	ID          string   `json:"id"`
+	Package     string   `json:"package"`
+	Version     string   `json:"version"`
+	FixVersion  string   `json:"fix_version,omitempty"`
+	Severity    string   `json:"severity"`
+	Description string   `json:"description,omitempty"`
+	Links       []string `json:"links,omitempty"`
+	// The CVSS of the vulnerability, it's optional as not all the scanners report it
+	CVSS *CVSS `json:"cvss,omitempty"`
+}
+
+// CVSS is the score and the vector of the Common Vulnerability Scoring System
+type CVSS struct {
+	Score  float64 `json:"score"`
+	Vector string  `json:"vector,omitempty"`
+}
+
+// Package is a package found in the artifact
+type Package struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
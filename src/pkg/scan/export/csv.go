@@ -0,0 +1,64 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+)
+
+var csvHeader = []string{"repository", "tag", "digest", "cve_id", "severity", "cvss_score", "cvss_vector",
+	"package", "version", "fixed_version", "link", "description"}
+
+// CSV returns the vulnerabilities of the images in CSV format with one vulnerability per row
+func CSV(images []*Image) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	if err := w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	for _, img := range images {
+		for _, v := range img.Vulnerabilities {
+			score := ""
+			if v.CVSSScore > 0 {
+				score = strconv.FormatFloat(v.CVSSScore, 'f', 1, 64)
+			}
+			record := []string{img.Repository, img.Tag, img.Digest, v.ID, v.Severity.String(), score, v.CVSSVector,
+				v.Pkg, v.Version, v.Fixed, firstLink(&v), v.Description}
+			for i := range record {
+				record[i] = escapeFormula(record[i])
+			}
+			if err := w.Write(record); err != nil {
+				return nil, err
+			}
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// escapeFormula prefixes the value which may be interpreted as a formula by the spreadsheet applications
+// with a single quote, as the values are provided by the scanners and not trusted
+func escapeFormula(value string) string {
+	if len(value) > 0 && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,85 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/pkg/scan"
+)
+
+const (
+	// FormatSARIF is the SARIF 2.1.0 JSON format
+	FormatSARIF = "sarif"
+	// FormatCSV is the CSV format with one vulnerability per row
+	FormatCSV = "csv"
+	// MimeTypeSARIF is the mime type of the SARIF log
+	MimeTypeSARIF = "application/sarif+json"
+	// MimeTypeCSV is the mime type of the CSV file
+	MimeTypeCSV = "text/csv"
+
+	toolName = "Harbor"
+	toolURI  = "https://goharbor.io"
+)
+
+// Image is the scanned image whose vulnerabilities are exported
+type Image struct {
+	Repository      string
+	Tag             string
+	Digest          string
+	Vulnerabilities scan.VulnerabilityList
+}
+
+func (i *Image) String() string {
+	return fmt.Sprintf("%s:%s", i.Repository, i.Tag)
+}
+
+// Export exports the vulnerabilities of the images in the format, it returns the mime type and the content
+func Export(format string, images []*Image) (string, []byte, error) {
+	switch strings.ToLower(format) {
+	case "", FormatSARIF:
+		data, err := json.Marshal(NewSARIFLog(images))
+		return MimeTypeSARIF, data, err
+	case FormatCSV:
+		data, err := CSV(images)
+		return MimeTypeCSV, data, err
+	default:
+		return "", nil, fmt.Errorf("unsupported format of vulnerability report: %s", format)
+	}
+}
+
+// firstLink returns the first one of the links of the vulnerability, which are separated by space
+func firstLink(v *scan.VulnerabilityItem) string {
+	links := strings.Fields(v.Link)
+	if len(links) == 0 {
+		return ""
+	}
+	return links[0]
+}
+
+// level maps the severity of the vulnerability to the level of SARIF result
+func level(sev models.Severity) string {
+	switch sev {
+	case models.SevHigh:
+		return "error"
+	case models.SevMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/pkg/scan"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var images = []*Image{
+	{
+		Repository: "library/app",
+		Tag:        "1.0",
+		Digest:     "sha256:0204dc6e09fa57ab99ac40e415eb637d62c8b2571ecbbc9ca0eb5e2ad2b5c56f",
+		Vulnerabilities: scan.VulnerabilityList{
+			{
+				ID:          "CVE-2018-6485",
+				Severity:    models.SevHigh,
+				Pkg:         "glibc",
+				Version:     "2.24-11+deb9u4",
+				Fixed:       "2.24-11+deb9u5",
+				Description: "An integer overflow in glibc",
+				Link:        "https://security-tracker.debian.org/tracker/CVE-2018-6485 https://nvd.nist.gov/vuln/detail/CVE-2018-6485",
+				CVSSScore:   9.8,
+				CVSSVector:  "CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+			},
+			{ID: "CVE-2018-10754", Severity: models.SevLow, Pkg: "ncurses", Version: "6.0+20161126-1+deb9u2"},
+		},
+	},
+	{
+		Repository: "library/app",
+		Tag:        "2.0",
+		Digest:     "sha256:5d6f8ec0f1a2b4fd4fe6e0eb1a9e38ab0d4e1c2d3bf0a5e7c4ae9c6b1f3f2e4a",
+		Vulnerabilities: scan.VulnerabilityList{
+			{ID: "CVE-2018-6485", Severity: models.SevHigh, Pkg: "glibc", Version: "2.24-11+deb9u4"},
+		},
+	},
+}
+
+func TestExport(t *testing.T) {
+	mimeType, _, err := Export("", images)
+	require.Nil(t, err)
+	assert.Equal(t, MimeTypeSARIF, mimeType)
+
+	mimeType, _, err = Export("CSV", images)
+	require.Nil(t, err)
+	assert.Equal(t, MimeTypeCSV, mimeType)
+
+	_, _, err = Export("html", images)
+	assert.NotNil(t, err)
+}
+
+func TestNewSARIFLog(t *testing.T) {
+	l := NewSARIFLog(images)
+	assert.Equal(t, "2.1.0", l.Version)
+	require.Len(t, l.Runs, 1)
+	run := l.Runs[0]
+	assert.Equal(t, toolName, run.Tool.Driver.Name)
+	require.Len(t, run.Tool.Driver.Rules, 2, "the vulnerabilities with the same ID share the rule")
+	rule := run.Tool.Driver.Rules[0]
+	assert.Equal(t, "CVE-2018-6485", rule.ID)
+	assert.Equal(t, "https://security-tracker.debian.org/tracker/CVE-2018-6485", rule.HelpURI)
+	assert.Equal(t, "9.8", rule.Properties["security-severity"])
+	assert.Nil(t, run.Tool.Driver.Rules[1].FullDescription)
+
+	require.Len(t, run.Results, 3)
+	assert.Equal(t, "error", run.Results[0].Level)
+	assert.Equal(t, "note", run.Results[1].Level)
+	assert.Equal(t, 1, run.Results[1].RuleIndex)
+	assert.Equal(t, 0, run.Results[2].RuleIndex)
+	assert.Equal(t, "library/app@sha256:5d6f8ec0f1a2b4fd4fe6e0eb1a9e38ab0d4e1c2d3bf0a5e7c4ae9c6b1f3f2e4a",
+		run.Results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+
+	data, err := json.Marshal(l)
+	require.Nil(t, err)
+	m := map[string]interface{}{}
+	require.Nil(t, json.Unmarshal(data, &m))
+	assert.Equal(t, sarifSchema, m["$schema"])
+
+	// the results are empty rather than null for the images without vulnerabilities
+	data, err = json.Marshal(NewSARIFLog(nil))
+	require.Nil(t, err)
+	assert.Contains(t, string(data), `"results":[]`)
+}
+
+func TestCSV(t *testing.T) {
+	data, err := CSV(images)
+	require.Nil(t, err)
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.Nil(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, csvHeader, records[0])
+	assert.Equal(t, []string{"library/app", "1.0", "sha256:0204dc6e09fa57ab99ac40e415eb637d62c8b2571ecbbc9ca0eb5e2ad2b5c56f",
+		"CVE-2018-6485", "high", "9.8", "CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", "glibc", "2.24-11+deb9u4",
+		"2.24-11+deb9u5", "https://security-tracker.debian.org/tracker/CVE-2018-6485", "An integer overflow in glibc"}, records[1])
+	assert.Equal(t, "", records[2][5])
+	assert.Equal(t, "2.0", records[3][1])
+
+	// the values provided by the scanner are not interpreted as formulas
+	data, err = CSV([]*Image{{
+		Repository: "library/app",
+		Tag:        "1.0",
+		Vulnerabilities: scan.VulnerabilityList{
+			{ID: "CVE-2018-6485", Pkg: "=HYPERLINK(\"http://evil\")", Version: "-1", Link: "@SUM(1)", Description: "+cmd"},
+		},
+	}})
+	require.Nil(t, err)
+	records, err = csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.Nil(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "'=HYPERLINK(\"http://evil\")", records[1][7])
+	assert.Equal(t, "'-1", records[1][8])
+	assert.Equal(t, "'@SUM(1)", records[1][10])
+	assert.Equal(t, "'+cmd", records[1][11])
+}
@@ -0,0 +1,177 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"fmt"
+)
+
+const (
+	sarifSchema  = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// SARIFLog is the SARIF 2.1.0 log, each vulnerability found in an image is a result
+// and the vulnerabilities with the same ID share the rule
+type SARIFLog struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []*SARIFRun `json:"runs"`
+}
+
+// SARIFRun ...
+type SARIFRun struct {
+	Tool    *SARIFTool     `json:"tool"`
+	Results []*SARIFResult `json:"results"`
+}
+
+// SARIFTool ...
+type SARIFTool struct {
+	Driver *SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver ...
+type SARIFDriver struct {
+	Name           string       `json:"name"`
+	InformationURI string       `json:"informationUri"`
+	Rules          []*SARIFRule `json:"rules"`
+}
+
+// SARIFRule ...
+type SARIFRule struct {
+	ID                   string                 `json:"id"`
+	ShortDescription     *SARIFMessage          `json:"shortDescription"`
+	FullDescription      *SARIFMessage          `json:"fullDescription,omitempty"`
+	HelpURI              string                 `json:"helpUri,omitempty"`
+	DefaultConfiguration *SARIFConfiguration    `json:"defaultConfiguration"`
+	Properties           map[string]interface{} `json:"properties,omitempty"`
+}
+
+// SARIFMessage ...
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFConfiguration ...
+type SARIFConfiguration struct {
+	Level string `json:"level"`
+}
+
+// SARIFResult ...
+type SARIFResult struct {
+	RuleID     string                 `json:"ruleId"`
+	RuleIndex  int                    `json:"ruleIndex"`
+	Level      string                 `json:"level"`
+	Message    *SARIFMessage          `json:"message"`
+	Locations  []*SARIFLocation       `json:"locations"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// SARIFLocation ...
+type SARIFLocation struct {
+	PhysicalLocation *SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation ...
+type SARIFPhysicalLocation struct {
+	ArtifactLocation *SARIFArtifactLocation `json:"artifactLocation"`
+}
+
+// SARIFArtifactLocation ...
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// NewSARIFLog returns the SARIF log of the vulnerabilities of the images
+func NewSARIFLog(images []*Image) *SARIFLog {
+	driver := &SARIFDriver{
+		Name:           toolName,
+		InformationURI: toolURI,
+		Rules:          []*SARIFRule{},
+	}
+	results := []*SARIFResult{}
+	ruleIndexes := map[string]int{}
+	for _, img := range images {
+		for i := range img.Vulnerabilities {
+			v := &img.Vulnerabilities[i]
+			index, ok := ruleIndexes[v.ID]
+			if !ok {
+				index = len(driver.Rules)
+				ruleIndexes[v.ID] = index
+				rule := &SARIFRule{
+					ID:                   v.ID,
+					ShortDescription:     &SARIFMessage{Text: v.ID},
+					HelpURI:              firstLink(v),
+					DefaultConfiguration: &SARIFConfiguration{Level: level(v.Severity)},
+					Properties: map[string]interface{}{
+						"tags": []string{"security", "vulnerability"},
+					},
+				}
+				if len(v.Description) > 0 {
+					rule.FullDescription = &SARIFMessage{Text: v.Description}
+				}
+				if v.CVSSScore > 0 {
+					rule.Properties["security-severity"] = fmt.Sprintf("%.1f", v.CVSSScore)
+				}
+				driver.Rules = append(driver.Rules, rule)
+			}
+
+			msg := fmt.Sprintf("Package %s %s in image %s is affected by %s, severity: %s", v.Pkg, v.Version, img, v.ID, v.Severity)
+			if len(v.Fixed) > 0 {
+				msg = fmt.Sprintf("%s, fixed version: %s", msg, v.Fixed)
+			}
+			result := &SARIFResult{
+				RuleID:    v.ID,
+				RuleIndex: index,
+				Level:     level(v.Severity),
+				Message:   &SARIFMessage{Text: msg + "."},
+				Locations: []*SARIFLocation{
+					{
+						PhysicalLocation: &SARIFPhysicalLocation{
+							ArtifactLocation: &SARIFArtifactLocation{
+								URI: fmt.Sprintf("%s@%s", img.Repository, img.Digest),
+							},
+						},
+					},
+				},
+				Properties: map[string]interface{}{
+					"repository": img.Repository,
+					"tag":        img.Tag,
+					"digest":     img.Digest,
+					"package":    v.Pkg,
+					"version":    v.Version,
+				},
+			}
+			if len(v.Fixed) > 0 {
+				result.Properties["fixedVersion"] = v.Fixed
+			}
+			if v.CVSSScore > 0 {
+				result.Properties["cvssScore"] = v.CVSSScore
+				result.Properties["cvssVector"] = v.CVSSVector
+			}
+			results = append(results, result)
+		}
+	}
+	return &SARIFLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []*SARIFRun{
+			{
+				Tool:    &SARIFTool{Driver: driver},
+				Results: results,
+			},
+		},
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quarantine
+
+import (
+	"fmt"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/pkg/scan"
+)
+
+// PolicyGetter returns the vulnerable policy of the project
+type PolicyGetter func(projectName string) *models.VulnerablePolicy
+
+// Quarantine quarantines the image pushed to the project with quarantine on push enabled,
+// the image keeps its current state if it's pushed before, e.g. tagged with another tag.
+// It returns whether the image is quarantined by this call.
+func Quarantine(repository, tag, digest string) (bool, error) {
+	created, err := dao.AddImgQuarantine(&models.ImgQuarantine{
+		Repository: repository,
+		Digest:     digest,
+		Tag:        tag,
+		Status:     models.QuarantineStatusQuarantined,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to quarantine image %s:%s(%s): %v", repository, tag, digest, err)
+	}
+	if created {
+		log.Infof("Image %s:%s(%s) is quarantined until its scan is finished", repository, tag, digest)
+	}
+	return created, nil
+}
+
+// Release removes the quarantine record of the image, e.g. when the push of the image is not accepted
+// by the registry, so no scan is triggered to promote or reject it
+func Release(repository, digest string) error {
+	if err := dao.DeleteImgQuarantine(repository, digest); err != nil {
+		return fmt.Errorf("failed to release image %s(%s): %v", repository, digest, err)
+	}
+	log.Infof("Image %s(%s) is released from quarantine", repository, digest)
+	return nil
+}
+
+// Blocked returns the quarantine record of the image if it can't be pulled, otherwise nil is returned
+func Blocked(repository, digest string) (*models.ImgQuarantine, error) {
+	q, err := dao.GetImgQuarantine(repository, digest)
+	if err != nil {
+		return nil, err
+	}
+	if q == nil || !q.Blocked() {
+		return nil, nil
+	}
+	return q, nil
+}
+
+// Evaluate promotes or rejects the quarantined image scanned by the scan job according to the vulnerable policy
+// of the project. The rejected image is evaluated again when it's rescanned, e.g. after the whitelist is updated.
+func Evaluate(jobID int64, succeeded bool, policyOf PolicyGetter) error {
+	job, err := dao.GetScanJob(jobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return nil
+	}
+	q, err := Blocked(job.Repository, job.Digest)
+	if err != nil {
+		return err
+	}
+	if q == nil {
+		return nil
+	}
+
+	var vl scan.VulnerabilityList
+	var policy *models.VulnerablePolicy
+	if succeeded {
+		projectName, _ := utils.ParseRepository(job.Repository)
+		policy = policyOf(projectName)
+		if policy.Enabled {
+			if vl, err = scan.VulnListByDigest(job.Digest); err != nil {
+				return err
+			}
+		}
+	}
+	status, reason := decide(succeeded, policy, vl)
+	if err := dao.UpdateImgQuarantineStatus(q.ID, status, reason); err != nil {
+		return err
+	}
+	log.Infof("Image %s:%s(%s) is %s, %s", job.Repository, job.Tag, job.Digest, status, reason)
+	return nil
+}
+
+// decide returns the status of the quarantined image and the reason
+func decide(succeeded bool, policy *models.VulnerablePolicy, vl scan.VulnerabilityList) (string, string) {
+	if !succeeded {
+		return models.QuarantineStatusRejected, "The scan of the image failed."
+	}
+	if !policy.Enabled {
+		return models.QuarantineStatusPromoted, "The vulnerable policy of the project isn't enabled."
+	}
+	vl.ApplyWhitelist(policy.Whitelist)
+	if violation := vl.PolicyViolation(policy); len(violation) > 0 {
+		return models.QuarantineStatusRejected, violation
+	}
+	return models.QuarantineStatusPromoted, "The image passed the vulnerable policy of the project."
+}
@@ -0,0 +1,71 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quarantine
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/pkg/scan"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecide(t *testing.T) {
+	vl := func() scan.VulnerabilityList {
+		return scan.VulnerabilityList{
+			{ID: "CVE-2018-6485", Severity: models.SevHigh, Fixed: "2.24-11+deb9u5", CVSSScore: 9.8},
+			{ID: "CVE-2018-10754", Severity: models.SevLow},
+		}
+	}
+	cases := []struct {
+		succeeded bool
+		policy    *models.VulnerablePolicy
+		expect    string
+	}{
+		{
+			succeeded: false,
+			expect:    models.QuarantineStatusRejected,
+		},
+		{
+			succeeded: true,
+			policy:    &models.VulnerablePolicy{Enabled: false},
+			expect:    models.QuarantineStatusPromoted,
+		},
+		{
+			succeeded: true,
+			policy:    &models.VulnerablePolicy{Enabled: true, Severity: models.SevHigh},
+			expect:    models.QuarantineStatusRejected,
+		},
+		{
+			succeeded: true,
+			policy: &models.VulnerablePolicy{
+				Enabled:   true,
+				Severity:  models.SevHigh,
+				Whitelist: models.CVEWhitelist{Items: []models.CVEWhitelistItem{{CVEID: "CVE-2018-6485"}}},
+			},
+			expect: models.QuarantineStatusPromoted,
+		},
+		{
+			succeeded: true,
+			policy:    &models.VulnerablePolicy{Enabled: true, Severity: models.SevLow, CVSSThreshold: 9.9, FixableOnly: true},
+			expect:    models.QuarantineStatusPromoted,
+		},
+	}
+	for _, c := range cases {
+		status, reason := decide(c.succeeded, c.policy, vl())
+		assert.Equal(t, c.expect, status)
+		assert.NotEmpty(t, reason)
+	}
+}
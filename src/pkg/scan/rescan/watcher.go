@@ -0,0 +1,219 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rescan
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	clairdao "github.com/goharbor/harbor/src/common/dao/clair"
+	cjob "github.com/goharbor/harbor/src/common/job"
+	jobmodels "github.com/goharbor/harbor/src/common/job/models"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/core/config"
+	coreutils "github.com/goharbor/harbor/src/core/utils"
+	"github.com/goharbor/harbor/src/pkg/scan/scanner"
+)
+
+const (
+	// DBUpdatedAtProperty is the property in the metadata of the scanner adapter
+	// reporting the time when the vulnerability database is updated, in RFC3339 format
+	DBUpdatedAtProperty = "harbor.scanner-adapter/vulnerability-database-updated-at"
+
+	// How often the update time of the vulnerability databases is checked
+	checkInterval = 10 * time.Minute
+	// The rescan job created before the time is considered lost if it's still pending or running
+	jobTimeout = 24 * time.Hour
+)
+
+// watcher checks the update time of the vulnerability databases of the scanners, and submits
+// a rescan job to jobservice for the images scanned before the update when it's updated
+type watcher struct {
+	registrations func() ([]*models.ScannerRegistration, error)
+	dbUpdatedAt   func(registration *models.ScannerRegistration) (time.Time, error)
+	inProgress    func() (bool, error)
+	submit        func(parms *cjob.RescanJobParms) error
+	// the last update time of the vulnerability database handled for each scanner
+	handled map[string]time.Time
+}
+
+// Start watches the vulnerability databases of the scanners until closing is closed,
+// the images are rescanned only when the automatic rescan is enabled in the configuration.
+func Start(closing <-chan struct{}) {
+	w := newWatcher()
+	go func() {
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if !config.AutoRescanEnabled() {
+					continue
+				}
+				if err := w.check(); err != nil {
+					log.Errorf("Failed to check the update of the vulnerability databases: %v", err)
+				}
+			case <-closing:
+				log.Debug("Stop watching the update of the vulnerability databases")
+				return
+			}
+		}
+	}()
+}
+
+func newWatcher() *watcher {
+	return &watcher{
+		registrations: scanner.NewDefaultManager().List,
+		dbUpdatedAt:   dbUpdatedAt,
+		inProgress:    inProgress,
+		submit:        submit,
+		handled:       map[string]time.Time{},
+	}
+}
+
+// check submits the rescan job for the scanner whose vulnerability database is updated.
+// One job is submitted at a time, the updates of other scanners are handled in the next checks
+// after the job completes, so are the updates failed to be submitted.
+func (w *watcher) check() error {
+	busy, err := w.inProgress()
+	if err != nil {
+		return err
+	}
+	if busy {
+		log.Debug("The rescan job is in progress, skip checking the update of the vulnerability databases")
+		return nil
+	}
+
+	rs, err := w.registrations()
+	if err != nil {
+		return err
+	}
+	for _, r := range rs {
+		if r.Disabled {
+			continue
+		}
+		updatedAt, err := w.dbUpdatedAt(r)
+		if err != nil {
+			log.Warningf("Failed to get the update time of the vulnerability database of scanner %s: %v", r.Name, err)
+			continue
+		}
+		if updatedAt.IsZero() || !updatedAt.After(w.handled[r.UUID]) {
+			continue
+		}
+
+		log.Infof("The vulnerability database of scanner %s is updated at %s, rescanning the images", r.Name, updatedAt)
+		parms := &cjob.RescanJobParms{
+			ScannerUUIDs:  []string{r.UUID},
+			ScannedBefore: updatedAt.Unix(),
+		}
+		if r.UUID == scanner.BuiltinClairUUID {
+			// the scanner isn't recorded for the images scanned by the built-in Clair in the earlier versions
+			parms.ScannerUUIDs = append(parms.ScannerUUIDs, "")
+		}
+		if days := config.AutoRescanPulledWithinDays(); days > 0 {
+			parms.PulledAfter = time.Now().Add(-time.Duration(days) * 24 * time.Hour).Unix()
+		}
+		if err := w.submit(parms); err != nil {
+			return fmt.Errorf("failed to submit the rescan job for scanner %s: %v", r.Name, err)
+		}
+		w.handled[r.UUID] = updatedAt
+		return nil
+	}
+	return nil
+}
+
+// dbUpdatedAt returns the update time of the vulnerability database of the scanner,
+// zero time is returned if the scanner doesn't report it
+func dbUpdatedAt(registration *models.ScannerRegistration) (time.Time, error) {
+	if registration.UUID == scanner.BuiltinClairUUID {
+		last, err := clairdao.GetLastUpdate()
+		if err != nil || last <= 0 {
+			return time.Time{}, err
+		}
+		return time.Unix(last, 0), nil
+	}
+
+	a, err := scanner.NewAdapter(registration)
+	if err != nil {
+		return time.Time{}, err
+	}
+	meta, err := a.GetMetadata()
+	if err != nil {
+		return time.Time{}, err
+	}
+	v, ok := meta.Properties[DBUpdatedAtProperty]
+	if !ok || len(v) == 0 {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+// inProgress checks whether there is a rescan job pending or running
+func inProgress() (bool, error) {
+	for _, status := range []string{models.JobPending, models.JobRunning} {
+		jobs, err := dao.GetAdminJobs(&models.AdminJobQuery{
+			Name:   cjob.ImageRescanJob,
+			Status: status,
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, j := range jobs {
+			if time.Since(j.CreationTime) < jobTimeout {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// submit submits the rescan job to jobservice, it's recorded as an admin job
+func submit(parms *cjob.RescanJobParms) error {
+	data, err := json.Marshal(parms)
+	if err != nil {
+		return err
+	}
+	parameters := map[string]interface{}{}
+	if err := json.Unmarshal(data, &parameters); err != nil {
+		return err
+	}
+
+	id, err := dao.AddAdminJob(&models.AdminJob{
+		Name: cjob.ImageRescanJob,
+		Kind: cjob.JobKindGeneric,
+	})
+	if err != nil {
+		return err
+	}
+	uuid, err := coreutils.GetJobServiceClient().SubmitJob(&jobmodels.JobData{
+		Name:       cjob.ImageRescanJob,
+		Parameters: parameters,
+		Metadata: &jobmodels.JobMetadata{
+			JobKind:  cjob.JobKindGeneric,
+			IsUnique: true,
+		},
+		StatusHook: fmt.Sprintf("%s/service/notifications/jobs/adminjob/%d", config.InternalCoreURL(), id),
+	})
+	if err != nil {
+		if err := dao.DeleteAdminJob(id); err != nil {
+			log.Debugf("Failed to delete admin job, err: %v", err)
+		}
+		return err
+	}
+	return dao.SetAdminJobUUID(id, uuid)
+}
@@ -0,0 +1,137 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rescan
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/common"
+	cjob "github.com/goharbor/harbor/src/common/job"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/pkg/scan/scanner"
+	"github.com/stretchr/testify/suite"
+)
+
+// WatcherTestSuite is test suite of the watcher of the vulnerability databases
+type WatcherTestSuite struct {
+	suite.Suite
+
+	w         *watcher
+	updates   map[string]time.Time
+	busy      bool
+	submitted []*cjob.RescanJobParms
+	submitErr error
+}
+
+// TestWatcher is the entry of WatcherTestSuite
+func TestWatcher(t *testing.T) {
+	suite.Run(t, new(WatcherTestSuite))
+}
+
+// SetupTest prepares the watcher with the fake scanners
+func (suite *WatcherTestSuite) SetupTest() {
+	config.InitWithSettings(map[string]interface{}{
+		common.AutoRescanPulledWithinDays: 0,
+	})
+	suite.updates = map[string]time.Time{
+		scanner.BuiltinClairUUID: time.Unix(1000, 0),
+		"trivy":                  time.Unix(2000, 0),
+	}
+	suite.busy = false
+	suite.submitted = nil
+	suite.submitErr = nil
+
+	suite.w = &watcher{
+		registrations: func() ([]*models.ScannerRegistration, error) {
+			return []*models.ScannerRegistration{
+				{UUID: scanner.BuiltinClairUUID, Name: "Clair"},
+				{UUID: "trivy", Name: "Trivy"},
+				{UUID: "disabled", Name: "Disabled", Disabled: true},
+			}, nil
+		},
+		dbUpdatedAt: func(r *models.ScannerRegistration) (time.Time, error) {
+			if r.Disabled {
+				return time.Time{}, errors.New("should not be called")
+			}
+			return suite.updates[r.UUID], nil
+		},
+		inProgress: func() (bool, error) {
+			return suite.busy, nil
+		},
+		submit: func(parms *cjob.RescanJobParms) error {
+			if suite.submitErr != nil {
+				return suite.submitErr
+			}
+			suite.submitted = append(suite.submitted, parms)
+			return nil
+		},
+		handled: map[string]time.Time{},
+	}
+}
+
+// TestCheck tests the updates are handled one by one
+func (suite *WatcherTestSuite) TestCheck() {
+	suite.Require().NoError(suite.w.check())
+	suite.Require().Len(suite.submitted, 1)
+	suite.Equal([]string{scanner.BuiltinClairUUID, ""}, suite.submitted[0].ScannerUUIDs)
+	suite.Equal(int64(1000), suite.submitted[0].ScannedBefore)
+	suite.Equal(int64(0), suite.submitted[0].PulledAfter)
+
+	// the rescan job is in progress
+	suite.busy = true
+	suite.Require().NoError(suite.w.check())
+	suite.Len(suite.submitted, 1)
+
+	suite.busy = false
+	suite.Require().NoError(suite.w.check())
+	suite.Require().Len(suite.submitted, 2)
+	suite.Equal([]string{"trivy"}, suite.submitted[1].ScannerUUIDs)
+	suite.Equal(int64(2000), suite.submitted[1].ScannedBefore)
+
+	// nothing updated
+	suite.Require().NoError(suite.w.check())
+	suite.Len(suite.submitted, 2)
+
+	suite.updates["trivy"] = time.Unix(3000, 0)
+	suite.Require().NoError(suite.w.check())
+	suite.Require().Len(suite.submitted, 3)
+	suite.Equal(int64(3000), suite.submitted[2].ScannedBefore)
+}
+
+// TestCheckSubmitFailure tests the update is handled again if the job fails to be submitted
+func (suite *WatcherTestSuite) TestCheckSubmitFailure() {
+	suite.submitErr = errors.New("jobservice is down")
+	suite.Error(suite.w.check())
+	suite.Len(suite.submitted, 0)
+
+	suite.submitErr = nil
+	suite.Require().NoError(suite.w.check())
+	suite.Require().Len(suite.submitted, 1)
+	suite.Equal(int64(1000), suite.submitted[0].ScannedBefore)
+}
+
+// TestCheckPulledWithinDays tests the images not pulled recently are skipped
+func (suite *WatcherTestSuite) TestCheckPulledWithinDays() {
+	config.InitWithSettings(map[string]interface{}{
+		common.AutoRescanPulledWithinDays: 7,
+	})
+	suite.Require().NoError(suite.w.check())
+	suite.Require().Len(suite.submitted, 1)
+	pulledAfter := time.Unix(suite.submitted[0].PulledAfter, 0)
+	suite.WithinDuration(time.Now().Add(-7*24*time.Hour), pulledAfter, time.Minute)
+}
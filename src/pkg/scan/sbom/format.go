@@ -0,0 +1,215 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/models"
+)
+
+const (
+	// FormatSPDX is the SPDX 2.2 JSON format
+	FormatSPDX = "spdx"
+	// FormatCycloneDX is the CycloneDX 1.2 JSON format
+	FormatCycloneDX = "cyclonedx"
+	// MimeTypeSPDX is the mime type of the SPDX JSON document
+	MimeTypeSPDX = "application/spdx+json"
+	// MimeTypeCycloneDX is the mime type of the CycloneDX JSON document
+	MimeTypeCycloneDX = "application/vnd.cyclonedx+json"
+
+	noAssertion = "NOASSERTION"
+	toolName    = "Harbor"
+)
+
+// Artifact is the image the software bill of materials is generated for
+type Artifact struct {
+	Repository string
+	Tag        string
+	Digest     string
+	// The URL of Harbor, it's used to form the unique namespace of the SPDX document
+	BaseURL string
+}
+
+// Generate generates the software bill of materials of the artifact in the format,
+// it returns the mime type and the document to be marshaled as JSON
+func Generate(format string, artifact *Artifact, components []*models.ImgComponent, created time.Time) (string, interface{}, error) {
+	switch strings.ToLower(format) {
+	case "", FormatSPDX:
+		return MimeTypeSPDX, NewSPDXDocument(artifact, components, created), nil
+	case FormatCycloneDX:
+		return MimeTypeCycloneDX, NewCycloneDXDocument(artifact, components, created), nil
+	default:
+		return "", nil, fmt.Errorf("unsupported format of software bill of materials: %s", format)
+	}
+}
+
+// SPDXDocument is the SPDX 2.2 document
+type SPDXDocument struct {
+	SPDXVersion       string              `json:"spdxVersion"`
+	DataLicense       string              `json:"dataLicense"`
+	SPDXID            string              `json:"SPDXID"`
+	Name              string              `json:"name"`
+	DocumentNamespace string              `json:"documentNamespace"`
+	CreationInfo      *SPDXCreationInfo   `json:"creationInfo"`
+	DocumentDescribes []string            `json:"documentDescribes"`
+	Packages          []*SPDXPackage      `json:"packages"`
+	Relationships     []*SPDXRelationship `json:"relationships"`
+}
+
+// SPDXCreationInfo ...
+type SPDXCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+// SPDXPackage ...
+type SPDXPackage struct {
+	SPDXID           string `json:"SPDXID"`
+	Name             string `json:"name"`
+	VersionInfo      string `json:"versionInfo,omitempty"`
+	DownloadLocation string `json:"downloadLocation"`
+	FilesAnalyzed    bool   `json:"filesAnalyzed"`
+	LicenseConcluded string `json:"licenseConcluded"`
+	LicenseDeclared  string `json:"licenseDeclared"`
+	CopyrightText    string `json:"copyrightText"`
+}
+
+// SPDXRelationship ...
+type SPDXRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// NewSPDXDocument returns the SPDX document describing the image which contains the components
+func NewSPDXDocument(artifact *Artifact, components []*models.ImgComponent, created time.Time) *SPDXDocument {
+	imageID := "SPDXRef-Image"
+	doc := &SPDXDocument{
+		SPDXVersion:       "SPDX-2.2",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              artifact.name(),
+		DocumentNamespace: fmt.Sprintf("%s/spdx/%s@%s", strings.TrimSuffix(artifact.BaseURL, "/"), artifact.Repository, artifact.Digest),
+		CreationInfo: &SPDXCreationInfo{
+			Created:  created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: " + toolName},
+		},
+		DocumentDescribes: []string{imageID},
+		Packages: []*SPDXPackage{
+			newSPDXPackage(imageID, artifact.Repository, artifact.Digest),
+		},
+		Relationships: []*SPDXRelationship{},
+	}
+	for i, c := range components {
+		id := fmt.Sprintf("SPDXRef-Package-%d", i+1)
+		doc.Packages = append(doc.Packages, newSPDXPackage(id, c.Name, c.Version))
+		doc.Relationships = append(doc.Relationships, &SPDXRelationship{
+			SPDXElementID:      imageID,
+			RelationshipType:   "CONTAINS",
+			RelatedSPDXElement: id,
+		})
+	}
+	return doc
+}
+
+func newSPDXPackage(id, name, version string) *SPDXPackage {
+	return &SPDXPackage{
+		SPDXID:           id,
+		Name:             name,
+		VersionInfo:      version,
+		DownloadLocation: noAssertion,
+		LicenseConcluded: noAssertion,
+		LicenseDeclared:  noAssertion,
+		CopyrightText:    noAssertion,
+	}
+}
+
+// CycloneDXDocument is the CycloneDX 1.2 document
+type CycloneDXDocument struct {
+	BOMFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	SerialNumber string                `json:"serialNumber"`
+	Version      int                   `json:"version"`
+	Metadata     *CycloneDXMetadata    `json:"metadata"`
+	Components   []*CycloneDXComponent `json:"components"`
+}
+
+// CycloneDXMetadata ...
+type CycloneDXMetadata struct {
+	Timestamp string              `json:"timestamp"`
+	Tools     []*CycloneDXTool    `json:"tools"`
+	Component *CycloneDXComponent `json:"component"`
+}
+
+// CycloneDXTool ...
+type CycloneDXTool struct {
+	Name string `json:"name"`
+}
+
+// CycloneDXComponent ...
+type CycloneDXComponent struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// NewCycloneDXDocument returns the CycloneDX document describing the image which contains the components
+func NewCycloneDXDocument(artifact *Artifact, components []*models.ImgComponent, created time.Time) *CycloneDXDocument {
+	doc := &CycloneDXDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.2",
+		SerialNumber: serialNumber(artifact.Digest),
+		Version:      1,
+		Metadata: &CycloneDXMetadata{
+			Timestamp: created.UTC().Format(time.RFC3339),
+			Tools:     []*CycloneDXTool{{Name: toolName}},
+			Component: &CycloneDXComponent{
+				Type:    "container",
+				Name:    artifact.Repository,
+				Version: artifact.Digest,
+			},
+		},
+		Components: []*CycloneDXComponent{},
+	}
+	for _, c := range components {
+		doc.Components = append(doc.Components, &CycloneDXComponent{
+			Type:    "library",
+			Name:    c.Name,
+			Version: c.Version,
+		})
+	}
+	return doc
+}
+
+// serialNumber derives the serial number of the CycloneDX document from the digest of the image,
+// so that the document of the same image has the same serial number
+func serialNumber(digest string) string {
+	h := sha256.Sum256([]byte(digest))
+	// Set the version and variant bits as a random UUID
+	h[6] = (h[6] & 0x0f) | 0x40
+	h[8] = (h[8] & 0x3f) | 0x80
+	return fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", h[0:4], h[4:6], h[6:8], h[8:10], h[10:16])
+}
+
+func (a *Artifact) name() string {
+	if len(a.Tag) > 0 {
+		return fmt.Sprintf("%s:%s", a.Repository, a.Tag)
+	}
+	return fmt.Sprintf("%s@%s", a.Repository, a.Digest)
+}
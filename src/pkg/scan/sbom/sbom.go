@@ -0,0 +1,98 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/pkg/scan"
+	"github.com/goharbor/harbor/src/pkg/scan/adapter"
+)
+
+// ComponentsFromReport returns the components found in the image from the scan report.
+// The packages listed in the report are used, or the vulnerable packages if the scanner doesn't list the packages.
+func ComponentsFromReport(report *adapter.VulnerabilityReport) []*models.ImgComponent {
+	type key struct {
+		name    string
+		version string
+	}
+	seen := map[key]bool{}
+	components := []*models.ImgComponent{}
+	add := func(name, version string) {
+		k := key{name, version}
+		if len(name) == 0 || seen[k] {
+			return
+		}
+		seen[k] = true
+		components = append(components, &models.ImgComponent{
+			Name:    name,
+			Version: version,
+		})
+	}
+
+	if report == nil {
+		return components
+	}
+	if len(report.Packages) > 0 {
+		for _, p := range report.Packages {
+			if p != nil {
+				add(p.Name, p.Version)
+			}
+		}
+	} else {
+		for _, v := range report.Vulnerabilities {
+			if v != nil {
+				add(v.Package, v.Version)
+			}
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		if components[i].Name != components[j].Name {
+			return components[i].Name < components[j].Name
+		}
+		return components[i].Version < components[j].Version
+	})
+	return components
+}
+
+// Save keeps the components found in the image with the digest from the scan report
+func Save(digest string, report *adapter.VulnerabilityReport) error {
+	return dao.SetImgComponents(digest, ComponentsFromReport(report))
+}
+
+// ComponentsByDigest returns the components found in the image with the digest.
+// For the image scanned before the components are kept, they're captured from the scan result and kept.
+func ComponentsByDigest(digest string) ([]*models.ImgComponent, error) {
+	components, err := dao.GetImgComponents(digest)
+	if err != nil {
+		return nil, err
+	}
+	if len(components) > 0 {
+		return components, nil
+	}
+
+	report, err := scan.ReportByDigest(digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the components of image %s: %v", digest, err)
+	}
+	if err := Save(digest, report); err != nil {
+		return nil, err
+	}
+	return dao.GetImgComponents(digest)
+}
@@ -0,0 +1,108 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/pkg/scan/adapter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	artifact = &Artifact{
+		Repository: "library/app",
+		Tag:        "1.0",
+		Digest:     "sha256:0204dc6e09fa57ab99ac40e415eb637d62c8b2571ecbbc9ca0eb5e2ad2b5c56f",
+		BaseURL:    "https://harbor.example.com/",
+	}
+	components = []*models.ImgComponent{
+		{Name: "bash", Version: "4.4"},
+		{Name: "log4j", Version: "2.14.1"},
+	}
+	created = time.Date(2019, 10, 10, 10, 10, 10, 0, time.UTC)
+)
+
+func TestComponentsFromReport(t *testing.T) {
+	assert.Empty(t, ComponentsFromReport(nil))
+
+	report := &adapter.VulnerabilityReport{
+		Vulnerabilities: []*adapter.VulnerabilityItem{
+			{ID: "CVE-2021-44228", Package: "log4j", Version: "2.14.1"},
+			{ID: "CVE-2021-45046", Package: "log4j", Version: "2.14.1"},
+		},
+	}
+	cs := ComponentsFromReport(report)
+	if assert.Len(t, cs, 1, "the vulnerable packages are used if the packages are not listed") {
+		assert.Equal(t, "log4j", cs[0].Name)
+	}
+
+	report.Packages = []*adapter.Package{
+		{Name: "log4j", Version: "2.14.1"},
+		{Name: "bash", Version: "4.4"},
+		{Name: "bash", Version: "4.4"},
+	}
+	cs = ComponentsFromReport(report)
+	if assert.Len(t, cs, 2) {
+		assert.Equal(t, "bash", cs[0].Name)
+		assert.Equal(t, "log4j", cs[1].Name)
+	}
+}
+
+func TestGenerateSPDX(t *testing.T) {
+	mimeType, doc, err := Generate(FormatSPDX, artifact, components, created)
+	require.NoError(t, err)
+	assert.Equal(t, MimeTypeSPDX, mimeType)
+
+	spdx := doc.(*SPDXDocument)
+	assert.Equal(t, "library/app:1.0", spdx.Name)
+	assert.Equal(t, "https://harbor.example.com/spdx/library/app@"+artifact.Digest, spdx.DocumentNamespace)
+	assert.Equal(t, "2019-10-10T10:10:10Z", spdx.CreationInfo.Created)
+	require.Len(t, spdx.Packages, 3)
+	assert.Equal(t, "SPDXRef-Image", spdx.Packages[0].SPDXID)
+	assert.Equal(t, "2.14.1", spdx.Packages[2].VersionInfo)
+	require.Len(t, spdx.Relationships, 2)
+	assert.Equal(t, "SPDXRef-Package-2", spdx.Relationships[1].RelatedSPDXElement)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"spdxVersion":"SPDX-2.2"`)
+}
+
+func TestGenerateCycloneDX(t *testing.T) {
+	mimeType, doc, err := Generate("CycloneDX", artifact, components, created)
+	require.NoError(t, err)
+	assert.Equal(t, MimeTypeCycloneDX, mimeType)
+
+	bom := doc.(*CycloneDXDocument)
+	assert.Regexp(t, `^urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, bom.SerialNumber)
+	assert.Equal(t, artifact.Digest, bom.Metadata.Component.Version)
+	require.Len(t, bom.Components, 2)
+	assert.Equal(t, "library", bom.Components[1].Type)
+	assert.Equal(t, "log4j", bom.Components[1].Name)
+
+	_, doc2, err := Generate(FormatCycloneDX, artifact, components, created)
+	require.NoError(t, err)
+	assert.Equal(t, bom.SerialNumber, doc2.(*CycloneDXDocument).SerialNumber)
+}
+
+func TestGenerateUnsupportedFormat(t *testing.T) {
+	_, _, err := Generate("swid", artifact, components, created)
+	assert.Error(t, err)
+}
@@ -0,0 +1,361 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/goharbor/harbor/src/common/config/encrypt"
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/pkg/scan/adapter"
+)
+
+// BuiltinClairUUID is the UUID of the registration of the Clair deployed with Harbor.
+// The built-in Clair is not stored in the database and can't be updated or deleted.
+const BuiltinClairUUID = "clair"
+
+// Manager defines the interface of the scanner registration manager,
+// it also manages which scanner is used by the projects
+type Manager interface {
+	// List returns all the scanner registrations, the built-in Clair comes first if it's deployed
+	List() ([]*models.ScannerRegistration, error)
+	// Get returns the scanner registration with the UUID, nil is returned if it's not found
+	Get(uuid string) (*models.ScannerRegistration, error)
+	// Create creates the scanner registration and returns the UUID of it
+	Create(registration *models.ScannerRegistration) (string, error)
+	// Update updates the scanner registration
+	Update(registration *models.ScannerRegistration) error
+	// Delete deletes the scanner registration, the projects using it fall back to the default scanner
+	Delete(uuid string) error
+	// SetAsDefault sets the scanner registration as the default one used by the projects
+	SetAsDefault(uuid string) error
+	// GetDefault returns the default scanner registration, nil is returned if there is no scanner
+	GetDefault() (*models.ScannerRegistration, error)
+	// GetProjectScanner returns the scanner registration used by the project, nil is returned if there is no scanner
+	GetProjectScanner(projectID int64) (*models.ScannerRegistration, error)
+	// SetProjectScanner sets the scanner used by the project
+	SetProjectScanner(projectID int64, uuid string) error
+}
+
+type defaultManager struct{}
+
+// NewDefaultManager return a new instance of defaultManager
+func NewDefaultManager() Manager {
+	return &defaultManager{}
+}
+
+// List returns all the scanner registrations
+func (d *defaultManager) List() ([]*models.ScannerRegistration, error) {
+	rs, err := dao.ListScannerRegistrations()
+	if err != nil {
+		return nil, err
+	}
+
+	if clair := builtinClair(); clair != nil {
+		clair.IsDefault = true
+		for _, r := range rs {
+			if r.IsDefault {
+				clair.IsDefault = false
+				break
+			}
+		}
+		rs = append([]*models.ScannerRegistration{clair}, rs...)
+	}
+
+	return rs, nil
+}
+
+// Get returns the scanner registration with the UUID
+func (d *defaultManager) Get(uuid string) (*models.ScannerRegistration, error) {
+	if uuid == BuiltinClairUUID {
+		clair := builtinClair()
+		if clair != nil {
+			def, err := dao.GetDefaultScannerRegistration()
+			if err != nil {
+				return nil, err
+			}
+			clair.IsDefault = def == nil
+		}
+		return clair, nil
+	}
+
+	return dao.GetScannerRegistration(uuid)
+}
+
+// Create creates the scanner registration
+func (d *defaultManager) Create(registration *models.ScannerRegistration) (string, error) {
+	if err := validate(registration); err != nil {
+		return "", err
+	}
+
+	registration.UUID = utils.GenerateRandomString()
+	registration.IsDefault = false
+	cred, err := encryptCredential(registration.AccessCredential)
+	if err != nil {
+		return "", err
+	}
+	registration.AccessCredential = cred
+	if _, err := dao.AddScannerRegistration(registration); err != nil {
+		if err == dao.ErrDupRows {
+			return "", NewInvalidErr(fmt.Sprintf("scanner registration with name %s or URL %s already exists", registration.Name, registration.URL))
+		}
+		return "", err
+	}
+
+	return registration.UUID, nil
+}
+
+// Update updates the scanner registration
+func (d *defaultManager) Update(registration *models.ScannerRegistration) error {
+	if registration.UUID == BuiltinClairUUID {
+		return NewInvalidErr("the built-in Clair can't be updated")
+	}
+	if err := validate(registration); err != nil {
+		return err
+	}
+
+	old, err := dao.GetScannerRegistration(registration.UUID)
+	if err != nil {
+		return err
+	}
+	if old == nil {
+		return NewInvalidErr(fmt.Sprintf("scanner registration %s not found", registration.UUID))
+	}
+
+	registration.ID = old.ID
+	// the credential kept from the stored registration is encrypted already
+	if registration.AccessCredential != old.AccessCredential {
+		cred, err := encryptCredential(registration.AccessCredential)
+		if err != nil {
+			return err
+		}
+		registration.AccessCredential = cred
+	}
+	err = dao.UpdateScannerRegistration(registration, "Name", "Description", "URL", "Auth",
+		"AccessCredential", "SkipCertVerify", "Disabled")
+	if err == dao.ErrDupRows {
+		return NewInvalidErr(fmt.Sprintf("scanner registration with name %s or URL %s already exists", registration.Name, registration.URL))
+	}
+	return err
+}
+
+// Delete deletes the scanner registration
+func (d *defaultManager) Delete(uuid string) error {
+	if uuid == BuiltinClairUUID {
+		return NewInvalidErr("the built-in Clair can't be deleted")
+	}
+
+	// The projects using the scanner fall back to the default one
+	metas, err := dao.ListProjectMetadata(models.ProMetaScanner, uuid)
+	if err != nil {
+		return err
+	}
+	for _, m := range metas {
+		if err := dao.DeleteProjectMetadata(m.ProjectID, models.ProMetaScanner); err != nil {
+			return err
+		}
+	}
+
+	return dao.DeleteScannerRegistration(uuid)
+}
+
+// SetAsDefault sets the scanner registration as the default one
+func (d *defaultManager) SetAsDefault(uuid string) error {
+	if uuid == BuiltinClairUUID {
+		if builtinClair() == nil {
+			return NewInvalidErr("Clair is not deployed with Harbor")
+		}
+		// The built-in Clair is the default one if no other is set
+		return dao.SetDefaultScannerRegistration("")
+	}
+
+	r, err := dao.GetScannerRegistration(uuid)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return NewInvalidErr(fmt.Sprintf("scanner registration %s not found", uuid))
+	}
+	if r.Disabled {
+		return NewInvalidErr(fmt.Sprintf("the disabled scanner registration %s can't be the default one", r.Name))
+	}
+
+	return dao.SetDefaultScannerRegistration(uuid)
+}
+
+// GetDefault returns the default scanner registration
+func (d *defaultManager) GetDefault() (*models.ScannerRegistration, error) {
+	r, err := dao.GetDefaultScannerRegistration()
+	if err != nil {
+		return nil, err
+	}
+	if r != nil && !r.Disabled {
+		return r, nil
+	}
+
+	clair := builtinClair()
+	if clair != nil && r == nil {
+		clair.IsDefault = true
+	}
+	return clair, nil
+}
+
+// GetProjectScanner returns the scanner registration used by the project
+func (d *defaultManager) GetProjectScanner(projectID int64) (*models.ScannerRegistration, error) {
+	metas, err := dao.GetProjectMetadata(projectID, models.ProMetaScanner)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(metas) > 0 && len(metas[0].Value) > 0 {
+		r, err := d.Get(metas[0].Value)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil && !r.Disabled {
+			return r, nil
+		}
+		log.Warningf("The scanner %s of project %d is not available, use the default one", metas[0].Value, projectID)
+	}
+
+	return d.GetDefault()
+}
+
+// SetProjectScanner sets the scanner used by the project
+func (d *defaultManager) SetProjectScanner(projectID int64, uuid string) error {
+	r, err := d.Get(uuid)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return NewInvalidErr(fmt.Sprintf("scanner registration %s not found", uuid))
+	}
+	if r.Disabled {
+		return NewInvalidErr(fmt.Sprintf("the scanner registration %s is disabled", r.Name))
+	}
+
+	metas, err := dao.GetProjectMetadata(projectID, models.ProMetaScanner)
+	if err != nil {
+		return err
+	}
+	meta := &models.ProjectMetadata{
+		ProjectID: projectID,
+		Name:      models.ProMetaScanner,
+		Value:     uuid,
+	}
+	if len(metas) == 0 {
+		return dao.AddProjectMetadata(meta)
+	}
+	return dao.UpdateProjectMetadata(meta)
+}
+
+// NewAdapter returns the adapter to talk with the scanner of the registration
+func NewAdapter(registration *models.ScannerRegistration) (adapter.Adapter, error) {
+	if registration.UUID == BuiltinClairUUID {
+		return adapter.NewClairAdapter(registration.URL, nil), nil
+	}
+
+	cred, err := decryptCredential(registration.AccessCredential)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.NewClient(registration.URL, registration.Auth, cred, registration.SkipCertVerify)
+}
+
+// encryptCredential encrypts the access credential of the scanner registration to store it
+func encryptCredential(cred string) (string, error) {
+	if len(cred) == 0 {
+		return cred, nil
+	}
+	encrypted, err := encrypt.Instance().Encrypt(cred)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt the access credential of scanner registration: %v", err)
+	}
+	return encrypted, nil
+}
+
+// decryptCredential decrypts the stored access credential of the scanner registration,
+// the credential stored in plaintext before it's encrypted is returned as it is
+func decryptCredential(cred string) (string, error) {
+	if !strings.HasPrefix(cred, utils.EncryptHeaderV1) {
+		return cred, nil
+	}
+	decrypted, err := encrypt.Instance().Decrypt(cred)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt the access credential of scanner registration: %v", err)
+	}
+	return decrypted, nil
+}
+
+// builtinClair returns the registration of the built-in Clair, nil is returned if Clair is not deployed
+func builtinClair() *models.ScannerRegistration {
+	if !config.WithClair() {
+		return nil
+	}
+
+	return &models.ScannerRegistration{
+		UUID:        BuiltinClairUUID,
+		Name:        adapter.ClairScanner.Name,
+		Description: "The Clair deployed with Harbor",
+		URL:         config.ClairEndpoint(),
+	}
+}
+
+func validate(registration *models.ScannerRegistration) error {
+	if registration == nil {
+		return NewInvalidErr("empty scanner registration")
+	}
+	if len(strings.TrimSpace(registration.Name)) == 0 {
+		return NewInvalidErr("missing name of scanner registration")
+	}
+	if u, err := url.ParseRequestURI(registration.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return NewInvalidErr(fmt.Sprintf("invalid URL of scanner registration: %s", registration.URL))
+	}
+	if err := adapter.ValidateAuth(registration.Auth); err != nil {
+		return NewInvalidErr(err.Error())
+	}
+	if registration.Auth != adapter.AuthNone && len(registration.AccessCredential) == 0 {
+		return NewInvalidErr("missing access credential of scanner registration")
+	}
+
+	return nil
+}
+
+// Enabled checks if there is any scanner to scan the images,
+// either the built-in Clair is deployed or an enabled scanner registration exists
+func Enabled() bool {
+	if config.WithClair() {
+		return true
+	}
+
+	rs, err := dao.ListScannerRegistrations()
+	if err != nil {
+		log.Errorf("Failed to list the scanner registrations: %v", err)
+		return false
+	}
+	for _, r := range rs {
+		if !r.Disabled {
+			return true
+		}
+	}
+
+	return false
+}
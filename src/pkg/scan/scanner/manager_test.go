@@ -0,0 +1,170 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/pkg/scan/adapter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	key, err := ioutil.TempFile("", "key")
+	if err != nil {
+		log.Fatalf("failed to create the key file: %v", err)
+	}
+	defer os.Remove(key.Name())
+	if _, err := key.WriteString("0123456789abcdef"); err != nil {
+		log.Fatalf("failed to write the key file: %v", err)
+	}
+	key.Close()
+	os.Setenv("KEY_PATH", key.Name())
+
+	databases := []string{"postgresql"}
+	for _, database := range databases {
+		log.Infof("run test cases for database: %s", database)
+
+		result := 1
+		switch database {
+		case "postgresql":
+			dao.PrepareTestForPostgresSQL()
+		default:
+			log.Fatalf("invalid database: %s", database)
+		}
+
+		config.InitWithSettings(map[string]interface{}{
+			common.WithClair: true,
+			common.ClairURL:  "http://clair:6060",
+		})
+
+		result = m.Run()
+
+		if result != 0 {
+			os.Exit(result)
+		}
+	}
+}
+
+func TestDefaultManager_Create(t *testing.T) {
+	defer dao.ClearTable(models.ScannerRegistrationTable)
+
+	dm := NewDefaultManager()
+	_, err := dm.Create(&models.ScannerRegistration{Name: "trivy", URL: "not-a-url"})
+	assert.True(t, IsInvalidErr(err))
+	_, err = dm.Create(&models.ScannerRegistration{Name: "trivy", URL: "http://trivy:8080", Auth: adapter.AuthBearer})
+	assert.True(t, IsInvalidErr(err), "missing credential: invalid error expected")
+
+	uuid, err := dm.Create(&models.ScannerRegistration{Name: "trivy", URL: "http://trivy:8080"})
+	require.NoError(t, err)
+	_, err = dm.Create(&models.ScannerRegistration{Name: "trivy", URL: "http://trivy:8081"})
+	assert.True(t, IsInvalidErr(err), "duplicate name: invalid error expected")
+
+	r, err := dm.Get(uuid)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	assert.Equal(t, "trivy", r.Name)
+
+	rs, err := dm.List()
+	require.NoError(t, err)
+	if assert.Len(t, rs, 2) {
+		assert.Equal(t, BuiltinClairUUID, rs[0].UUID)
+		assert.True(t, rs[0].IsDefault)
+	}
+
+	assert.Error(t, dm.Update(&models.ScannerRegistration{UUID: BuiltinClairUUID, Name: "clair", URL: "http://clair:6060"}))
+	assert.Error(t, dm.Delete(BuiltinClairUUID))
+}
+
+func TestDefaultManager_Credential(t *testing.T) {
+	defer dao.ClearTable(models.ScannerRegistrationTable)
+
+	dm := NewDefaultManager()
+	uuid, err := dm.Create(&models.ScannerRegistration{
+		Name:             "trivy",
+		URL:              "http://trivy:8080",
+		Auth:             adapter.AuthBearer,
+		AccessCredential: "token",
+	})
+	require.NoError(t, err)
+
+	// the credential is stored encrypted
+	r, err := dao.GetScannerRegistration(uuid)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	assert.True(t, strings.HasPrefix(r.AccessCredential, utils.EncryptHeaderV1))
+	cred, err := decryptCredential(r.AccessCredential)
+	require.NoError(t, err)
+	assert.Equal(t, "token", cred)
+
+	// the stored credential isn't encrypted again when it's kept
+	stored := r.AccessCredential
+	r.Description = "updated"
+	require.NoError(t, dm.Update(r))
+	r, err = dao.GetScannerRegistration(uuid)
+	require.NoError(t, err)
+	assert.Equal(t, stored, r.AccessCredential)
+
+	r.AccessCredential = "another-token"
+	require.NoError(t, dm.Update(r))
+	r, err = dao.GetScannerRegistration(uuid)
+	require.NoError(t, err)
+	cred, err = decryptCredential(r.AccessCredential)
+	require.NoError(t, err)
+	assert.Equal(t, "another-token", cred)
+
+	// the credential stored in plaintext before
+	cred, err = decryptCredential("plaintext")
+	require.NoError(t, err)
+	assert.Equal(t, "plaintext", cred)
+}
+
+func TestDefaultManager_ProjectScanner(t *testing.T) {
+	defer dao.ClearTable(models.ScannerRegistrationTable)
+
+	dm := NewDefaultManager()
+	uuid, err := dm.Create(&models.ScannerRegistration{Name: "anchore", URL: "http://anchore:8080"})
+	require.NoError(t, err)
+
+	r, err := dm.GetProjectScanner(1)
+	require.NoError(t, err)
+	assert.Equal(t, BuiltinClairUUID, r.UUID)
+
+	require.NoError(t, dm.SetAsDefault(uuid))
+	r, err = dm.GetDefault()
+	require.NoError(t, err)
+	assert.Equal(t, uuid, r.UUID)
+
+	require.NoError(t, dm.SetProjectScanner(1, BuiltinClairUUID))
+	r, err = dm.GetProjectScanner(1)
+	require.NoError(t, err)
+	assert.Equal(t, BuiltinClairUUID, r.UUID)
+
+	require.NoError(t, dm.SetProjectScanner(1, uuid))
+	require.NoError(t, dm.Delete(uuid))
+	r, err = dm.GetProjectScanner(1)
+	require.NoError(t, err)
+	assert.Equal(t, BuiltinClairUUID, r.UUID, "fall back to the built-in Clair")
+}
@@ -15,13 +15,16 @@
 package scan
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/goharbor/harbor/src/common/dao"
 	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/common/utils/clair"
 	"github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/pkg/scan/adapter"
 	"reflect"
+	"strings"
 )
 
 // VulnerabilityItem represents a vulnerability reported by scanner
@@ -33,6 +36,20 @@ type VulnerabilityItem struct {
 	Description string          `json:"description"`
 	Link        string          `json:"link"`
 	Fixed       string          `json:"fixedVersion,omitempty"`
+	CVSSScore   float64         `json:"cvssScore,omitempty"`
+	CVSSVector  string          `json:"cvssVector,omitempty"`
+}
+
+// violates returns whether the vulnerability violates the policy,
+// the severity is checked if the CVSS score isn't checked by the policy or isn't reported by the scanner
+func (v *VulnerabilityItem) violates(policy *models.VulnerablePolicy) bool {
+	if policy.FixableOnly && len(v.Fixed) == 0 {
+		return false
+	}
+	if policy.CVSSThreshold > 0 && v.CVSSScore > 0 {
+		return v.CVSSScore >= policy.CVSSThreshold
+	}
+	return v.Severity >= policy.Severity
 }
 
 // VulnerabilityList is a list of vulnerabilities, which should be scanner-agnostic
@@ -72,6 +89,41 @@ func (vl *VulnerabilityList) Severity() models.Severity {
 	return s
 }
 
+// Violations returns the vulnerabilities in the list which violate the policy
+func (vl *VulnerabilityList) Violations(policy *models.VulnerablePolicy) VulnerabilityList {
+	res := VulnerabilityList{}
+	for _, v := range *vl {
+		if v.violates(policy) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// PolicyViolation checks the list against the vulnerable policy of the project and returns the message describing the
+// violation, empty string is returned if the policy isn't violated. The whitelist should be applied to the list before.
+func (vl *VulnerabilityList) PolicyViolation(policy *models.VulnerablePolicy) string {
+	if policy.CVSSThreshold <= 0 && !policy.FixableOnly {
+		if int(vl.Severity()) >= int(policy.Severity) {
+			return fmt.Sprintf("The severity of vulnerability of the image: %q is equal or higher than the threshold in project setting: %q.", vl.Severity(), policy.Severity)
+		}
+		return ""
+	}
+	violations := vl.Violations(policy)
+	if len(violations) == 0 {
+		return ""
+	}
+	v := violations[0]
+	msg := fmt.Sprintf("The image has %d vulnerabilities violating the project policy, e.g. %s of package %s %s, severity: %q", len(violations), v.ID, v.Pkg, v.Version, v.Severity)
+	if v.CVSSScore > 0 {
+		msg = fmt.Sprintf("%s, CVSS score: %.1f", msg, v.CVSSScore)
+	}
+	if len(v.Fixed) > 0 {
+		msg = fmt.Sprintf("%s, fixed version: %s", msg, v.Fixed)
+	}
+	return msg + "."
+}
+
 // HasCVE returns whether the vulnerability list has the vulnerability with CVE ID in the parm
 func (vl *VulnerabilityList) HasCVE(id string) bool {
 	for _, v := range *vl {
@@ -111,26 +163,196 @@ func VulnListFromClairResult(layerWithVuln *models.ClairLayerEnvelope) Vulnerabi
 				Link:        v.Link,
 				Description: v.Description,
 			}
+			if cvss := adapter.CVSSFromClairMetadata(v.Metadata); cvss != nil {
+				vItem.CVSSScore = cvss.Score
+				vItem.CVSSVector = cvss.Vector
+			}
 			res = append(res, vItem)
 		}
 	}
 	return res
 }
 
-// VulnListByDigest returns the VulnerabilityList based on the scan result of artifact with the digest in the parm
-func VulnListByDigest(digest string) (VulnerabilityList, error) {
-	var res VulnerabilityList
+// VulnListFromReport transforms the report of the scanner adapter to a VulnerabilityList
+func VulnListFromReport(report *adapter.VulnerabilityReport) VulnerabilityList {
+	res := VulnerabilityList{}
+	if report == nil {
+		return res
+	}
+	for _, v := range report.Vulnerabilities {
+		if v == nil {
+			continue
+		}
+		item := VulnerabilityItem{
+			ID:          v.ID,
+			Pkg:         v.Package,
+			Version:     v.Version,
+			Severity:    adapter.ParseSeverity(v.Severity),
+			Fixed:       v.FixVersion,
+			Link:        strings.Join(v.Links, " "),
+			Description: v.Description,
+		}
+		if v.CVSS != nil {
+			item.CVSSScore = v.CVSS.Score
+			item.CVSSVector = v.CVSS.Vector
+		}
+		res = append(res, item)
+	}
+	return res
+}
+
+// OverviewFromReport summarizes the components of the report by the highest severity of their vulnerabilities,
+// it also returns the highest severity of the whole report.
+func OverviewFromReport(report *adapter.VulnerabilityReport) (*models.ComponentsOverview, models.Severity) {
+	type component struct {
+		name    string
+		version string
+	}
+	sevs := map[component]models.Severity{}
+	if report != nil {
+		for _, p := range report.Packages {
+			if p != nil {
+				sevs[component{p.Name, p.Version}] = models.SevNone
+			}
+		}
+		for _, v := range report.Vulnerabilities {
+			if v == nil {
+				continue
+			}
+			c := component{v.Package, v.Version}
+			if sev := adapter.ParseSeverity(v.Severity); sev > sevs[c] {
+				sevs[c] = sev
+			}
+		}
+	}
+
+	counts := make(map[models.Severity]int)
+	for _, sev := range sevs {
+		if sev < models.SevNone {
+			sev = models.SevNone
+		}
+		counts[sev]++
+	}
+	overallSev := models.SevNone
+	summary := []*models.ComponentsOverviewEntry{}
+	for sev, count := range counts {
+		if sev > overallSev {
+			overallSev = sev
+		}
+		summary = append(summary, &models.ComponentsOverviewEntry{
+			Sev:   int(sev),
+			Count: count,
+		})
+	}
+	return &models.ComponentsOverview{
+		Total:   len(sevs),
+		Summary: summary,
+	}, overallSev
+}
+
+// IndexFromReport returns the vulnerabilities of the report to index the image by the CVE,
+// the duplicated vulnerabilities of the same package are merged
+func IndexFromReport(report *adapter.VulnerabilityReport) []*models.ImgVulnerability {
+	type key struct {
+		id      string
+		pkg     string
+		version string
+	}
+	res := []*models.ImgVulnerability{}
+	if report == nil {
+		return res
+	}
+	seen := map[key]*models.ImgVulnerability{}
+	for _, v := range report.Vulnerabilities {
+		if v == nil || len(v.ID) == 0 {
+			continue
+		}
+		sev := int(adapter.ParseSeverity(v.Severity))
+		k := key{v.ID, v.Package, v.Version}
+		if item, ok := seen[k]; ok {
+			if sev > item.Severity {
+				item.Severity = sev
+			}
+			continue
+		}
+		item := &models.ImgVulnerability{
+			CVEID:      v.ID,
+			Package:    v.Package,
+			Version:    v.Version,
+			FixVersion: v.FixVersion,
+			Severity:   sev,
+		}
+		seen[k] = item
+		res = append(res, item)
+	}
+	return res
+}
+
+// IndexReport indexes the image with the digest by the vulnerabilities found in the report
+func IndexReport(digest string, report *adapter.VulnerabilityReport) error {
+	return dao.SetImgVulnerabilities(digest, IndexFromReport(report))
+}
+
+// IndexScannedImages indexes the images scanned before the index is introduced by the vulnerabilities
+// found in their scan results, the failure of an image is logged and doesn't stop indexing the others
+func IndexScannedImages() error {
+	digests, err := dao.ListImgDigestsToIndex()
+	if err != nil {
+		return err
+	}
+	if len(digests) == 0 {
+		return nil
+	}
+
+	log.Infof("Indexing the vulnerabilities of %d scanned images...", len(digests))
+	indexed := 0
+	for _, digest := range digests {
+		report, err := ReportByDigest(digest)
+		if err != nil {
+			log.Errorf("Failed to get the scan report of image %s: %v", digest, err)
+			continue
+		}
+		if err := IndexReport(digest, report); err != nil {
+			log.Errorf("Failed to index the vulnerabilities of image %s: %v", digest, err)
+			continue
+		}
+		indexed++
+	}
+	log.Infof("The vulnerabilities of %d/%d scanned images are indexed", indexed, len(digests))
+	return nil
+}
+
+// ReportByDigest returns the report of the scan result of artifact with the digest in the parm.
+// The report of the artifact scanned by the built-in Clair is fetched from Clair.
+func ReportByDigest(digest string) (*adapter.VulnerabilityReport, error) {
 	overview, err := dao.GetImgScanOverview(digest)
 	if err != nil {
-		return res, err
+		return nil, err
+	}
+	if overview != nil && len(overview.Report) > 0 {
+		report := &adapter.VulnerabilityReport{}
+		if err := json.Unmarshal([]byte(overview.Report), report); err != nil {
+			return nil, fmt.Errorf("failed to parse the scan report for digest: %s, error: %v", digest, err)
+		}
+		return report, nil
 	}
 	if overview == nil || len(overview.DetailsKey) == 0 {
-		return res, fmt.Errorf("unable to get the scan result for digest: %s, the artifact is not scanned", digest)
+		return nil, fmt.Errorf("unable to get the scan result for digest: %s, the artifact is not scanned", digest)
 	}
 	c := clair.NewClient(config.ClairEndpoint(), nil)
 	clairRes, err := c.GetResult(overview.DetailsKey)
 	if err != nil {
-		return res, fmt.Errorf("failed to get scan result from Clair, error: %v", err)
+		return nil, fmt.Errorf("failed to get scan result from Clair, error: %v", err)
+	}
+	return adapter.ReportFromClairResult(clairRes), nil
+}
+
+// VulnListByDigest returns the VulnerabilityList based on the scan result of artifact with the digest in the parm
+func VulnListByDigest(digest string) (VulnerabilityList, error) {
+	var res VulnerabilityList
+	report, err := ReportByDigest(digest)
+	if err != nil {
+		return res, err
 	}
-	return VulnListFromClairResult(clairRes), nil
+	return VulnListFromReport(report), nil
 }
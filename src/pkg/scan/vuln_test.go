@@ -17,6 +17,7 @@ package scan
 import (
 	"github.com/goharbor/harbor/src/common/dao"
 	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/pkg/scan/adapter"
 	"github.com/stretchr/testify/assert"
 	"os"
 	"testing"
@@ -56,6 +57,13 @@ var (
 			{CVEID: "CVE-2019-12817"},
 		},
 	}
+	// the item of CVE-2018-6485 is expired
+	whiteList4 = models.CVEWhitelist{
+		Items: []models.CVEWhitelistItem{
+			{CVEID: "CVE-2018-6485", ExpiresAt: &past, Justification: "not exploitable"},
+			{CVEID: "CVE-2018-10754", Justification: "not exploitable"},
+		},
+	}
 )
 
 func TestMain(m *testing.M) {
@@ -153,6 +161,17 @@ func TestVulnerabilityList_ApplyWhitelist(t *testing.T) {
 			},
 			expectSev: models.SevNone,
 		},
+		{
+			vl: VulnerabilityList{
+				{ID: "CVE-2018-10754", Severity: models.SevLow},
+				{ID: "CVE-2018-6485", Severity: models.SevHigh},
+			},
+			wl: whiteList4,
+			expectFiltered: VulnerabilityList{
+				{ID: "CVE-2018-10754", Severity: models.SevLow},
+			},
+			expectSev: models.SevHigh,
+		},
 	}
 	for _, c := range cases {
 		filtered := c.vl.ApplyWhitelist(c.wl)
@@ -161,6 +180,51 @@ func TestVulnerabilityList_ApplyWhitelist(t *testing.T) {
 	}
 }
 
+func TestVulnerabilityList_Violations(t *testing.T) {
+	vl := VulnerabilityList{
+		{ID: "CVE-2018-6485", Severity: models.SevHigh, Fixed: "2.24-11+deb9u5", CVSSScore: 9.8},
+		{ID: "CVE-2019-9169", Severity: models.SevHigh, CVSSScore: 9.8},
+		{ID: "CVE-2018-10754", Severity: models.SevHigh, Fixed: "6.0+20161126-1+deb9u3", CVSSScore: 5.0},
+		{ID: "CVE-2019-12817", Severity: models.SevMedium, Fixed: "4.4-5+deb9u1"},
+	}
+	ids := func(l VulnerabilityList) []string {
+		res := []string{}
+		for _, v := range l {
+			res = append(res, v.ID)
+		}
+		return res
+	}
+	cases := []struct {
+		policy *models.VulnerablePolicy
+		expect []string
+	}{
+		{
+			policy: &models.VulnerablePolicy{Severity: models.SevHigh},
+			expect: []string{"CVE-2018-6485", "CVE-2019-9169", "CVE-2018-10754"},
+		},
+		{
+			policy: &models.VulnerablePolicy{Severity: models.SevHigh, FixableOnly: true},
+			expect: []string{"CVE-2018-6485", "CVE-2018-10754"},
+		},
+		{
+			policy: &models.VulnerablePolicy{Severity: models.SevHigh, CVSSThreshold: 8.0},
+			expect: []string{"CVE-2018-6485", "CVE-2019-9169"},
+		},
+		{
+			policy: &models.VulnerablePolicy{Severity: models.SevHigh, CVSSThreshold: 8.0, FixableOnly: true},
+			expect: []string{"CVE-2018-6485"},
+		},
+		{
+			// the severity is checked for the vulnerabilities without CVSS score
+			policy: &models.VulnerablePolicy{Severity: models.SevMedium, CVSSThreshold: 9.9, FixableOnly: true},
+			expect: []string{"CVE-2019-12817"},
+		},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expect, ids(vl.Violations(c.policy)))
+	}
+}
+
 func TestVulnListByDigest(t *testing.T) {
 	_, err := VulnListByDigest("notexist")
 	assert.NotNil(t, err)
@@ -175,4 +239,105 @@ func TestVulnListFromClairResult(t *testing.T) {
 	}
 	l2 := VulnListFromClairResult(lv)
 	assert.Equal(t, VulnerabilityList{}, l2)
+
+	lv = &models.ClairLayerEnvelope{
+		Layer: &models.ClairLayer{
+			Features: []models.ClairFeature{
+				{
+					Name:    "glibc",
+					Version: "2.24-11+deb9u4",
+					Vulnerabilities: []models.ClairVulnerability{
+						{
+							Name:     "CVE-2018-6485",
+							Severity: "High",
+							Metadata: map[string]interface{}{
+								"NVD": map[string]interface{}{
+									"CVSSv2": map[string]interface{}{
+										"Score":   7.5,
+										"Vectors": "AV:N/AC:L/Au:N/C:P/I:P/A:P",
+									},
+								},
+							},
+						},
+						{Name: "CVE-2019-9169", Severity: "Medium"},
+					},
+				},
+			},
+		},
+	}
+	l3 := VulnListFromClairResult(lv)
+	if assert.Len(t, l3, 2) {
+		assert.Equal(t, 7.5, l3[0].CVSSScore)
+		assert.Equal(t, "AV:N/AC:L/Au:N/C:P/I:P/A:P", l3[0].CVSSVector)
+		assert.Equal(t, 0.0, l3[1].CVSSScore)
+	}
+}
+
+func TestVulnListFromReport(t *testing.T) {
+	assert.Equal(t, VulnerabilityList{}, VulnListFromReport(nil))
+
+	report := &adapter.VulnerabilityReport{
+		Vulnerabilities: []*adapter.VulnerabilityItem{
+			{
+				ID:         "CVE-2018-6485",
+				Package:    "glibc",
+				Version:    "2.24-11+deb9u4",
+				FixVersion: "2.24-11+deb9u5",
+				Severity:   "Critical",
+				Links:      []string{"https://security-tracker.debian.org/tracker/CVE-2018-6485"},
+				CVSS:       &adapter.CVSS{Score: 9.8, Vector: "CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"},
+			},
+		},
+	}
+	l := VulnListFromReport(report)
+	if assert.Len(t, l, 1) {
+		assert.Equal(t, 9.8, l[0].CVSSScore)
+		assert.Equal(t, "CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", l[0].CVSSVector)
+		assert.Equal(t, models.SevHigh, l[0].Severity)
+		assert.Equal(t, "2.24-11+deb9u5", l[0].Fixed)
+		assert.Equal(t, "https://security-tracker.debian.org/tracker/CVE-2018-6485", l[0].Link)
+	}
+}
+
+func TestOverviewFromReport(t *testing.T) {
+	report := &adapter.VulnerabilityReport{
+		Vulnerabilities: []*adapter.VulnerabilityItem{
+			{ID: "CVE-2018-10754", Package: "ncurses", Version: "6.0", Severity: "Low"},
+			{ID: "CVE-2018-6485", Package: "glibc", Version: "2.24", Severity: "High"},
+			{ID: "CVE-2019-9169", Package: "glibc", Version: "2.24", Severity: "Medium"},
+		},
+		Packages: []*adapter.Package{
+			{Name: "ncurses", Version: "6.0"},
+			{Name: "glibc", Version: "2.24"},
+			{Name: "bash", Version: "4.4"},
+		},
+	}
+	overview, sev := OverviewFromReport(report)
+	assert.Equal(t, models.SevHigh, sev)
+	assert.Equal(t, 3, overview.Total)
+	counts := map[int]int{}
+	for _, e := range overview.Summary {
+		counts[e.Sev] = e.Count
+	}
+	assert.Equal(t, map[int]int{int(models.SevNone): 1, int(models.SevLow): 1, int(models.SevHigh): 1}, counts)
+}
+
+func TestIndexFromReport(t *testing.T) {
+	assert.Len(t, IndexFromReport(nil), 0)
+
+	report := &adapter.VulnerabilityReport{
+		Vulnerabilities: []*adapter.VulnerabilityItem{
+			{ID: "CVE-2018-6485", Package: "glibc", Version: "2.24-11+deb9u4", FixVersion: "2.24-11+deb9u5", Severity: "Medium"},
+			{ID: "CVE-2018-6485", Package: "glibc", Version: "2.24-11+deb9u4", Severity: "High"},
+			{ID: "CVE-2018-6485", Package: "libc-bin", Version: "2.24-11+deb9u4", Severity: "High"},
+			{ID: "", Package: "bash", Version: "4.4", Severity: "Low"},
+		},
+	}
+	l := IndexFromReport(report)
+	if assert.Len(t, l, 2) {
+		assert.Equal(t, "glibc", l[0].Package)
+		assert.Equal(t, int(models.SevHigh), l[0].Severity)
+		assert.Equal(t, "2.24-11+deb9u5", l[0].FixVersion)
+		assert.Equal(t, "libc-bin", l[1].Package)
+	}
 }
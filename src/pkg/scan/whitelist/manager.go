@@ -15,6 +15,8 @@
 package whitelist
 
 import (
+	"time"
+
 	"github.com/goharbor/harbor/src/common/dao"
 	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/common/utils/log"
@@ -33,6 +35,8 @@ type Manager interface {
 	SetSys(list models.CVEWhitelist) error
 	// GetSys gets system level whitelist
 	GetSys() (*models.CVEWhitelist, error)
+	// Audit returns the items expired or expiring in the duration of all the whitelists
+	Audit(expiringIn time.Duration) ([]*models.CVEWhitelistAuditItem, error)
 }
 
 type defaultManager struct{}
@@ -56,7 +60,14 @@ func (d *defaultManager) Set(projectID int64, list models.CVEWhitelist) error {
 	if err := Validate(list); err != nil {
 		return err
 	}
-	_, err := dao.UpdateCVEWhitelist(list)
+	existing, err := dao.GetCVEWhitelist(projectID)
+	if err != nil {
+		return err
+	}
+	if err := ValidateJustification(list, existing); err != nil {
+		return err
+	}
+	_, err = dao.UpdateCVEWhitelist(list)
 	return err
 }
 
@@ -83,6 +94,36 @@ func (d *defaultManager) GetSys() (*models.CVEWhitelist, error) {
 	return d.Get(0)
 }
 
+// Audit returns the items expired or expiring in the duration of the whitelists of the system and all the projects.
+// The item expires when the whole whitelist expires if it's earlier, the expiration time of the item is set to it.
+func (d *defaultManager) Audit(expiringIn time.Duration) ([]*models.CVEWhitelistAuditItem, error) {
+	lists, err := dao.ListCVEWhitelists()
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(expiringIn).Unix()
+	res := []*models.CVEWhitelistAuditItem{}
+	for _, l := range lists {
+		for _, it := range l.Items {
+			expiresAt := it.ExpiresAt
+			if l.ExpiresAt != nil && (expiresAt == nil || *l.ExpiresAt < *expiresAt) {
+				expiresAt = l.ExpiresAt
+			}
+			if expiresAt == nil || *expiresAt > deadline {
+				continue
+			}
+			it.ExpiresAt = expiresAt
+			res = append(res, &models.CVEWhitelistAuditItem{
+				CVEWhitelistItem: it,
+				ProjectID:        l.ProjectID,
+				Expired:          it.IsExpired(),
+			})
+		}
+	}
+	return res, nil
+}
+
 // NewDefaultManager return a new instance of defaultManager
 func NewDefaultManager() Manager {
 	return &defaultManager{}
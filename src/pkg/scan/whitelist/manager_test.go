@@ -2,10 +2,13 @@ package whitelist
 
 import (
 	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestMain(m *testing.M) {
@@ -44,3 +47,60 @@ func TestDefaultManager_Get(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Empty(t, l.Items)
 }
+
+func TestDefaultManager_Audit(t *testing.T) {
+	dm := NewDefaultManager()
+	now := time.Now().Unix()
+	past, soon, later := now-10, now+3600, now+30*24*3600
+	_, err := dao.UpdateCVEWhitelist(models.CVEWhitelist{
+		ProjectID: 98,
+		Items: []models.CVEWhitelistItem{
+			{CVEID: "CVE-2019-0001", ExpiresAt: &past, Justification: "not exploitable", Approver: "admin"},
+			{CVEID: "CVE-2019-0002", ExpiresAt: &soon},
+			{CVEID: "CVE-2019-0003", ExpiresAt: &later},
+			{CVEID: "CVE-2019-0004"},
+		},
+	})
+	require.Nil(t, err)
+	// the whole whitelist is expired
+	_, err = dao.UpdateCVEWhitelist(models.CVEWhitelist{
+		ProjectID: 97,
+		ExpiresAt: &past,
+		Items: []models.CVEWhitelistItem{
+			{CVEID: "CVE-2019-0005"},
+		},
+	})
+	require.Nil(t, err)
+	defer dao.GetOrmer().Raw("delete from cve_whitelist where project_id in (97, 98)").Exec()
+
+	// only check the whitelists created in this case
+	audit := func(expiringIn time.Duration) ([]*models.CVEWhitelistAuditItem, error) {
+		all, err := dm.Audit(expiringIn)
+		items := []*models.CVEWhitelistAuditItem{}
+		for _, it := range all {
+			if it.ProjectID == 97 || it.ProjectID == 98 {
+				items = append(items, it)
+			}
+		}
+		return items, err
+	}
+
+	items, err := audit(0)
+	require.Nil(t, err)
+	if assert.Len(t, items, 2) {
+		assert.Equal(t, int64(97), items[0].ProjectID)
+		assert.Equal(t, "CVE-2019-0005", items[0].CVEID)
+		assert.True(t, items[0].Expired)
+		assert.Equal(t, past, *items[0].ExpiresAt)
+		assert.Equal(t, "CVE-2019-0001", items[1].CVEID)
+		assert.Equal(t, "admin", items[1].Approver)
+		assert.True(t, items[1].Expired)
+	}
+
+	items, err = audit(24 * time.Hour)
+	require.Nil(t, err)
+	if assert.Len(t, items, 3) {
+		assert.Equal(t, "CVE-2019-0002", items[2].CVEID)
+		assert.False(t, items[2].Expired)
+	}
+}
@@ -16,6 +16,8 @@ package whitelist
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/goharbor/harbor/src/common/models"
 )
 
@@ -54,7 +56,59 @@ func Validate(wl models.CVEWhitelist) error {
 		if _, ok := m[it.CVEID]; ok {
 			return &invalidErr{fmt.Sprintf("duplicate CVE ID in whitelist: %s", it.CVEID)}
 		}
+		if it.ExpiresAt != nil && *it.ExpiresAt <= 0 {
+			return &invalidErr{fmt.Sprintf("invalid expiration time of CVE %s in whitelist: %d", it.CVEID, *it.ExpiresAt)}
+		}
 		m[it.CVEID] = struct{}{}
 	}
 	return nil
 }
+
+// ValidateJustification ensures every item in the whitelist has the justification, except the ones
+// saved without justification before it's required, which are in the existing whitelist unchanged
+func ValidateJustification(wl models.CVEWhitelist, existing *models.CVEWhitelist) error {
+	legacy := map[string]models.CVEWhitelistItem{}
+	if existing != nil {
+		for _, it := range existing.Items {
+			if len(it.Justification) == 0 {
+				legacy[it.CVEID] = it
+			}
+		}
+	}
+	for _, it := range wl.Items {
+		if len(strings.TrimSpace(it.Justification)) > 0 {
+			continue
+		}
+		if l, ok := legacy[it.CVEID]; ok && sameExpiration(l.ExpiresAt, it.ExpiresAt) {
+			continue
+		}
+		return &invalidErr{fmt.Sprintf("missing justification of CVE %s in whitelist", it.CVEID)}
+	}
+	return nil
+}
+
+// FillApprover sets the user as the approver of the new or changed items of the whitelist,
+// the stored approver is kept for the unchanged items, so the approver sent by the client is never trusted
+func FillApprover(wl *models.CVEWhitelist, existing *models.CVEWhitelist, username string) {
+	stored := map[string]models.CVEWhitelistItem{}
+	if existing != nil {
+		for _, it := range existing.Items {
+			stored[it.CVEID] = it
+		}
+	}
+	for i := range wl.Items {
+		it := &wl.Items[i]
+		if s, ok := stored[it.CVEID]; ok && s.Justification == it.Justification && sameExpiration(s.ExpiresAt, it.ExpiresAt) {
+			it.Approver = s.Approver
+			continue
+		}
+		it.Approver = username
+	}
+}
+
+func sameExpiration(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -47,6 +47,7 @@ func TestIsInvalidErr(t *testing.T) {
 }
 
 func TestValidate(t *testing.T) {
+	invalidExpiration := int64(-1)
 	cases := []struct {
 		l       models.CVEWhitelist
 		noError bool
@@ -91,6 +92,14 @@ func TestValidate(t *testing.T) {
 			},
 			noError: false,
 		},
+		{
+			l: models.CVEWhitelist{
+				Items: []models.CVEWhitelistItem{
+					{CVEID: "CVE-2014-456132", ExpiresAt: &invalidExpiration},
+				},
+			},
+			noError: false,
+		},
 	}
 	for n, c := range cases {
 		t.Logf("Executing TestValidate case: %d\n", n)
@@ -101,3 +110,101 @@ func TestValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateJustification(t *testing.T) {
+	expiration := int64(4411494000)
+	existing := &models.CVEWhitelist{
+		Items: []models.CVEWhitelistItem{
+			{CVEID: "CVE-2014-456132"},
+			{CVEID: "CVE-2014-7654321", Justification: "not exploitable", Approver: "admin"},
+		},
+	}
+	cases := []struct {
+		l        models.CVEWhitelist
+		existing *models.CVEWhitelist
+		noError  bool
+	}{
+		{
+			l: models.CVEWhitelist{
+				Items: []models.CVEWhitelistItem{
+					{CVEID: "CVE-2014-456132", Justification: "not exploitable"},
+				},
+			},
+			noError: true,
+		},
+		{
+			l: models.CVEWhitelist{
+				Items: []models.CVEWhitelistItem{
+					{CVEID: "CVE-2014-456132", Justification: "  "},
+				},
+			},
+			noError: false,
+		},
+		// the item saved before the justification is required
+		{
+			l: models.CVEWhitelist{
+				Items: []models.CVEWhitelistItem{
+					{CVEID: "CVE-2014-456132"},
+				},
+			},
+			existing: existing,
+			noError:  true,
+		},
+		// the expiration of the item saved before the justification is required is changed
+		{
+			l: models.CVEWhitelist{
+				Items: []models.CVEWhitelistItem{
+					{CVEID: "CVE-2014-456132", ExpiresAt: &expiration},
+				},
+			},
+			existing: existing,
+			noError:  false,
+		},
+		// the justification can't be removed
+		{
+			l: models.CVEWhitelist{
+				Items: []models.CVEWhitelistItem{
+					{CVEID: "CVE-2014-7654321"},
+				},
+			},
+			existing: existing,
+			noError:  false,
+		},
+	}
+	for n, c := range cases {
+		t.Logf("Executing TestValidateJustification case: %d\n", n)
+		e := ValidateJustification(c.l, c.existing)
+		assert.Equal(t, c.noError, e == nil)
+		if e != nil {
+			assert.True(t, IsInvalidErr(e))
+		}
+	}
+}
+
+func TestFillApprover(t *testing.T) {
+	expiration := int64(4411494000)
+	existing := &models.CVEWhitelist{
+		Items: []models.CVEWhitelistItem{
+			{CVEID: "CVE-2014-456132"},
+			{CVEID: "CVE-2014-7654321", Justification: "not exploitable", Approver: "admin"},
+			{CVEID: "CVE-2014-1234567", Justification: "not exploitable", Approver: "admin"},
+		},
+	}
+	l := &models.CVEWhitelist{
+		Items: []models.CVEWhitelistItem{
+			// unchanged legacy item
+			{CVEID: "CVE-2014-456132", Approver: "someone"},
+			// unchanged item
+			{CVEID: "CVE-2014-7654321", Justification: "not exploitable", Approver: "someone"},
+			// changed item
+			{CVEID: "CVE-2014-1234567", Justification: "not exploitable", ExpiresAt: &expiration, Approver: "admin"},
+			// new item
+			{CVEID: "CVE-2019-0001", Justification: "not exploitable", Approver: "someone"},
+		},
+	}
+	FillApprover(l, existing, "user")
+	assert.Equal(t, "", l.Items[0].Approver)
+	assert.Equal(t, "admin", l.Items[1].Approver)
+	assert.Equal(t, "user", l.Items[2].Approver)
+	assert.Equal(t, "user", l.Items[3].Approver)
+}
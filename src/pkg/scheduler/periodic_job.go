@@ -16,6 +16,7 @@ package scheduler
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/goharbor/harbor/src/jobservice/job"
 )
@@ -39,6 +40,11 @@ func (pj *PeriodicJob) ShouldRetry() bool {
 	return true
 }
 
+// MaxRuntime of the job
+func (pj *PeriodicJob) MaxRuntime() time.Duration {
+	return 0
+}
+
 // Validate the parameters
 func (pj *PeriodicJob) Validate(params job.Parameters) error {
 	return nil
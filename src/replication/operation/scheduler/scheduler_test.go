@@ -1,7 +1,11 @@
 package scheduler
 
 import (
+	"context"
 	"encoding/json"
+	"io"
+	"io/ioutil"
+	"strings"
 	"testing"
 
 	"github.com/goharbor/harbor/src/common/job/models"
@@ -22,12 +26,18 @@ func (client TestClient) SubmitJob(*models.JobData) (string, error) {
 func (client TestClient) GetJobLog(uuid string) ([]byte, error) {
 	return []byte("job log"), nil
 }
+func (client TestClient) FollowJobLog(ctx context.Context, uuid string, acceptEvents bool) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader("job log")), nil
+}
 func (client TestClient) PostAction(uuid, action string) error {
 	return nil
 }
 func (client TestClient) GetExecutions(uuid string) ([]job.Stats, error) {
 	return nil, nil
 }
+func (client TestClient) GetJob(uuid string) (*job.Stats, error) {
+	return nil, nil
+}
 
 func TestPreprocess(t *testing.T) {
 	items, err := generateData()
@@ -15,7 +15,9 @@
 package scheduler
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"testing"
 
 	"github.com/goharbor/harbor/src/common/job/models"
@@ -48,6 +50,9 @@ func (f *fakedJobserviceClient) GetJobLog(uuid string) ([]byte, error) {
 	f.stopped = true
 	return nil, nil
 }
+func (f *fakedJobserviceClient) FollowJobLog(ctx context.Context, uuid string, acceptEvents bool) (io.ReadCloser, error) {
+	return nil, nil
+}
 func (f *fakedJobserviceClient) PostAction(uuid, action string) error {
 	f.stopped = true
 	return nil
@@ -56,6 +61,9 @@ func (f *fakedJobserviceClient) GetExecutions(uuid string) ([]job.Stats, error)
 	f.stopped = true
 	return nil, nil
 }
+func (f *fakedJobserviceClient) GetJob(uuid string) (*job.Stats, error) {
+	return nil, nil
+}
 
 type fakedScheduleJobDAO struct {
 	idCounter int64
@@ -1,8 +1,12 @@
 package job
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"math/rand"
+	"strings"
 
 	"github.com/goharbor/harbor/src/common/http"
 	"github.com/goharbor/harbor/src/common/job/models"
@@ -25,6 +29,17 @@ func (mjc *MockJobClient) GetJobLog(uuid string) ([]byte, error) {
 	return nil, &http.Error{404, "not Found"}
 }
 
+// FollowJobLog ...
+func (mjc *MockJobClient) FollowJobLog(ctx context.Context, uuid string, acceptEvents bool) (io.ReadCloser, error) {
+	if uuid == "500" {
+		return nil, &http.Error{Code: 500, Message: "server side error"}
+	}
+	if mjc.validUUID(uuid) {
+		return ioutil.NopCloser(strings.NewReader("some log")), nil
+	}
+	return nil, &http.Error{Code: 404, Message: "not Found"}
+}
+
 // SubmitJob ...
 func (mjc *MockJobClient) SubmitJob(data *models.JobData) (string, error) {
 	uuid := fmt.Sprintf("u-%d", rand.Int())
@@ -48,6 +63,19 @@ func (mjc *MockJobClient) GetExecutions(uuid string) ([]job.Stats, error) {
 	return nil, nil
 }
 
+// GetJob ...
+func (mjc *MockJobClient) GetJob(uuid string) (*job.Stats, error) {
+	if mjc.validUUID(uuid) {
+		return &job.Stats{
+			Info: &job.StatsInfo{
+				JobID:  uuid,
+				Status: job.RunningStatus.String(),
+			},
+		}, nil
+	}
+	return nil, &http.Error{Code: 404, Message: "not Found"}
+}
+
 func (mjc *MockJobClient) validUUID(uuid string) bool {
 	for _, u := range mjc.JobUUID {
 		if uuid == u {